@@ -0,0 +1,81 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/elastic/package-spec/v3/code/go/pkg/spec"
+)
+
+func runDiff(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	pkgType := flags.String("type", "integration", "package type: integration, input or content")
+	format := flags.String("format", formatText, "output format: text or json")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: package-spec diff [flags] <from spec version> <to spec version>")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Flags:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return exitUsage
+	}
+	if *format != formatText && *format != formatJSON {
+		fmt.Fprintf(stderr, "unknown output format %q\n", *format)
+		return exitUsage
+	}
+	var versions [2]semver.Version
+	for i, arg := range flags.Args() {
+		version, err := semver.NewVersion(arg)
+		if err != nil {
+			fmt.Fprintf(stderr, "invalid spec version %q: %v\n", arg, err)
+			return exitUsage
+		}
+		versions[i] = *version
+	}
+
+	changes, err := spec.Diff(*pkgType, versions[0], versions[1])
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	if *format == formatJSON {
+		if changes == nil {
+			changes = []spec.Change{}
+		}
+		d, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			fmt.Fprintf(stderr, "failed to encode changes: %v\n", err)
+			return exitInternal
+		}
+		fmt.Fprintln(stdout, string(d))
+		return exitOK
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintf(stdout, "no changes for %s packages between spec %s and %s\n", *pkgType, &versions[0], &versions[1])
+		return exitOK
+	}
+	for _, change := range changes {
+		fmt.Fprintln(stdout, change)
+	}
+	return exitOK
+}
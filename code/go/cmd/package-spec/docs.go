@@ -0,0 +1,123 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+
+	packagespec "github.com/elastic/package-spec/v3"
+	"github.com/elastic/package-spec/v3/code/go/pkg/spec"
+)
+
+func runDocs(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("docs", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", string(spec.DocsFormatMarkdown), "documentation format: markdown or html")
+	outDir := flags.String("out", "", "directory where the documentation is written")
+	check := flags.Bool("check", false, "check that the documentation in the output directory is up to date, without writing it")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: package-spec docs [flags] [<spec version>]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "The documentation is generated for the latest version in the spec changelog by default.")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Flags:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() > 1 || *outDir == "" {
+		flags.Usage()
+		return exitUsage
+	}
+	docsFormat := spec.DocsFormat(*format)
+	if docsFormat != spec.DocsFormatMarkdown && docsFormat != spec.DocsFormatHTML {
+		fmt.Fprintf(stderr, "unknown documentation format %q\n", *format)
+		return exitUsage
+	}
+
+	var version *semver.Version
+	if flags.NArg() == 1 {
+		v, err := semver.NewVersion(flags.Arg(0))
+		if err != nil {
+			fmt.Fprintf(stderr, "invalid spec version %q: %v\n", flags.Arg(0), err)
+			return exitUsage
+		}
+		version = v
+	} else {
+		v, err := latestVersion()
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitInternal
+		}
+		version = v
+	}
+
+	if *check {
+		stale, err := spec.StaleDocs(*outDir, *version, docsFormat)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitInternal
+		}
+		if len(stale) > 0 {
+			fmt.Fprintf(stdout, "documentation in %s is outdated for spec %s, regenerate it with \"package-spec docs -out %s\":\n", *outDir, version, *outDir)
+			for _, name := range stale {
+				fmt.Fprintf(stdout, "  %s\n", name)
+			}
+			return exitInvalid
+		}
+		fmt.Fprintf(stdout, "documentation in %s is up to date for spec %s\n", *outDir, version)
+		return exitOK
+	}
+
+	docs, err := spec.Docs(*version, docsFormat)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	for _, name := range slices.Sorted(maps.Keys(docs)) {
+		path := filepath.Join(*outDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitInternal
+		}
+		if err := os.WriteFile(path, docs[name], 0o644); err != nil {
+			fmt.Fprintf(stderr, "failed to write documentation: %v\n", err)
+			return exitInternal
+		}
+	}
+
+	fmt.Fprintf(stdout, "wrote %d pages for spec %s to %s\n", len(docs), version, *outDir)
+	return exitOK
+}
+
+// latestVersion returns the latest version of the spec in the changelog, without
+// its prerelease suffix, as versions under development are referenced.
+func latestVersion() (*semver.Version, error) {
+	versions, err := packagespec.VersionsInChangelog()
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, errors.New("no version found in the spec changelog")
+	}
+	latest, err := versions[0].SetPrerelease("")
+	if err != nil {
+		return nil, err
+	}
+	return &latest, nil
+}
@@ -0,0 +1,41 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
+)
+
+func runExplain(args []string, stdout, stderr io.Writer) int {
+	if len(args) != 1 {
+		fmt.Fprintln(stderr, "Usage: package-spec explain <code>")
+		return exitUsage
+	}
+
+	info, found := specerrors.LookupCode(args[0])
+	if !found {
+		fmt.Fprintf(stderr, "unknown validation code %q\n", args[0])
+		return exitUsage
+	}
+
+	fmt.Fprintf(stdout, "%s - %s\n", info.Code, info.Title)
+	if info.Since != "" {
+		fmt.Fprintf(stdout, "Available since %s\n", info.Since)
+	}
+	if info.Description != "" {
+		fmt.Fprintf(stdout, "\n%s\n", info.Description)
+	}
+	for _, example := range info.Examples {
+		fmt.Fprintln(stdout)
+		for _, line := range strings.Split(strings.TrimSuffix(example, "\n"), "\n") {
+			fmt.Fprintf(stdout, "    %s\n", line)
+		}
+	}
+	return exitOK
+}
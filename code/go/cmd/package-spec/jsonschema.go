@@ -0,0 +1,83 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/elastic/package-spec/v3/code/go/pkg/spec"
+)
+
+// schemasIndexFile is the name of the file with the package files matched by
+// each schema, in the format of the yaml.schemas setting of yaml-language-server.
+const schemasIndexFile = "schemas.json"
+
+func runJSONSchema(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("jsonschema", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	pkgType := flags.String("type", "integration", "package type: integration, input or content")
+	outDir := flags.String("out", "", "directory where the schemas are written")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: package-spec jsonschema [flags] <spec version>")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Flags:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() != 1 || *outDir == "" {
+		flags.Usage()
+		return exitUsage
+	}
+	version, err := semver.NewVersion(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "invalid spec version %q: %v\n", flags.Arg(0), err)
+		return exitUsage
+	}
+
+	schemas, err := spec.JSONSchemas(*version, *pkgType)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitInternal
+	}
+	index := make(map[string][]string, len(schemas))
+	for _, schema := range schemas {
+		name := schema.Name + ".json"
+		if err := os.WriteFile(filepath.Join(*outDir, name), append(schema.Schema, '\n'), 0o644); err != nil {
+			fmt.Fprintf(stderr, "failed to write schema: %v\n", err)
+			return exitInternal
+		}
+		index["./"+name] = schema.Files
+	}
+	d, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to encode schemas index: %v\n", err)
+		return exitInternal
+	}
+	if err := os.WriteFile(filepath.Join(*outDir, schemasIndexFile), append(d, '\n'), 0o644); err != nil {
+		fmt.Fprintf(stderr, "failed to write schemas index: %v\n", err)
+		return exitInternal
+	}
+
+	fmt.Fprintf(stdout, "wrote %d schemas for %s packages of spec %s to %s\n", len(schemas), *pkgType, version, *outDir)
+	return exitOK
+}
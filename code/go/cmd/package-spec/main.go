@@ -0,0 +1,115 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Command package-spec validates packages against the package specification.
+//
+// Usage:
+//
+//	package-spec validate [flags] <package path or zip>...
+//	package-spec versions
+//	package-spec explain <code>
+//	package-spec jsonschema [flags] <spec version>
+//	package-spec diff [flags] <from spec version> <to spec version>
+//	package-spec migrate [flags] <package path>...
+//	package-spec docs [flags] [<spec version>]
+//
+// The exit code is 0 when all packages are valid, 1 when some package is
+// invalid, 2 on usage errors, and 3 when validation could not be completed.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Exit codes returned by the command.
+const (
+	exitOK       = 0
+	exitInvalid  = 1
+	exitUsage    = 2
+	exitInternal = 3
+)
+
+type command struct {
+	name        string
+	description string
+	run         func(args []string, stdout, stderr io.Writer) int
+}
+
+var commands = []command{
+	{
+		name:        "validate",
+		description: "Validate packages against the package specification",
+		run:         runValidate,
+	},
+	{
+		name:        "versions",
+		description: "List the versions of the package specification",
+		run:         runVersions,
+	},
+	{
+		name:        "explain",
+		description: "Describe a validation code",
+		run:         runExplain,
+	},
+	{
+		name:        "jsonschema",
+		description: "Generate the JSON Schemas of the files of packages",
+		run:         runJSONSchema,
+	},
+	{
+		name:        "diff",
+		description: "Show the differences between two versions of the package specification",
+		run:         runDiff,
+	},
+	{
+		name:        "migrate",
+		description: "Migrate packages to a newer version of the package specification",
+		run:         runMigrate,
+	},
+	{
+		name:        "docs",
+		description: "Generate the reference documentation of the package specification",
+		run:         runDocs,
+	},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		printUsage(stdout)
+		return exitOK
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdout, stderr)
+		}
+	}
+
+	fmt.Fprintf(stderr, "unknown command %q\n\n", args[0])
+	printUsage(stderr)
+	return exitUsage
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: package-spec <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.description)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Exit codes: 0 valid, 1 invalid package, 2 usage error, 3 internal failure.")
+}
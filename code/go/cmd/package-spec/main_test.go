@@ -0,0 +1,431 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/package-spec/v3/code/go/pkg/spec"
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
+)
+
+var testPackagesPath = filepath.Join("..", "..", "..", "..", "test", "packages")
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		title          string
+		args           []string
+		expectedCode   int
+		expectedStdout string
+		expectedStderr string
+	}{
+		{
+			title:          "no command",
+			args:           nil,
+			expectedCode:   exitUsage,
+			expectedStderr: "Usage: package-spec",
+		},
+		{
+			title:          "unknown command",
+			args:           []string{"foo"},
+			expectedCode:   exitUsage,
+			expectedStderr: `unknown command "foo"`,
+		},
+		{
+			title:          "help",
+			args:           []string{"help"},
+			expectedCode:   exitOK,
+			expectedStdout: "Usage: package-spec",
+		},
+		{
+			title:          "valid package",
+			args:           []string{"validate", filepath.Join(testPackagesPath, "good_v3")},
+			expectedCode:   exitOK,
+			expectedStdout: "good_v3: valid",
+		},
+		{
+			title:          "invalid package",
+			args:           []string{"validate", filepath.Join(testPackagesPath, "bad_pipeline_tags")},
+			expectedCode:   exitInvalid,
+			expectedStdout: "missing required tag (SVR00006)",
+		},
+		{
+			title:          "package filtered with validation.yml",
+			args:           []string{"validate", filepath.Join(testPackagesPath, "skip_pipeline_rename_validation")},
+			expectedCode:   exitOK,
+			expectedStdout: "skip_pipeline_rename_validation: valid",
+		},
+		{
+			title:          "package not filtered",
+			args:           []string{"validate", "-no-filter", filepath.Join(testPackagesPath, "skip_pipeline_rename_validation")},
+			expectedCode:   exitInvalid,
+			expectedStdout: "(JSE00001)",
+		},
+		{
+			title:          "invalid package with concurrency",
+			args:           []string{"validate", "-concurrency", "4", filepath.Join(testPackagesPath, "bad_pipeline_tags")},
+			expectedCode:   exitInvalid,
+			expectedStdout: "missing required tag (SVR00006)",
+		},
+		{
+			title:          "package with used exclusions",
+			args:           []string{"validate", "-unused-exclusions", filepath.Join(testPackagesPath, "good_scoped_exclusions")},
+			expectedCode:   exitOK,
+			expectedStdout: "valid",
+		},
+		{
+			title:          "package with warnings",
+			args:           []string{"validate", "-min-severity", "warning", filepath.Join(testPackagesPath, "visualizations_by_reference")},
+			expectedCode:   exitOK,
+			expectedStdout: "(SVR00004)",
+		},
+		{
+			title:          "invalid minimum severity",
+			args:           []string{"validate", "-min-severity", "foo", filepath.Join(testPackagesPath, "good_v3")},
+			expectedCode:   exitUsage,
+			expectedStderr: `invalid minimum severity "foo"`,
+		},
+		{
+			title:          "missing package",
+			args:           []string{"validate", filepath.Join(testPackagesPath, "not_found")},
+			expectedCode:   exitInternal,
+			expectedStderr: "validation failed",
+		},
+		{
+			title:          "invalid mode",
+			args:           []string{"validate", "-mode", "foo", filepath.Join(testPackagesPath, "good_v3")},
+			expectedCode:   exitUsage,
+			expectedStderr: `invalid validation mode "foo"`,
+		},
+		{
+			title:          "invalid format",
+			args:           []string{"validate", "-format", "foo", filepath.Join(testPackagesPath, "good_v3")},
+			expectedCode:   exitUsage,
+			expectedStderr: `unknown output format "foo"`,
+		},
+		{
+			title:          "validate without packages",
+			args:           []string{"validate"},
+			expectedCode:   exitUsage,
+			expectedStderr: "Usage: package-spec validate",
+		},
+		{
+			title:          "update baseline without baseline",
+			args:           []string{"validate", "-update-baseline", filepath.Join(testPackagesPath, "good_v3")},
+			expectedCode:   exitUsage,
+			expectedStderr: "-update-baseline requires -baseline",
+		},
+		{
+			title:          "versions",
+			args:           []string{"versions"},
+			expectedCode:   exitOK,
+			expectedStdout: "\n3.0.0\n",
+		},
+		{
+			title:          "explain",
+			args:           []string{"explain", "SVR00006"},
+			expectedCode:   exitOK,
+			expectedStdout: "SVR00006 - Processor tag is required",
+		},
+		{
+			title:          "explain with examples",
+			args:           []string{"explain", "SVR00008"},
+			expectedCode:   exitOK,
+			expectedStdout: "\n    on_failure:\n      - set:\n          field: event.kind\n",
+		},
+		{
+			title:          "jsonschema without version",
+			args:           []string{"jsonschema", "-out", "schemas"},
+			expectedCode:   exitUsage,
+			expectedStderr: "Usage: package-spec jsonschema",
+		},
+		{
+			title:          "jsonschema unknown version",
+			args:           []string{"jsonschema", "-out", "schemas", "9999.0.0"},
+			expectedCode:   exitUsage,
+			expectedStderr: "could not load specification for version [9999.0.0]",
+		},
+		{
+			title:          "diff",
+			args:           []string{"diff", "3.0.0", "3.6.0"},
+			expectedCode:   exitOK,
+			expectedStdout: "kibana/slo_template: folder added (added)\n",
+		},
+		{
+			title:          "diff same version",
+			args:           []string{"diff", "-type", "input", "3.6.0", "3.6.0"},
+			expectedCode:   exitOK,
+			expectedStdout: "no changes for input packages between spec 3.6.0 and 3.6.0",
+		},
+		{
+			title:          "diff without versions",
+			args:           []string{"diff", "3.6.0"},
+			expectedCode:   exitUsage,
+			expectedStderr: "Usage: package-spec diff",
+		},
+		{
+			title:          "diff invalid format",
+			args:           []string{"diff", "-format", "sarif", "3.0.0", "3.6.0"},
+			expectedCode:   exitUsage,
+			expectedStderr: `unknown output format "sarif"`,
+		},
+		{
+			title:          "migrate without packages",
+			args:           []string{"migrate", "-to", "3.0.0"},
+			expectedCode:   exitUsage,
+			expectedStderr: "Usage: package-spec migrate",
+		},
+		{
+			title:          "migrate dry run",
+			args:           []string{"migrate", "-dry-run", "-to", "3.0.0", filepath.Join(testPackagesPath, "good_v2")},
+			expectedCode:   exitInvalid,
+			expectedStdout: "good_v2: migrated from 2.12.0 to 3.0.0 (dry run)\n",
+		},
+		{
+			title:          "migrate to older version",
+			args:           []string{"migrate", "-dry-run", "-to", "2.0.0", filepath.Join(testPackagesPath, "good_v3")},
+			expectedCode:   exitInternal,
+			expectedStderr: "is newer than [2.0.0]",
+		},
+		{
+			title:          "explain unknown code",
+			args:           []string{"explain", "FOO00001"},
+			expectedCode:   exitUsage,
+			expectedStderr: `unknown validation code "FOO00001"`,
+		},
+		{
+			title:          "docs without output directory",
+			args:           []string{"docs"},
+			expectedCode:   exitUsage,
+			expectedStderr: "Usage: package-spec docs",
+		},
+		{
+			title:          "docs with unknown format",
+			args:           []string{"docs", "-format", "pdf", "-out", "docs"},
+			expectedCode:   exitUsage,
+			expectedStderr: `unknown documentation format "pdf"`,
+		},
+		{
+			title:          "docs are up to date",
+			args:           []string{"docs", "-check", "-out", filepath.Join("..", "..", "..", "..", "docs")},
+			expectedCode:   exitOK,
+			expectedStdout: "is up to date",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(c.args, &stdout, &stderr)
+			assert.Equal(t, c.expectedCode, code, "stdout: %s\nstderr: %s", stdout.String(), stderr.String())
+			if c.expectedStdout != "" {
+				assert.Contains(t, stdout.String(), c.expectedStdout)
+			}
+			if c.expectedStderr != "" {
+				assert.Contains(t, stderr.String(), c.expectedStderr)
+			}
+		})
+	}
+}
+
+func TestValidateJSONFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{
+		"validate", "-format", "json",
+		filepath.Join(testPackagesPath, "good_v3"),
+		filepath.Join(testPackagesPath, "bad_pipeline_tags"),
+	}, &stdout, &stderr)
+	require.Equal(t, exitInvalid, code, stderr.String())
+
+	var report struct {
+		Packages []struct {
+			Path   string `json:"path"`
+			Valid  bool   `json:"valid"`
+			Errors []struct {
+				Code string `json:"code"`
+				File string `json:"file"`
+			} `json:"errors"`
+		} `json:"packages"`
+	}
+	err := json.Unmarshal(stdout.Bytes(), &report)
+	require.NoError(t, err)
+	require.Len(t, report.Packages, 2)
+	assert.True(t, report.Packages[0].Valid)
+	assert.False(t, report.Packages[1].Valid)
+	require.NotEmpty(t, report.Packages[1].Errors)
+	assert.Equal(t, "SVR00006", report.Packages[1].Errors[0].Code)
+	assert.True(t, strings.HasSuffix(report.Packages[1].Errors[0].File, "default.yml"))
+}
+
+func TestValidateBaseline(t *testing.T) {
+	baselinePath := filepath.Join(t.TempDir(), "baseline.yml")
+	pkgPath := filepath.Join(testPackagesPath, "bad_pipeline_tags")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"validate", "-baseline", baselinePath, pkgPath}, &stdout, &stderr)
+	require.Equal(t, exitInternal, code)
+	assert.Contains(t, stderr.String(), "failed to load baseline")
+
+	stdout.Reset()
+	stderr.Reset()
+	code = run([]string{"validate", "-baseline", baselinePath, "-update-baseline", pkgPath}, &stdout, &stderr)
+	require.Equal(t, exitOK, code, stderr.String())
+	assert.Contains(t, stdout.String(), "bad_pipeline_tags: valid")
+
+	baseline, err := specerrors.LoadBaseline(baselinePath)
+	require.NoError(t, err)
+	require.Len(t, baseline.Packages["bad_pipeline_tags"], 2)
+	assert.Equal(t, "SVR00006", baseline.Packages["bad_pipeline_tags"][0].Code)
+
+	// Findings in the baseline are not reported, new findings are.
+	stdout.Reset()
+	stderr.Reset()
+	code = run([]string{"validate", "-baseline", baselinePath,
+		pkgPath,
+		filepath.Join(testPackagesPath, "bad_pipeline_on_failure"),
+	}, &stdout, &stderr)
+	require.Equal(t, exitInvalid, code, stderr.String())
+	assert.Contains(t, stdout.String(), "bad_pipeline_tags: valid")
+	assert.Contains(t, stdout.String(), "(SVR00008)")
+}
+
+func TestValidateFix(t *testing.T) {
+	pkgPath := filepath.Join(t.TempDir(), "missing_pipeline_dashes")
+	err := os.CopyFS(pkgPath, os.DirFS(filepath.Join(testPackagesPath, "missing_pipeline_dashes")))
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"validate", "-fix", pkgPath}, &stdout, &stderr)
+	require.Equal(t, exitOK, code, stdout.String())
+	assert.Contains(t, stderr.String(), "missing_pipeline_dashes: applied 1 fixes")
+	assert.Contains(t, stdout.String(), "missing_pipeline_dashes: valid")
+}
+
+func TestValidateZipFilter(t *testing.T) {
+	const name = "skip_pipeline_rename_validation"
+	dir := t.TempDir()
+	err := os.CopyFS(filepath.Join(dir, name), os.DirFS(filepath.Join(testPackagesPath, name)))
+	require.NoError(t, err)
+
+	zipPath := filepath.Join(t.TempDir(), name+".zip")
+	f, err := os.Create(zipPath)
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	require.NoError(t, w.AddFS(os.DirFS(dir)))
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"validate", zipPath}, &stdout, &stderr)
+	assert.Equal(t, exitOK, code, stdout.String())
+	assert.Contains(t, stdout.String(), name+".zip: valid")
+
+	stdout.Reset()
+	stderr.Reset()
+	code = run([]string{"validate", "-no-filter", zipPath}, &stdout, &stderr)
+	assert.Equal(t, exitInvalid, code, stderr.String())
+	assert.Contains(t, stdout.String(), "(JSE00001)")
+}
+
+func TestJSONSchema(t *testing.T) {
+	outDir := filepath.Join(t.TempDir(), "schemas")
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"jsonschema", "-type", "input", "-out", outDir, "3.6.0"}, &stdout, &stderr)
+	require.Equal(t, exitOK, code, stderr.String())
+	assert.Contains(t, stdout.String(), "schemas for input packages of spec 3.6.0")
+
+	d, err := os.ReadFile(filepath.Join(outDir, "schemas.json"))
+	require.NoError(t, err)
+	var index map[string][]string
+	require.NoError(t, json.Unmarshal(d, &index))
+	assert.Equal(t, []string{"manifest.yml"}, index["./input.manifest.json"])
+
+	d, err = os.ReadFile(filepath.Join(outDir, "input.manifest.json"))
+	require.NoError(t, err)
+	assert.True(t, json.Valid(d))
+}
+
+func TestDiffJSONFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"diff", "-format", "json", "3.0.0", "3.6.0"}, &stdout, &stderr)
+	require.Equal(t, exitOK, code, stderr.String())
+
+	var changes []spec.Change
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &changes))
+	assert.Contains(t, changes, spec.Change{
+		Kind:     spec.ChangeEnum,
+		Path:     "changelog.yml",
+		Property: "[].changes[].type",
+		Message:  `values added: ["deprecation"]`,
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	pkgPath := filepath.Join(t.TempDir(), "good_v2")
+	err := os.CopyFS(pkgPath, os.DirFS(filepath.Join(testPackagesPath, "good_v2")))
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"migrate", "-format", "json", "-to", "3.0.0", pkgPath}, &stdout, &stderr)
+	require.Equal(t, exitInvalid, code, stderr.String())
+
+	var reports []struct {
+		Path    string   `json:"path"`
+		To      string   `json:"to"`
+		Applied []string `json:"applied"`
+		Issues  []struct {
+			Migration string `json:"migration"`
+			File      string `json:"file"`
+			Code      string `json:"code"`
+		} `json:"issues"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &reports))
+	require.Len(t, reports, 1)
+	assert.Equal(t, "3.0.0", reports[0].To)
+	assert.Equal(t, []string{"expand-dotted-keys", "profiles-data-stream-type"}, reports[0].Applied)
+	require.NotEmpty(t, reports[0].Issues)
+	assert.Equal(t, "expand-dotted-keys", reports[0].Issues[0].Migration)
+	assert.Equal(t, "data_stream/foo/manifest.yml", reports[0].Issues[0].File)
+	for _, issue := range reports[0].Issues[1:] {
+		assert.Equal(t, "validation", issue.Migration)
+		assert.NotEmpty(t, issue.Code)
+	}
+
+	stdout.Reset()
+	code = run([]string{"migrate", "-to", "3.0.0", pkgPath}, &stdout, &stderr)
+	require.Equal(t, exitOK, code, stderr.String())
+	assert.Contains(t, stdout.String(), "good_v2: already at 3.0.0")
+}
+
+func TestDocs(t *testing.T) {
+	dir := t.TempDir()
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"docs", "-format", "html", "-out", dir, "3.6.0"}, &stdout, &stderr)
+	require.Equal(t, exitOK, code, stderr.String())
+	assert.Contains(t, stdout.String(), "wrote 4 pages for spec 3.6.0")
+	assert.FileExists(t, filepath.Join(dir, "validations.html"))
+	assert.FileExists(t, filepath.Join(dir, "reference", "integration.html"))
+
+	stdout.Reset()
+	code = run([]string{"docs", "-check", "-format", "html", "-out", dir, "3.6.0"}, &stdout, &stderr)
+	require.Equal(t, exitOK, code, stderr.String())
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "reference", "input.html")))
+	stdout.Reset()
+	code = run([]string{"docs", "-check", "-format", "html", "-out", dir, "3.7.0"}, &stdout, &stderr)
+	require.Equal(t, exitInvalid, code, stderr.String())
+	assert.Contains(t, stdout.String(), "is outdated for spec 3.7.0")
+	assert.Contains(t, stdout.String(), "reference/input.html")
+}
@@ -0,0 +1,156 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/Masterminds/semver/v3"
+
+	spec "github.com/elastic/package-spec/v3"
+	"github.com/elastic/package-spec/v3/code/go/pkg/migrate"
+)
+
+type migrateReport struct {
+	Path string `json:"path"`
+	*migrate.Result
+}
+
+func runMigrate(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	to := flags.String("to", "", "spec version to migrate to, the latest released version by default")
+	dryRun := flags.Bool("dry-run", false, "report the changes without writing them")
+	format := flags.String("format", formatText, "output format: text or json")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: package-spec migrate [flags] <package path>...")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Flags:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	if *format != formatText && *format != formatJSON {
+		fmt.Fprintf(stderr, "unknown output format %q\n", *format)
+		return exitUsage
+	}
+
+	var target *semver.Version
+	if *to != "" {
+		version, err := semver.NewVersion(*to)
+		if err != nil {
+			fmt.Fprintf(stderr, "invalid spec version %q: %v\n", *to, err)
+			return exitUsage
+		}
+		target = version
+	} else {
+		version, err := latestReleasedVersion()
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitInternal
+		}
+		target = version
+	}
+
+	m, err := migrate.New(migrate.WithDryRun(*dryRun))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitInternal
+	}
+
+	code := exitOK
+	var reports []migrateReport
+	for _, path := range flags.Args() {
+		result, err := m.Migrate(path, *target)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", path, err)
+			code = exitInternal
+			continue
+		}
+		if len(result.Issues) > 0 && code == exitOK {
+			code = exitInvalid
+		}
+		reports = append(reports, migrateReport{Path: path, Result: result})
+	}
+
+	if *format == formatJSON {
+		if reports == nil {
+			reports = []migrateReport{}
+		}
+		d, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			fmt.Fprintf(stderr, "failed to encode results: %v\n", err)
+			return exitInternal
+		}
+		fmt.Fprintln(stdout, string(d))
+		return code
+	}
+
+	for _, report := range reports {
+		printMigrateReport(stdout, report, *dryRun)
+	}
+	return code
+}
+
+func printMigrateReport(w io.Writer, report migrateReport, dryRun bool) {
+	if len(report.Changes) == 0 && len(report.Issues) == 0 {
+		fmt.Fprintf(w, "%s: already at %s\n", report.Path, report.To)
+		return
+	}
+	suffix := ""
+	if dryRun {
+		suffix = " (dry run)"
+	}
+	fmt.Fprintf(w, "%s: migrated from %s to %s%s\n", report.Path, report.From, report.To, suffix)
+	for _, change := range report.Changes {
+		fmt.Fprintf(w, "  %s: %s [%s]\n", change.File, change.Description, change.Migration)
+	}
+	if len(report.Issues) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%s: %d issues must be migrated manually\n", report.Path, len(report.Issues))
+	for _, issue := range report.Issues {
+		location := issue.File
+		if location == "" {
+			location = "package"
+		}
+		fmt.Fprintf(w, "  %s: %s [%s]\n", location, issue.Message, issue.Migration)
+	}
+}
+
+// latestReleasedVersion returns the latest version of the spec that is not a
+// prerelease.
+func latestReleasedVersion() (*semver.Version, error) {
+	versions, err := spec.VersionsInChangelog()
+	if err != nil {
+		return nil, err
+	}
+	var latest *semver.Version
+	for i := range versions {
+		version := &versions[i]
+		if version.Prerelease() != "" {
+			continue
+		}
+		if latest == nil || version.GreaterThan(latest) {
+			latest = version
+		}
+	}
+	if latest == nil {
+		return nil, errors.New("no released version found in the spec changelog")
+	}
+	return latest, nil
+}
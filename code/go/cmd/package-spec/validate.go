@@ -0,0 +1,319 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package main
+
+import (
+	"archive/zip"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/elastic/package-spec/v3/code/go/pkg/packages"
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
+	"github.com/elastic/package-spec/v3/code/go/pkg/validator"
+)
+
+// Output formats supported by the validate command.
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatSARIF = "sarif"
+	formatJUnit = "junit"
+)
+
+var reportWriters = map[string]func(io.Writer, ...specerrors.PackageReport) error{
+	formatJSON:  specerrors.WriteJSONReport,
+	formatSARIF: specerrors.WriteSARIFReport,
+	formatJUnit: specerrors.WriteJUnitReport,
+}
+
+// maxFixPasses is the maximum number of times fixes are applied to a package.
+// Fixes that overlap with others are applied in later passes.
+const maxFixPasses = 5
+
+// errValidationFailed is returned when a package could not be validated
+// for reasons different to the package being invalid.
+var errValidationFailed = errors.New("validation failed")
+
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	mode := flags.String("mode", string(validator.LegacyMode), "validation mode: legacy, source or build")
+	format := flags.String("format", formatText, "output format: text, json, sarif or junit")
+	warningsAsErrors := flags.Bool("warnings-as-errors", false, "report warnings as errors (defaults to PACKAGE_SPEC_WARNINGS_AS_ERRORS)")
+	minSeverity := flags.String("min-severity", string(specerrors.SeverityError), "minimum severity of the reported errors: error, warning or info")
+	noFilter := flags.Bool("no-filter", false, "ignore the validation.yml file of the packages")
+	concurrency := flags.Int("concurrency", 1, "maximum number of semantic rules run in parallel, 0 to use all CPUs")
+	unusedExclusions := flags.Bool("unused-exclusions", false, "report exclusions in validation.yml that don't exclude any error")
+	baselinePath := flags.String("baseline", "", "baseline file, errors included in it are not reported")
+	updateBaseline := flags.Bool("update-baseline", false, "write the errors found to the baseline file instead of reporting them")
+	fix := flags.Bool("fix", false, "apply the fixes suggested for the errors found in package directories, and report the remaining errors")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: package-spec validate [flags] <package path or zip>...")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Flags:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	if *format != formatText && reportWriters[*format] == nil {
+		fmt.Fprintf(stderr, "unknown output format %q\n", *format)
+		return exitUsage
+	}
+	if *updateBaseline && *baselinePath == "" {
+		fmt.Fprintln(stderr, "-update-baseline requires -baseline")
+		return exitUsage
+	}
+
+	opts := []validator.Option{validator.WithMinimumSeverity(specerrors.Severity(*minSeverity))}
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "warnings-as-errors":
+			opts = append(opts, validator.WithWarningsAsErrors(*warningsAsErrors))
+		case "concurrency":
+			opts = append(opts, validator.WithConcurrency(*concurrency))
+		case "unused-exclusions":
+			opts = append(opts, validator.WithUnusedExclusions(*unusedExclusions))
+		}
+	})
+	v, err := validator.New(validator.Mode(*mode), opts...)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	var baseline *specerrors.Baseline
+	if *baselinePath != "" {
+		baseline, err = specerrors.LoadBaseline(*baselinePath)
+		if errors.Is(err, os.ErrNotExist) && *updateBaseline {
+			baseline, err = specerrors.NewBaseline(), nil
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "failed to load baseline: %v\n", err)
+			return exitInternal
+		}
+	}
+
+	exitCode := exitOK
+	reports := make([]specerrors.PackageReport, 0, flags.NArg())
+	for _, path := range flags.Args() {
+		err := validatePackage(v, path, !*noFilter)
+		if *fix {
+			err = fixPackage(v, path, !*noFilter, err, stderr)
+		}
+		if errors.Is(err, errValidationFailed) {
+			fmt.Fprintln(stderr, err)
+			exitCode = exitInternal
+			continue
+		}
+		if baseline != nil {
+			err = applyBaseline(baseline, path, err, *updateBaseline)
+		}
+		report := specerrors.NewPackageReport(path, err)
+		if !report.Valid() && exitCode == exitOK {
+			exitCode = exitInvalid
+		}
+		reports = append(reports, report)
+	}
+
+	if *updateBaseline {
+		if err := writeBaseline(baseline, *baselinePath); err != nil {
+			fmt.Fprintf(stderr, "failed to write baseline: %v\n", err)
+			return exitInternal
+		}
+	}
+
+	if *format == formatText {
+		for _, report := range reports {
+			if len(report.Errors) == 0 {
+				fmt.Fprintf(stdout, "%s: valid\n", report.Path)
+				continue
+			}
+			fmt.Fprintf(stdout, "%s: %s", report.Path, report.Errors.Error())
+		}
+		return exitCode
+	}
+
+	if err := reportWriters[*format](stdout, reports...); err != nil {
+		fmt.Fprintf(stderr, "failed to write report: %v\n", err)
+		return exitInternal
+	}
+	return exitCode
+}
+
+// validatePackage validates the package in the given path, that can be a
+// directory or a zip file. It returns the validation errors of the package,
+// or an error wrapping errValidationFailed if it could not be validated.
+func validatePackage(v *validator.Validator, path string, filter bool) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errValidationFailed, err)
+	}
+
+	if info.IsDir() {
+		err = validationResult(path, v.ValidateFromPath(path))
+	} else {
+		if !strings.HasSuffix(path, ".zip") {
+			return fmt.Errorf("%w: %s is not a directory or a zip file", errValidationFailed, path)
+		}
+		err = validationResult(path, v.ValidateFromZip(path))
+	}
+	var errs specerrors.ValidationErrors
+	if !filter || !errors.As(err, &errs) {
+		return err
+	}
+
+	config, err := loadConfigFilter(path, info.IsDir())
+	if errors.Is(err, os.ErrNotExist) {
+		return errs
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", errValidationFailed, path, err)
+	}
+	result, err := specerrors.NewFilter(config).Run(errs)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", errValidationFailed, path, err)
+	}
+	return result.Processed
+}
+
+// loadConfigFilter loads the validation.yml file of the package in the given
+// path, that can be a directory or a zip file with the package in its root directory.
+func loadConfigFilter(path string, isDir bool) (*specerrors.ConfigFilter, error) {
+	if isDir {
+		return specerrors.LoadConfigFilter(os.DirFS(path))
+	}
+
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	dirs, err := fs.ReadDir(r, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(dirs) != 1 {
+		return nil, fmt.Errorf("a single directory is expected in zip file, %d found", len(dirs))
+	}
+	subDir, err := fs.Sub(r, dirs[0].Name())
+	if err != nil {
+		return nil, err
+	}
+	return specerrors.LoadConfigFilter(subDir)
+}
+
+// fixPackage applies the fixes suggested for the errors of the package in the
+// given path, and returns the errors found when validating it again. Packages
+// in zip files are not modified.
+func fixPackage(v *validator.Validator, path string, filter bool, err error, stderr io.Writer) error {
+	if strings.HasSuffix(path, ".zip") {
+		return err
+	}
+	for range maxFixPasses {
+		var errs specerrors.ValidationErrors
+		if errors.Is(err, errValidationFailed) || !errors.As(err, &errs) {
+			return err
+		}
+		result, fixErr := specerrors.ApplyFixes(path, errs)
+		if fixErr != nil {
+			return fmt.Errorf("%w: %s: failed to apply fixes: %w", errValidationFailed, path, fixErr)
+		}
+		if len(result.Applied) == 0 {
+			return err
+		}
+		fmt.Fprintf(stderr, "%s: applied %d fixes\n", path, len(result.Applied))
+		err = validatePackage(v, path, filter)
+	}
+	return err
+}
+
+// applyBaseline returns the errors of the package in the given path that are
+// not included in the baseline. If update is true, the errors of the package
+// replace its findings in the baseline, and no error is returned.
+func applyBaseline(baseline *specerrors.Baseline, path string, err error, update bool) error {
+	var errs specerrors.ValidationErrors
+	if err != nil && !errors.As(err, &errs) {
+		return err
+	}
+
+	name := packageName(path)
+	if update {
+		baseline.Set(name, path, errs)
+		return nil
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+
+	filter := specerrors.NewFilter(&specerrors.ConfigFilter{})
+	filter.AddProcessors([]specerrors.Processor{baseline.Processor(name, path)})
+	result, err := filter.Run(errs)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", errValidationFailed, path, err)
+	}
+	return result.Processed
+}
+
+// packageName returns the name of the package in the given path, used to
+// identify its findings in baselines. The base name of the path is used if
+// the package manifest cannot be read.
+func packageName(path string) string {
+	var pkg *packages.Package
+	var err error
+	if strings.HasSuffix(path, ".zip") {
+		pkg, err = packages.LoadFromZip(path)
+	} else {
+		pkg, err = packages.LoadFromPath(path)
+	}
+	if err != nil {
+		return strings.TrimSuffix(filepath.Base(path), ".zip")
+	}
+	defer pkg.Close()
+	if pkg.Name == "" {
+		return strings.TrimSuffix(filepath.Base(path), ".zip")
+	}
+	return pkg.Name
+}
+
+// writeBaseline writes the baseline to the file in the given path.
+func writeBaseline(baseline *specerrors.Baseline, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := baseline.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// validationResult classifies the error returned by the validator, errors
+// that are not validation errors mean that the package could not be validated.
+func validationResult(path string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var errs specerrors.ValidationErrors
+	if errors.As(err, &errs) {
+		return errs
+	}
+	return fmt.Errorf("%w: %s: %w", errValidationFailed, path, err)
+}
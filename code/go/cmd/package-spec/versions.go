@@ -0,0 +1,29 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	spec "github.com/elastic/package-spec/v3"
+)
+
+func runVersions(args []string, stdout, stderr io.Writer) int {
+	if len(args) > 0 {
+		fmt.Fprintln(stderr, "Usage: package-spec versions")
+		return exitUsage
+	}
+
+	versions, err := spec.VersionsInChangelog()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitInternal
+	}
+	for _, version := range versions {
+		fmt.Fprintln(stdout, version.String())
+	}
+	return exitOK
+}
@@ -0,0 +1,63 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package loader
+
+import (
+	"io/fs"
+	"sync"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/elastic/package-spec/v3/code/go/internal/spectypes"
+)
+
+// SpecCache keeps the specs loaded from a filesystem, so they are loaded and
+// compiled only once for each version and package type. It is safe for
+// concurrent use.
+type SpecCache struct {
+	fsys fs.FS
+
+	mutex   sync.Mutex
+	entries map[specCacheKey]*specCacheEntry
+}
+
+type specCacheKey struct {
+	version string
+	pkgType string
+}
+
+type specCacheEntry struct {
+	once sync.Once
+	spec spectypes.ItemSpec
+	err  error
+}
+
+// NewSpecCache creates a cache for the specs in the given filesystem.
+func NewSpecCache(fsys fs.FS) *SpecCache {
+	return &SpecCache{
+		fsys:    fsys,
+		entries: make(map[specCacheKey]*specCacheEntry),
+	}
+}
+
+// LoadSpec returns the spec for the given version and package type, loading it
+// if it was not loaded before. Concurrent calls for the same spec wait for the
+// same load. Errors are also cached.
+func (c *SpecCache) LoadSpec(version semver.Version, pkgType string) (spectypes.ItemSpec, error) {
+	key := specCacheKey{version: version.String(), pkgType: pkgType}
+
+	c.mutex.Lock()
+	entry, found := c.entries[key]
+	if !found {
+		entry = &specCacheEntry{}
+		c.entries[key] = entry
+	}
+	c.mutex.Unlock()
+
+	entry.once.Do(func() {
+		entry.spec, entry.err = LoadSpec(c.fsys, version, pkgType)
+	})
+	return entry.spec, entry.err
+}
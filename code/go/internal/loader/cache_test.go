@@ -0,0 +1,71 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package loader
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	spec "github.com/elastic/package-spec/v3"
+	"github.com/elastic/package-spec/v3/code/go/internal/spectypes"
+)
+
+func TestSpecCache(t *testing.T) {
+	cache := NewSpecCache(spec.FS())
+	version := *semver.MustParse("3.5.0")
+
+	const concurrency = 8
+	specs := make([]spectypes.ItemSpec, concurrency)
+	var wg sync.WaitGroup
+	for i := range concurrency {
+		wg.Go(func() {
+			s, err := cache.LoadSpec(version, "integration")
+			assert.NoError(t, err)
+			specs[i] = s
+		})
+	}
+	wg.Wait()
+
+	require.NotNil(t, specs[0])
+	for _, s := range specs {
+		assert.Same(t, specs[0], s)
+	}
+
+	other, err := cache.LoadSpec(version, "input")
+	require.NoError(t, err)
+	assert.NotSame(t, specs[0], other)
+
+	other, err = cache.LoadSpec(*semver.MustParse("3.4.0"), "integration")
+	require.NoError(t, err)
+	assert.NotSame(t, specs[0], other)
+
+	_, err = cache.LoadSpec(version, "unknown")
+	assert.ErrorContains(t, err, `package type "unknown" not supported`)
+}
+
+func BenchmarkLoadSpec(b *testing.B) {
+	version := *semver.MustParse("3.5.0")
+
+	b.Run("without cache", func(b *testing.B) {
+		for b.Loop() {
+			_, err := LoadSpec(spec.FS(), version, "integration")
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("with cache", func(b *testing.B) {
+		cache := NewSpecCache(spec.FS())
+		_, err := cache.LoadSpec(version, "integration")
+		require.NoError(b, err)
+		for b.Loop() {
+			_, err := cache.LoadSpec(version, "integration")
+			require.NoError(b, err)
+		}
+	})
+}
@@ -0,0 +1,356 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package packages
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/Masterminds/semver/v3"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest is the manifest of a package, as defined in its manifest.yml file.
+type Manifest struct {
+	FormatVersion   string           `yaml:"format_version"`
+	Name            string           `yaml:"name"`
+	Title           string           `yaml:"title"`
+	Version         string           `yaml:"version"`
+	Description     string           `yaml:"description"`
+	Type            string           `yaml:"type"`
+	Categories      []string         `yaml:"categories"`
+	Conditions      Conditions       `yaml:"conditions"`
+	Owner           Owner            `yaml:"owner"`
+	Icons           []Image          `yaml:"icons"`
+	Screenshots     []Image          `yaml:"screenshots"`
+	Requires        Requires         `yaml:"requires"`
+	Vars            []Var            `yaml:"vars"`
+	Sections        []Section        `yaml:"sections"`
+	PolicyTemplates []PolicyTemplate `yaml:"policy_templates"`
+}
+
+// Conditions are the requirements to install a package.
+type Conditions struct {
+	Kibana struct {
+		Version string `yaml:"version"`
+	} `yaml:"kibana"`
+	Elastic struct {
+		Subscription string `yaml:"subscription"`
+	} `yaml:"elastic"`
+}
+
+// noDottedConditionsVersion is the first version of the spec that doesn't
+// allow conditions defined with dotted keys.
+var noDottedConditionsVersion = semver.MustParse("3.0.0")
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for Manifest. Conditions
+// defined with dotted keys, as "kibana.version", are only read for packages with a
+// format_version before 3.0.0, the versions of the spec that allow them.
+func (m *Manifest) UnmarshalYAML(node *yaml.Node) error {
+	type notManifest Manifest
+	if err := node.Decode((*notManifest)(m)); err != nil {
+		return err
+	}
+
+	formatVersion, err := semver.NewVersion(m.FormatVersion)
+	if err != nil || !formatVersion.LessThan(noDottedConditionsVersion) {
+		return nil
+	}
+
+	var dotted struct {
+		Conditions struct {
+			KibanaVersion       string `yaml:"kibana.version"`
+			ElasticSubscription string `yaml:"elastic.subscription"`
+		} `yaml:"conditions"`
+	}
+	if err := node.Decode(&dotted); err != nil {
+		return err
+	}
+	if dotted.Conditions.KibanaVersion != "" {
+		m.Conditions.Kibana.Version = dotted.Conditions.KibanaVersion
+	}
+	if dotted.Conditions.ElasticSubscription != "" {
+		m.Conditions.Elastic.Subscription = dotted.Conditions.ElasticSubscription
+	}
+	return nil
+}
+
+// Owner is the owner of a package.
+type Owner struct {
+	Github string `yaml:"github"`
+	Type   string `yaml:"type"`
+}
+
+// Image is an icon or a screenshot of a package.
+type Image struct {
+	Src   string `yaml:"src"`
+	Title string `yaml:"title"`
+	Size  string `yaml:"size"`
+	Type  string `yaml:"type"`
+}
+
+// Requires contains the packages required by a package.
+type Requires struct {
+	Input   []PackageRequirement `yaml:"input"`
+	Content []PackageRequirement `yaml:"content"`
+}
+
+// PackageRequirement is a reference to a required package.
+type PackageRequirement struct {
+	Package string `yaml:"package"`
+	Version string `yaml:"version"`
+}
+
+// PolicyTemplate is a policy template of a package. Integration packages define
+// inputs, input packages define a single input.
+type PolicyTemplate struct {
+	Name               string                `yaml:"name"`
+	Title              string                `yaml:"title"`
+	Description        string                `yaml:"description"`
+	DataStreams        []string              `yaml:"data_streams"`
+	Categories         []string              `yaml:"categories"`
+	Inputs             []PolicyTemplateInput `yaml:"inputs"`
+	Vars               []Var                 `yaml:"vars"`
+	Sections           []Section             `yaml:"sections"`
+	DeploymentModes    map[string]any        `yaml:"deployment_modes"`
+	Input              string                `yaml:"input"`
+	Type               string                `yaml:"type"`
+	TemplatePath       string                `yaml:"template_path"`
+	DynamicSignalTypes bool                  `yaml:"dynamic_signal_types"`
+}
+
+// PolicyTemplateInput is an input of a policy template of an integration package.
+type PolicyTemplateInput struct {
+	Type         string           `yaml:"type"`
+	Title        string           `yaml:"title"`
+	Description  string           `yaml:"description"`
+	Package      string           `yaml:"package"`
+	TemplatePath string           `yaml:"template_path"`
+	Vars         []Var            `yaml:"vars"`
+	Sections     []Section        `yaml:"sections"`
+	RequiredVars map[string][]Var `yaml:"required_vars"`
+}
+
+// Var is a variable that can be configured in a package policy.
+type Var struct {
+	Name        string  `yaml:"name"`
+	Type        string  `yaml:"type"`
+	Title       string  `yaml:"title"`
+	Description string  `yaml:"description"`
+	Multi       bool    `yaml:"multi"`
+	Required    bool    `yaml:"required"`
+	ShowUser    bool    `yaml:"show_user"`
+	Secret      *bool   `yaml:"secret"`
+	Default     any     `yaml:"default"`
+	Section     string  `yaml:"section"`
+	MinDuration *string `yaml:"min_duration"`
+	MaxDuration *string `yaml:"max_duration"`
+
+	// Position is the position of the definition of the variable in its file.
+	Position Position `yaml:"-"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for Var, to keep the
+// position of the variable in the file.
+func (v *Var) UnmarshalYAML(node *yaml.Node) error {
+	type notVar Var
+	if err := node.Decode((*notVar)(v)); err != nil {
+		return err
+	}
+	v.Position = Position{Line: node.Line, Column: node.Column}
+	return nil
+}
+
+// Position is a position in a file.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Section groups variables in the configuration forms.
+type Section struct {
+	Name        string `yaml:"name"`
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+}
+
+// DataStreamManifest is the manifest of a data stream, as defined in its manifest.yml file.
+type DataStreamManifest struct {
+	Title           string   `yaml:"title"`
+	Type            string   `yaml:"type"`
+	Dataset         string   `yaml:"dataset"`
+	DatasetIsPrefix bool     `yaml:"dataset_is_prefix"`
+	ILMPolicy       string   `yaml:"ilm_policy"`
+	Release         string   `yaml:"release"`
+	Hidden          bool     `yaml:"hidden"`
+	Categories      []string `yaml:"categories"`
+	Streams         []Stream `yaml:"streams"`
+}
+
+// Stream is a stream of a data stream.
+type Stream struct {
+	Input              string           `yaml:"input"`
+	Title              string           `yaml:"title"`
+	Description        string           `yaml:"description"`
+	Package            string           `yaml:"package"`
+	TemplatePath       string           `yaml:"template_path"`
+	Enabled            *bool            `yaml:"enabled"`
+	DynamicSignalTypes bool             `yaml:"dynamic_signal_types"`
+	Vars               []Var            `yaml:"vars"`
+	Sections           []Section        `yaml:"sections"`
+	RequiredVars       map[string][]Var `yaml:"required_vars"`
+}
+
+// Field is a field definition. Fields of type group contain other fields.
+type Field struct {
+	Name        string  `yaml:"name"`
+	Type        string  `yaml:"type"`
+	Description string  `yaml:"description"`
+	Unit        string  `yaml:"unit"`
+	DateFormat  string  `yaml:"date_format"`
+	MetricType  string  `yaml:"metric_type"`
+	Dimension   bool    `yaml:"dimension"`
+	External    string  `yaml:"external"`
+	Fields      []Field `yaml:"fields"`
+
+	Runtime RuntimeField `yaml:"runtime"`
+}
+
+// RuntimeField is the runtime setting of a field. It can be a boolean, or a
+// script that enables the runtime field.
+type RuntimeField struct {
+	enabled bool
+	script  string
+}
+
+// Ensure RuntimeField implements these interfaces.
+var (
+	_ json.Unmarshaler = new(RuntimeField)
+	_ yaml.Unmarshaler = new(RuntimeField)
+)
+
+// Enabled returns true if the field is a runtime field.
+func (r RuntimeField) Enabled() bool {
+	return r.enabled || r.script != ""
+}
+
+// String returns the script of the runtime field, or if it is enabled.
+func (r RuntimeField) String() string {
+	if r.script != "" {
+		return r.script
+	}
+	return strconv.FormatBool(r.enabled)
+}
+
+func (r *RuntimeField) unmarshalString(text string) error {
+	value, err := strconv.ParseBool(text)
+	if err == nil {
+		r.enabled = value
+		return nil
+	}
+
+	// JSONSchema already checks about the type of this field (e.g. int or float)
+	r.enabled = true
+	r.script = text
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for RuntimeField.
+func (r *RuntimeField) UnmarshalJSON(data []byte) error {
+	var alias any
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+
+	switch v := alias.(type) {
+	case bool:
+		r.enabled = v
+	case string:
+		r.enabled = true
+		r.script = v
+	default:
+		// JSONSchema already checks about the type of this field (e.g. int or float)
+		r.enabled = true
+		r.script = string(data)
+	}
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for RuntimeField.
+func (r *RuntimeField) UnmarshalYAML(node *yaml.Node) error {
+	return r.unmarshalString(node.Value)
+}
+
+// IngestPipelineDefinition is the content of an ingest pipeline.
+type IngestPipelineDefinition struct {
+	Description string      `yaml:"description"`
+	Processors  []Processor `yaml:"processors"`
+	OnFailure   []Processor `yaml:"on_failure"`
+}
+
+// Processor is a processor of an ingest pipeline.
+type Processor struct {
+	// Type is the type of processor, as "set" or "rename".
+	Type string
+	// Attributes contains the configuration of the processor.
+	Attributes map[string]any
+	// OnFailure contains the processors to run if this processor fails.
+	OnFailure []Processor
+
+	// Position is the position of the definition of the processor in its file.
+	Position Position
+	// AttributesPosition is the position of the first attribute of the processor,
+	// if its attributes are defined in a block mapping.
+	AttributesPosition Position
+}
+
+// StringAttribute returns the value of an attribute of the processor, if it is a string.
+func (p *Processor) StringAttribute(key string) (string, bool) {
+	s, ok := p.Attributes[key].(string)
+	return s, ok
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for Processor.
+func (p *Processor) UnmarshalYAML(node *yaml.Node) error {
+	var procMap map[string]struct {
+		Attributes map[string]any `yaml:",inline"`
+		OnFailure  []Processor    `yaml:"on_failure"`
+	}
+	if err := node.Decode(&procMap); err != nil {
+		return err
+	}
+
+	for k, v := range procMap {
+		p.Type = k
+		p.Attributes = v.Attributes
+		p.OnFailure = v.OnFailure
+		break
+	}
+	p.Position = Position{Line: node.Line, Column: node.Column}
+
+	if len(node.Content) == 2 {
+		attributes := node.Content[1]
+		if attributes.Kind == yaml.MappingNode && attributes.Style&yaml.FlowStyle == 0 && len(attributes.Content) > 0 {
+			p.AttributesPosition = Position{Line: attributes.Content[0].Line, Column: attributes.Content[0].Column}
+		}
+	}
+	return nil
+}
+
+// KibanaObjectDefinition is the content of a Kibana saved object.
+type KibanaObjectDefinition struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	Attributes map[string]any    `json:"attributes"`
+	References []KibanaReference `json:"references"`
+}
+
+// KibanaReference is a reference from a Kibana saved object to another one.
+type KibanaReference struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
@@ -0,0 +1,107 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package packages
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRuntimeUnmarshal(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		testRuntimeUnmarshalFormat(t, json.Unmarshal)
+	})
+	t.Run("yaml", func(t *testing.T) {
+		testRuntimeUnmarshalFormat(t, yaml.Unmarshal)
+	})
+}
+
+func testRuntimeUnmarshalFormat(t *testing.T, unmarshaler func([]byte, interface{}) error) {
+	cases := []struct {
+		json     string
+		expected RuntimeField
+		enabled  bool
+	}{
+		{"true", RuntimeField{enabled: true, script: ""}, true},
+		{"false", RuntimeField{enabled: false, script: ""}, false},
+		{"42", RuntimeField{enabled: true, script: "42"}, true},
+		{"\"doc['message'].value().doSomething()\"", RuntimeField{enabled: true, script: "doc['message'].value().doSomething()"}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.json, func(t *testing.T) {
+			var found RuntimeField
+			err := unmarshaler([]byte(c.json), &found)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, found)
+			assert.Equal(t, c.enabled, found.Enabled())
+		})
+	}
+}
+
+func TestManifestConditionsUnmarshal(t *testing.T) {
+	cases := []struct {
+		formatVersion string
+		kibanaVersion string
+		subscription  string
+	}{
+		{"1.0.0", "^8.0.0", "basic"},
+		{"2.12.0", "^8.0.0", "basic"},
+		{"3.0.0", "", ""},
+		{"3.6.0", "", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.formatVersion, func(t *testing.T) {
+			var manifest Manifest
+			err := yaml.Unmarshal([]byte(`format_version: `+c.formatVersion+`
+conditions:
+  kibana.version: ^8.0.0
+  elastic.subscription: basic
+`), &manifest)
+			require.NoError(t, err)
+			assert.Equal(t, c.kibanaVersion, manifest.Conditions.Kibana.Version)
+			assert.Equal(t, c.subscription, manifest.Conditions.Elastic.Subscription)
+		})
+	}
+
+	var manifest Manifest
+	err := yaml.Unmarshal([]byte(`format_version: 3.6.0
+conditions:
+  kibana:
+    version: ^8.0.0
+`), &manifest)
+	require.NoError(t, err)
+	assert.Equal(t, "^8.0.0", manifest.Conditions.Kibana.Version)
+}
+
+func TestProcessorUnmarshal(t *testing.T) {
+	var definition IngestPipelineDefinition
+	err := yaml.Unmarshal([]byte(`processors:
+  - set:
+      field: foo
+      value: bar
+  - remove: {field: baz}
+`), &definition)
+	require.NoError(t, err)
+	require.Len(t, definition.Processors, 2)
+
+	set := definition.Processors[0]
+	assert.Equal(t, Position{Line: 2, Column: 5}, set.Position)
+	assert.Equal(t, Position{Line: 3, Column: 7}, set.AttributesPosition)
+	value, ok := set.StringAttribute("value")
+	assert.True(t, ok)
+	assert.Equal(t, "bar", value)
+	_, ok = set.StringAttribute("tag")
+	assert.False(t, ok)
+
+	remove := definition.Processors[1]
+	assert.Equal(t, Position{Line: 5, Column: 5}, remove.Position)
+	assert.Zero(t, remove.AttributesPosition)
+}
@@ -0,0 +1,407 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package packages
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"sync"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	manifestFile  = "manifest.yml"
+	dataStreamDir = "data_stream"
+)
+
+// Model gives access to the contents of a package as typed structures. Files are
+// read and parsed the first time they are requested, and the result is reused by
+// later calls, so the same model can be shared by all the validation rules. It is
+// safe for concurrent use, values returned must be treated as read-only.
+type Model struct {
+	fsys      fs.FS
+	filesRead atomic.Int64
+
+	manifest        lazy[*Manifest]
+	dataStreams     lazy[[]*DataStream]
+	fieldsFiles     lazy[[]*FieldsFile]
+	ingestPipelines lazy[[]*IngestPipeline]
+	kibanaObjects   lazy[[]*KibanaObject]
+}
+
+// NewModel creates a model for the package in the given filesystem.
+func NewModel(fsys fs.FS) *Model {
+	m := &Model{}
+	m.fsys = &countingFS{FS: fsys, files: &m.filesRead}
+	return m
+}
+
+// FilesRead returns the number of files read by the model. Each file is read
+// once, the first time its contents are requested.
+func (m *Model) FilesRead() int {
+	return int(m.filesRead.Load())
+}
+
+// ParseError is returned when a file of the package can be read, but not parsed.
+type ParseError struct {
+	// File is the path of the file, relative to the package root.
+	File string
+	// Err is the error returned by the parser.
+	Err error
+}
+
+// Error returns the error of the parser.
+func (e *ParseError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error of the parser.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// IsParseError returns true if the error was returned when parsing a file.
+func IsParseError(err error) bool {
+	var parseErr *ParseError
+	return errors.As(err, &parseErr)
+}
+
+// lazy is a value that is loaded only once, the first time it is requested.
+type lazy[T any] struct {
+	once  sync.Once
+	value T
+	err   error
+}
+
+func (l *lazy[T]) get(load func() (T, error)) (T, error) {
+	l.once.Do(func() {
+		l.value, l.err = load()
+	})
+	return l.value, l.err
+}
+
+// Manifest returns the manifest of the package.
+func (m *Model) Manifest() (*Manifest, error) {
+	return m.manifest.get(func() (*Manifest, error) {
+		var manifest Manifest
+		if err := readYAML(m.fsys, manifestFile, &manifest); err != nil {
+			return nil, err
+		}
+		return &manifest, nil
+	})
+}
+
+// DataStreams returns the data streams of the package, sorted by name. Packages
+// without data streams return an empty list.
+func (m *Model) DataStreams() ([]*DataStream, error) {
+	return m.dataStreams.get(func() ([]*DataStream, error) {
+		entries, err := fs.ReadDir(m.fsys, dataStreamDir)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var dataStreams []*DataStream
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			dataStreams = append(dataStreams, &DataStream{
+				Name: entry.Name(),
+				Path: path.Join(dataStreamDir, entry.Name()),
+				fsys: m.fsys,
+			})
+		}
+		return dataStreams, nil
+	})
+}
+
+// DataStream is a data stream of an integration package.
+type DataStream struct {
+	// Name is the name of the directory of the data stream.
+	Name string
+	// Path is the path of the directory of the data stream, relative to the package root.
+	Path string
+
+	fsys     fs.FS
+	manifest lazy[*DataStreamManifest]
+}
+
+// ManifestPath returns the path of the manifest of the data stream, relative to the package root.
+func (d *DataStream) ManifestPath() string {
+	return path.Join(d.Path, manifestFile)
+}
+
+// Manifest returns the manifest of the data stream.
+func (d *DataStream) Manifest() (*DataStreamManifest, error) {
+	return d.manifest.get(func() (*DataStreamManifest, error) {
+		var manifest DataStreamManifest
+		if err := readYAML(d.fsys, d.ManifestPath(), &manifest); err != nil {
+			return nil, err
+		}
+		return &manifest, nil
+	})
+}
+
+// FieldsFiles returns the fields files of the package. These files can be defined in
+// data streams, in transforms, or in the root of input packages.
+func (m *Model) FieldsFiles() ([]*FieldsFile, error) {
+	return m.fieldsFiles.get(func() ([]*FieldsFile, error) {
+		var files []*FieldsFile
+
+		dataStreams, err := m.DataStreams()
+		if err != nil {
+			return nil, err
+		}
+		for _, dataStream := range dataStreams {
+			paths, err := listDir(m.fsys, path.Join(dataStream.Path, "fields"))
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range paths {
+				files = append(files, &FieldsFile{Path: p, DataStream: dataStream.Name, fsys: m.fsys})
+			}
+		}
+
+		paths, err := listDir(m.fsys, "fields")
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range paths {
+			files = append(files, &FieldsFile{Path: p, fsys: m.fsys})
+		}
+
+		transformsDir := path.Join("elasticsearch", "transform")
+		transforms, err := listDir(m.fsys, transformsDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, transform := range transforms {
+			paths, err := listDir(m.fsys, path.Join(transform, "fields"))
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range paths {
+				files = append(files, &FieldsFile{Path: p, Transform: path.Base(transform), fsys: m.fsys})
+			}
+		}
+
+		return files, nil
+	})
+}
+
+// FieldsFile is a file with field definitions.
+type FieldsFile struct {
+	// Path is the path of the file, relative to the package root.
+	Path string
+	// DataStream is the name of the data stream the file belongs to, if any.
+	DataStream string
+	// Transform is the name of the transform the file belongs to, if any.
+	Transform string
+
+	fsys   fs.FS
+	fields lazy[[]Field]
+}
+
+// Fields returns the fields defined in the file.
+func (f *FieldsFile) Fields() ([]Field, error) {
+	return f.fields.get(func() ([]Field, error) {
+		var fields []Field
+		if err := readYAML(f.fsys, f.Path, &fields); err != nil {
+			return nil, err
+		}
+		return fields, nil
+	})
+}
+
+// IngestPipelines returns the ingest pipelines of the package, first the ones in
+// the package root, and then the ones in data streams.
+func (m *Model) IngestPipelines() ([]*IngestPipeline, error) {
+	return m.ingestPipelines.get(func() ([]*IngestPipeline, error) {
+		pipelinesDir := path.Join("elasticsearch", "ingest_pipeline")
+		paths, err := listDir(m.fsys, pipelinesDir)
+		if err != nil {
+			return nil, err
+		}
+		var pipelines []*IngestPipeline
+		for _, p := range paths {
+			pipelines = append(pipelines, &IngestPipeline{Path: p, fsys: m.fsys})
+		}
+
+		dataStreams, err := m.DataStreams()
+		if err != nil {
+			return nil, err
+		}
+		for _, dataStream := range dataStreams {
+			paths, err := listDir(m.fsys, path.Join(dataStream.Path, pipelinesDir))
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range paths {
+				pipelines = append(pipelines, &IngestPipeline{Path: p, DataStream: dataStream.Name, fsys: m.fsys})
+			}
+		}
+
+		return pipelines, nil
+	})
+}
+
+// IngestPipeline is an ingest pipeline definition, in YAML or JSON.
+type IngestPipeline struct {
+	// Path is the path of the file, relative to the package root.
+	Path string
+	// DataStream is the name of the data stream the pipeline belongs to, if any.
+	DataStream string
+
+	fsys       fs.FS
+	content    lazy[[]byte]
+	definition lazy[*IngestPipelineDefinition]
+}
+
+// Content returns the raw contents of the pipeline file.
+func (p *IngestPipeline) Content() ([]byte, error) {
+	return p.content.get(func() ([]byte, error) {
+		return fs.ReadFile(p.fsys, p.Path)
+	})
+}
+
+// Definition returns the contents of the pipeline.
+func (p *IngestPipeline) Definition() (*IngestPipelineDefinition, error) {
+	return p.definition.get(func() (*IngestPipelineDefinition, error) {
+		d, err := p.Content()
+		if err != nil {
+			return nil, err
+		}
+		var definition IngestPipelineDefinition
+		// YAML parser is also used for JSON files, so processors keep their positions.
+		if err := yaml.Unmarshal(d, &definition); err != nil {
+			return nil, &ParseError{File: p.Path, Err: err}
+		}
+		return &definition, nil
+	})
+}
+
+// KibanaObjects returns the Kibana saved objects of the package.
+func (m *Model) KibanaObjects() ([]*KibanaObject, error) {
+	return m.kibanaObjects.get(func() ([]*KibanaObject, error) {
+		assetTypes, err := listDir(m.fsys, "kibana")
+		if err != nil {
+			return nil, err
+		}
+
+		var objects []*KibanaObject
+		for _, assetType := range assetTypes {
+			info, err := fs.Stat(m.fsys, assetType)
+			if err != nil {
+				return nil, err
+			}
+			if !info.IsDir() {
+				continue
+			}
+			paths, err := listDir(m.fsys, assetType)
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range paths {
+				if path.Ext(p) != ".json" {
+					continue
+				}
+				objects = append(objects, &KibanaObject{Path: p, AssetType: path.Base(assetType), fsys: m.fsys})
+			}
+		}
+		return objects, nil
+	})
+}
+
+// KibanaObject is a file with a Kibana saved object.
+type KibanaObject struct {
+	// Path is the path of the file, relative to the package root.
+	Path string
+	// AssetType is the name of the directory containing the object, as "dashboard" or "tag".
+	AssetType string
+
+	fsys       fs.FS
+	definition lazy[*KibanaObjectDefinition]
+}
+
+// Definition returns the contents of the saved object.
+func (o *KibanaObject) Definition() (*KibanaObjectDefinition, error) {
+	return o.definition.get(func() (*KibanaObjectDefinition, error) {
+		d, err := fs.ReadFile(o.fsys, o.Path)
+		if err != nil {
+			return nil, err
+		}
+		var definition KibanaObjectDefinition
+		if err := json.Unmarshal(d, &definition); err != nil {
+			return nil, &ParseError{File: o.Path, Err: err}
+		}
+		return &definition, nil
+	})
+}
+
+func readYAML(fsys fs.FS, file string, v any) error {
+	d, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(d, v); err != nil {
+		return &ParseError{File: file, Err: err}
+	}
+	return nil
+}
+
+// listDir returns the paths of the entries in the given directory, or nothing
+// if the directory doesn't exist.
+func listDir(fsys fs.FS, dir string) ([]string, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		paths[i] = path.Join(dir, entry.Name())
+	}
+	return paths, nil
+}
+
+// countingFS counts the files opened in a filesystem, directories are not counted.
+type countingFS struct {
+	fs.FS
+
+	files *atomic.Int64
+}
+
+// Open opens the named file.
+func (c *countingFS) Open(name string) (fs.File, error) {
+	f, err := c.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := f.Stat(); err == nil && !info.IsDir() {
+		c.files.Add(1)
+	}
+	return f, nil
+}
+
+// ReadDir reads the named directory.
+func (c *countingFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(c.FS, name)
+}
+
+// Stat returns information about the named file.
+func (c *countingFS) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(c.FS, name)
+}
@@ -0,0 +1,209 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package packages
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/package-spec/v3/code/go/internal/linkedfiles"
+)
+
+func testPackageFS(name string) fs.FS {
+	path := filepath.Join("..", "..", "..", "..", "test", "packages", name)
+	return linkedfiles.NewFS(path, os.DirFS(path))
+}
+
+func TestModelIntegrationPackage(t *testing.T) {
+	model := NewModel(testPackageFS("good_v3"))
+
+	manifest, err := model.Manifest()
+	require.NoError(t, err)
+	assert.Equal(t, "good_v3", manifest.Name)
+	assert.Equal(t, "integration", manifest.Type)
+	assert.NotEmpty(t, manifest.PolicyTemplates)
+
+	dataStreams, err := model.DataStreams()
+	require.NoError(t, err)
+	require.NotEmpty(t, dataStreams)
+	var foo *DataStream
+	for _, ds := range dataStreams {
+		if ds.Name == "foo" {
+			foo = ds
+		}
+	}
+	require.NotNil(t, foo)
+	assert.Equal(t, "data_stream/foo", foo.Path)
+	assert.Equal(t, "data_stream/foo/manifest.yml", foo.ManifestPath())
+	dsManifest, err := foo.Manifest()
+	require.NoError(t, err)
+	assert.NotEmpty(t, dsManifest.Streams)
+
+	fieldsFiles, err := model.FieldsFiles()
+	require.NoError(t, err)
+	require.NotEmpty(t, fieldsFiles)
+	for _, f := range fieldsFiles {
+		fields, err := f.Fields()
+		require.NoError(t, err, f.Path)
+		assert.NotEmpty(t, fields, f.Path)
+	}
+
+	pipelines, err := model.IngestPipelines()
+	require.NoError(t, err)
+	var fooPipeline *IngestPipeline
+	for _, p := range pipelines {
+		if p.Path == "data_stream/foo/elasticsearch/ingest_pipeline/default.yml" {
+			fooPipeline = p
+		}
+	}
+	require.NotNil(t, fooPipeline)
+	assert.Equal(t, "foo", fooPipeline.DataStream)
+	definition, err := fooPipeline.Definition()
+	require.NoError(t, err)
+	require.Len(t, definition.Processors, 1)
+	assert.Equal(t, "rename", definition.Processors[0].Type)
+	assert.Equal(t, "foo", definition.Processors[0].Attributes["field"])
+	assert.Equal(t, Position{Line: 5, Column: 5}, definition.Processors[0].Position)
+	assert.Len(t, definition.OnFailure, 2)
+
+	objects, err := model.KibanaObjects()
+	require.NoError(t, err)
+	require.NotEmpty(t, objects)
+	for _, o := range objects {
+		definition, err := o.Definition()
+		require.NoError(t, err, o.Path)
+		assert.NotEmpty(t, definition.ID, o.Path)
+	}
+}
+
+func TestModelInputPackage(t *testing.T) {
+	model := NewModel(testPackageFS("good_input"))
+
+	manifest, err := model.Manifest()
+	require.NoError(t, err)
+	assert.Equal(t, "input", manifest.Type)
+
+	dataStreams, err := model.DataStreams()
+	require.NoError(t, err)
+	assert.Empty(t, dataStreams)
+
+	fieldsFiles, err := model.FieldsFiles()
+	require.NoError(t, err)
+	var paths []string
+	for _, f := range fieldsFiles {
+		assert.Empty(t, f.DataStream)
+		paths = append(paths, f.Path)
+	}
+	assert.ElementsMatch(t, []string{"fields/base-fields.yml", "fields/input.yml"}, paths)
+}
+
+func TestModelFilesRead(t *testing.T) {
+	model := NewModel(fstest.MapFS{
+		"manifest.yml":                      {Data: []byte("name: foo\ntype: integration\n")},
+		"data_stream/logs/manifest.yml":     {Data: []byte("title: Logs\ntype: logs\n")},
+		"data_stream/logs/fields/base.yml":  {Data: []byte("- name: message\n  type: text\n")},
+		"data_stream/logs/fields/extra.yml": {Data: []byte("- name: extra\n  type: keyword\n")},
+	})
+	assert.Zero(t, model.FilesRead())
+
+	_, err := model.Manifest()
+	require.NoError(t, err)
+	assert.Equal(t, 1, model.FilesRead())
+
+	// Listing files doesn't read them.
+	files, err := model.FieldsFiles()
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, 1, model.FilesRead())
+
+	for range 2 {
+		for _, file := range files {
+			_, err := file.Fields()
+			require.NoError(t, err)
+		}
+	}
+	assert.Equal(t, 3, model.FilesRead())
+}
+
+func TestModelErrors(t *testing.T) {
+	model := NewModel(fstest.MapFS{
+		"data_stream/foo/manifest.yml": &fstest.MapFile{Data: []byte("streams: [")},
+		"kibana/dashboard/foo.json":    &fstest.MapFile{Data: []byte("{")},
+	})
+
+	_, err := model.Manifest()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	assert.False(t, IsParseError(err))
+
+	dataStreams, err := model.DataStreams()
+	require.NoError(t, err)
+	require.Len(t, dataStreams, 1)
+	_, err = dataStreams[0].Manifest()
+	require.Error(t, err)
+	assert.True(t, IsParseError(err))
+
+	objects, err := model.KibanaObjects()
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	_, err = objects[0].Definition()
+	require.Error(t, err)
+	var parseErr *ParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, "kibana/dashboard/foo.json", parseErr.File)
+}
+
+func TestModelKibanaObjectReferences(t *testing.T) {
+	model := NewModel(fstest.MapFS{
+		"kibana/dashboard/foo.json": &fstest.MapFile{Data: []byte(`{
+  "id": "foo",
+  "type": "dashboard",
+  "references": [
+    {"id": "12345", "name": "panel_0", "type": "visualization"},
+    {"id": "9000", "name": "panel_1", "type": "other"}
+  ]
+}`)},
+		"kibana/tags.yml": &fstest.MapFile{Data: []byte("- text: foo\n")},
+	})
+
+	objects, err := model.KibanaObjects()
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Equal(t, "dashboard", objects[0].AssetType)
+	definition, err := objects[0].Definition()
+	require.NoError(t, err)
+	assert.Equal(t, []KibanaReference{
+		{ID: "12345", Name: "panel_0", Type: "visualization"},
+		{ID: "9000", Name: "panel_1", Type: "other"},
+	}, definition.References)
+}
+
+func TestModelConcurrentAccess(t *testing.T) {
+	model := NewModel(testPackageFS("good_v3"))
+
+	const concurrency = 8
+	manifests := make([]*Manifest, concurrency)
+	var wg sync.WaitGroup
+	for i := range concurrency {
+		wg.Go(func() {
+			manifest, err := model.Manifest()
+			assert.NoError(t, err)
+			manifests[i] = manifest
+		})
+	}
+	wg.Wait()
+
+	require.NotNil(t, manifests[0])
+	for _, manifest := range manifests {
+		assert.Same(t, manifests[0], manifest)
+	}
+}
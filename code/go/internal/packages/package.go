@@ -25,6 +25,7 @@ type Package struct {
 
 	fs       fs.FS
 	location string
+	model    *Model
 }
 
 // Open opens a file in the package filesystem.
@@ -32,6 +33,11 @@ func (p *Package) Open(name string) (fs.File, error) {
 	return p.fs.Open(name)
 }
 
+// Model returns the model of the package, shared by all the users of the package.
+func (p *Package) Model() *Model {
+	return p.model
+}
+
 // Path returns a path meaningful for the user.
 func (p *Package) Path(names ...string) string {
 	return path.Join(append([]string{p.location}, names...)...)
@@ -63,7 +69,8 @@ func NewPackage(pkgRootPath string) (*Package, error) {
 }
 
 // NewPackageFromFS creates a new package from a given filesystem. A root path can be indicated
-// to help building paths meaningful for the users.
+// to help building paths meaningful for the users. If the filesystem is another package, they
+// share the same model.
 func NewPackageFromFS(location string, fsys fs.FS) (*Package, error) {
 	pkgManifestPath := "manifest.yml"
 	_, err := fs.Stat(fsys, pkgManifestPath)
@@ -104,6 +111,11 @@ func NewPackageFromFS(location string, fsys fs.FS) (*Package, error) {
 		return nil, fmt.Errorf("could not read specification version from package manifest file [%v]: %w", manifest.SpecVersion, err)
 	}
 
+	model := NewModel(fsys)
+	if pkg, ok := fsys.(*Package); ok && pkg.model != nil {
+		model = pkg.model
+	}
+
 	// Instantiate Package object and return it
 	p := Package{
 		Name:        manifest.Name,
@@ -113,6 +125,7 @@ func NewPackageFromFS(location string, fsys fs.FS) (*Package, error) {
 		fs:          fsys,
 
 		location: location,
+		model:    model,
 	}
 
 	return &p, nil
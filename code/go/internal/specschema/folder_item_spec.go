@@ -76,6 +76,11 @@ func (s *ItemSpec) Contents() []spectypes.ItemSpec {
 	return result
 }
 
+// Description returns the description of the item in the spec.
+func (s *ItemSpec) Description() string {
+	return s.itemSpec.Description
+}
+
 // DevelopmentFolder returns true if the item is inside a development folder.
 func (s *ItemSpec) DevelopmentFolder() bool {
 	return s.itemSpec.DevelopmentFolder
@@ -122,6 +127,12 @@ func (s *ItemSpec) Required() bool {
 	return s.itemSpec.Required
 }
 
+// SchemaPath returns the path in the spec of the file with the schema of the
+// item, if it has one.
+func (s *ItemSpec) SchemaPath() string {
+	return s.itemSpec.schemaPath
+}
+
 // Type returns the type of file ('file' or 'folder').
 func (s *ItemSpec) Type() string {
 	return s.itemSpec.ItemType
@@ -160,7 +171,8 @@ type folderItemSpec struct {
 	// Default release: ga
 	Release string `json:"release" yaml:"release"`
 
-	schema spectypes.FileSchema
+	schema     spectypes.FileSchema
+	schemaPath string
 }
 
 func (s *folderItemSpec) setDefaultValues() error {
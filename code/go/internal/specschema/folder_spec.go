@@ -110,10 +110,11 @@ func (l *FolderSpecLoader) loadContents(s *folderItemSpec, fs fs.FS, specPath st
 			// Resolve references.
 			switch content.ItemType {
 			case spectypes.ItemTypeFile:
+				specPath := path.Join(path.Dir(specPath), content.Ref)
+				content.schemaPath = specPath
 				if l.fileSpecLoader == nil {
 					break
 				}
-				specPath := path.Join(path.Dir(specPath), content.Ref)
 				options := spectypes.FileSchemaLoadOptions{
 					SpecVersion: l.specVersion,
 					Limits:      &ItemSpec{content},
@@ -5,7 +5,10 @@
 package spectypes
 
 import (
+	"fmt"
 	"io/fs"
+	"regexp"
+	"strings"
 
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
@@ -62,6 +65,9 @@ type ItemSpec interface {
 	// Contents returns the definitions of the children elements of this item.
 	Contents() []ItemSpec
 
+	// Description returns the description of the item in the spec.
+	Description() string
+
 	// DevelopmentFolder returns true if the item is inside a development folder.
 	DevelopmentFolder() bool
 
@@ -90,9 +96,44 @@ type ItemSpec interface {
 	// Required returns true if this item must be defined.
 	Required() bool
 
+	// SchemaPath returns the path in the spec of the file with the schema of the
+	// item, if it has one.
+	SchemaPath() string
+
 	// Type returns the type of file ('file' or 'folder').
 	Type() string
 
 	// ValidateSchema validates if the indicated file complies with the schema of the item.
 	ValidateSchema(fsys fs.FS, itemPath string) specerrors.ValidationErrors
 }
+
+// MatchName returns true if the given name of a file or folder matches the name
+// or pattern of the item, and none of its forbidden patterns. Placeholders of
+// the package name in the pattern are replaced with the given expression, usually
+// the name of the package.
+func MatchName(item ItemSpec, name string, packageName string) (bool, error) {
+	if item.Name() != "" && item.Name() == name {
+		return true, nil
+	}
+	if item.Pattern() == "" {
+		return false, nil
+	}
+
+	isMatch, err := regexp.MatchString(strings.ReplaceAll(item.Pattern(), "{PACKAGE_NAME}", packageName), name)
+	if err != nil {
+		return false, fmt.Errorf("invalid folder item spec pattern: %w", err)
+	}
+	if !isMatch {
+		return false, nil
+	}
+	for _, forbidden := range item.ForbiddenPatterns() {
+		isForbidden, err := regexp.MatchString(forbidden, name)
+		if err != nil {
+			return false, fmt.Errorf("invalid forbidden pattern for folder item: %w", err)
+		}
+		if isForbidden {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,30 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package common
+
+import (
+	"context"
+	"log/slog"
+)
+
+type loggerKey struct{}
+
+// discardLogger is used when no logger is configured.
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// ContextWithLogger returns a copy of the context with the given logger, used by
+// the validation rules to log messages.
+func ContextWithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFrom returns the logger of the context. Messages are discarded if the
+// context doesn't have a logger.
+func LoggerFrom(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok && logger != nil {
+		return logger
+	}
+	return discardLogger
+}
@@ -0,0 +1,25 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package common
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerFrom(t *testing.T) {
+	// Context without logger discards messages.
+	logger := LoggerFrom(t.Context())
+	assert.NotNil(t, logger)
+	logger.Info("discarded")
+
+	var buf bytes.Buffer
+	ctx := ContextWithLogger(t.Context(), slog.New(slog.NewTextHandler(&buf, nil)))
+	LoggerFrom(ctx).Info("foo", "code", "SVR00001")
+	assert.Contains(t, buf.String(), "msg=foo code=SVR00001")
+}
@@ -9,8 +9,10 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"strings"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/spectypes"
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 func validateContentType(fsys fs.FS, path string, contentType spectypes.ContentType) error {
@@ -49,8 +51,17 @@ func validateYAMLDashes(fsys fs.FS, path string) error {
 	if err := scanner.Err(); err != bufio.ErrTooLong && err != nil {
 		return err
 	}
-	if scanner.Text() != "---" {
-		return errors.New("document dashes are required (start the document with '---')")
+	if line := scanner.Text(); line != "---" {
+		err := specerrors.NewStructuredError(errors.New("document dashes are required (start the document with '---')"), specerrors.UnassignedCode)
+		// Documents starting with other markers or directives need to be fixed manually.
+		if !strings.HasPrefix(line, "---") && !strings.HasPrefix(line, "%") {
+			err = err.WithFix(specerrors.Fix{
+				Description: "Start the document with '---'",
+				File:        path,
+				Edits:       []specerrors.Edit{{Start: 0, End: 0, Text: "---\n"}},
+			})
+		}
+		return err
 	}
 
 	return nil
@@ -49,16 +49,16 @@ func matchingFileExists(spec spectypes.ItemSpec, files []fs.DirEntry) (bool, err
 func validateFile(spec spectypes.ItemSpec, fsys fs.FS, itemPath string) specerrors.ValidationErrors {
 	err := validateMaxSize(fsys, itemPath, spec)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.CodeSizeLimitExceeded).WithFile(itemPath)}
 	}
 	if mediaType := spec.ContentMediaType(); mediaType != nil {
 		err := validateContentType(fsys, itemPath, *mediaType)
 		if err != nil {
-			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.CodeInvalidFileFormat).WithFile(itemPath)}
 		}
 		err = validateContentTypeSize(fsys, itemPath, *mediaType, spec)
 		if err != nil {
-			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.CodeSizeLimitExceeded).WithFile(itemPath)}
 		}
 	}
 
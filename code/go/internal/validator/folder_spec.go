@@ -5,13 +5,12 @@
 package validator
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"io/fs"
-	"log"
 	"path"
-	"regexp"
 	"strings"
+	"time"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/internal/spectypes"
@@ -19,36 +18,57 @@ import (
 )
 
 type validator struct {
-	spec             spectypes.ItemSpec
-	pkg              *packages.Package
-	folderPath       string
-	warningsAsErrors bool
-	mode             Mode
+	spec       spectypes.ItemSpec
+	pkg        *packages.Package
+	folderPath string
+	mode       Mode
 
 	totalSize     spectypes.FileSize
 	totalContents int
+
+	// stats collects the statistics of the validation when set.
+	stats              *Stats
+	filesRead          int
+	subfoldersDuration time.Duration
+	subfoldersFindings int
 }
 
-func newValidator(spec spectypes.ItemSpec, pkg *packages.Package, warningsAsErrors bool, mode Mode) *validator {
-	return newValidatorForPath(spec, pkg, ".", warningsAsErrors, mode)
+func newValidator(spec spectypes.ItemSpec, pkg *packages.Package, mode Mode) *validator {
+	return newValidatorForPath(spec, pkg, ".", mode)
 }
 
-func newValidatorForPath(spec spectypes.ItemSpec, pkg *packages.Package, folderPath string, warningsAsErrors bool, mode Mode) *validator {
+func newValidatorForPath(spec spectypes.ItemSpec, pkg *packages.Package, folderPath string, mode Mode) *validator {
 	return &validator{
-		spec:             spec,
-		pkg:              pkg,
-		folderPath:       folderPath,
-		warningsAsErrors: warningsAsErrors,
-		mode:             mode,
+		spec:       spec,
+		pkg:        pkg,
+		folderPath: folderPath,
+		mode:       mode,
 	}
 }
 
-func (v *validator) Validate() specerrors.ValidationErrors {
+// Validate validates the contents of the folder against its spec. The walk
+// stops when the context is done, returning the errors found so far.
+func (v *validator) Validate(ctx context.Context) specerrors.ValidationErrors {
+	if v.stats == nil {
+		return v.validate(ctx)
+	}
+
+	i := len(v.stats.Folders)
+	v.stats.Folders = append(v.stats.Folders, FolderStats{Path: v.folderPath})
+	start := time.Now()
+	errs := v.validate(ctx)
+	v.stats.Folders[i].Duration = time.Since(start) - v.subfoldersDuration
+	v.stats.Folders[i].FilesRead = v.filesRead
+	v.stats.Folders[i].Findings = len(errs) - v.subfoldersFindings
+	return errs
+}
+
+func (v *validator) validate(ctx context.Context) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 	files, err := fs.ReadDir(v.pkg, v.folderPath)
 	if err != nil {
 		errs = append(errs,
-			specerrors.NewStructuredErrorf("could not read folder [%s]: %w", v.pkg.Path(v.folderPath), err),
+			specerrors.NewStructuredError(fmt.Errorf("could not read folder [%s]: %w", v.pkg.Path(v.folderPath), err), specerrors.CodeUnreadablePackage).WithFile(v.folderPath),
 		)
 		return errs
 	}
@@ -57,7 +77,7 @@ func (v *validator) Validate() specerrors.ValidationErrors {
 	// this limit in all cases to avoid having to read too many files.
 	if contentsLimit := v.spec.MaxTotalContents(); contentsLimit > 0 && len(files) > contentsLimit {
 		errs = append(errs,
-			specerrors.NewStructuredErrorf("folder [%s] exceeds the limit of %d files", v.pkg.Path(v.folderPath), contentsLimit),
+			specerrors.NewStructuredError(fmt.Errorf("folder [%s] exceeds the limit of %d files", v.pkg.Path(v.folderPath), contentsLimit), specerrors.CodeSizeLimitExceeded).WithFile(v.folderPath),
 		)
 		return errs
 	}
@@ -68,35 +88,40 @@ func (v *validator) Validate() specerrors.ValidationErrors {
 	case "beta":
 		if v.pkg.IsGA() {
 			errs = append(errs,
-				specerrors.NewStructuredErrorf("spec for [%s] defines beta features which can't be enabled for packages with a stable semantic version", v.pkg.Path(v.folderPath)),
+				specerrors.NewStructuredError(fmt.Errorf("spec for [%s] defines beta features which can't be enabled for packages with a stable semantic version", v.pkg.Path(v.folderPath)), specerrors.CodePrereleaseFeatureOnGAPackage).WithFile(v.folderPath),
 			)
 		} else {
-			message := fmt.Sprintf("package with non-stable semantic version and active beta features (enabled in [%s]) can't be released as stable version.", v.pkg.Path(v.folderPath))
-			if v.warningsAsErrors || v.pkg.SpecVersion.Major() >= 3 {
-				err = errors.New(message)
-				errs = append(errs, specerrors.NewStructuredError(err, specerrors.CodePrereleaseFeatureOnGAPackage))
-			} else {
-				log.Print("Warning: ", message)
+			err := specerrors.NewStructuredError(
+				fmt.Errorf("package with non-stable semantic version and active beta features (enabled in [%s]) can't be released as stable version.", v.pkg.Path(v.folderPath)),
+				specerrors.CodePrereleaseFeatureOnGAPackage).WithFile(v.folderPath)
+			if v.pkg.SpecVersion.Major() < 3 {
+				err = err.WithSeverity(specerrors.SeverityWarning)
 			}
+			errs = append(errs, err)
 		}
 	default:
-		errs = append(errs, specerrors.NewStructuredErrorf("unsupport release level, supported values: beta, ga"))
+		errs = append(errs, specerrors.NewStructuredError(fmt.Errorf("spec for [%s] has unsupported release level %q, supported values: beta, ga", v.pkg.Path(v.folderPath), v.spec.Release()), specerrors.CodeInvalidSpecRelease).WithFile(v.folderPath))
 	}
 
 	for _, file := range files {
+		if ctx.Err() != nil {
+			return errs
+		}
+
 		fileName := file.Name()
+		itemPath := path.Join(v.folderPath, fileName)
 
 		if isLink, _ := checkLink(fileName); v.mode == BuildMode && isLink {
-			errs = append(errs, specerrors.NewStructuredErrorf(
+			errs = append(errs, specerrors.NewStructuredError(fmt.Errorf(
 				"file %q: .link files are not allowed in built packages",
-				v.pkg.Path(path.Join(v.folderPath, fileName)),
-			))
+				v.pkg.Path(itemPath),
+			), specerrors.CodeItemNotAllowedInMode).WithFile(itemPath))
 			continue
 		}
 
 		itemSpec, err := v.findItemSpec(fileName)
 		if err != nil {
-			errs = append(errs, specerrors.NewStructuredError(err, specerrors.UnassignedCode))
+			errs = append(errs, specerrors.NewStructuredError(err, specerrors.CodeUnexpectedItem).WithFile(itemPath))
 			continue
 		}
 
@@ -105,9 +130,9 @@ func (v *validator) Validate() specerrors.ValidationErrors {
 			if file.IsDir() {
 				if !v.spec.DevelopmentFolder() && strings.Contains(fileName, "-") {
 					errs = append(errs,
-						specerrors.NewStructuredErrorf(
+						specerrors.NewStructuredError(fmt.Errorf(
 							`file "%s" is invalid: directory name inside package %s contains -: %s`,
-							v.pkg.Path(v.folderPath, fileName), v.pkg.Name, fileName),
+							v.pkg.Path(v.folderPath, fileName), v.pkg.Name, fileName), specerrors.CodeUnexpectedItem).WithFile(itemPath),
 					)
 				}
 			}
@@ -117,7 +142,7 @@ func (v *validator) Validate() specerrors.ValidationErrors {
 		if itemSpec == nil && !v.spec.AdditionalContents() {
 			// No spec found for current folder item and we do not allow additional contents in folder.
 			errs = append(errs,
-				specerrors.NewStructuredErrorf("item [%s] is not allowed in folder [%s]", fileName, v.pkg.Path(v.folderPath)),
+				specerrors.NewStructuredError(fmt.Errorf("item [%s] is not allowed in folder [%s]", fileName, v.pkg.Path(v.folderPath)), specerrors.CodeUnexpectedItem).WithFile(itemPath),
 			)
 			continue
 		}
@@ -125,24 +150,27 @@ func (v *validator) Validate() specerrors.ValidationErrors {
 		if file.IsDir() {
 			if !itemSpec.IsDir() {
 				errs = append(errs,
-					specerrors.NewStructuredErrorf("[%s] is a folder but is expected to be a file", fileName),
+					specerrors.NewStructuredError(fmt.Errorf("[%s] is a folder but is expected to be a file", fileName), specerrors.CodeUnexpectedItem).WithFile(itemPath),
 				)
 				continue
 			}
 
 			if itemForbiddenInMode(itemSpec, v.mode) {
-				errs = append(errs, specerrors.NewStructuredErrorf(
+				errs = append(errs, specerrors.NewStructuredError(fmt.Errorf(
 					"file %q: %s-only folder is not allowed in %s packages",
-					v.pkg.Path(path.Join(v.folderPath, fileName)),
+					v.pkg.Path(itemPath),
 					itemSpec.ValidationMode(),
 					string(v.mode),
-				))
+				), specerrors.CodeItemNotAllowedInMode).WithFile(itemPath))
 				continue
 			}
 
-			subFolderPath := path.Join(v.folderPath, fileName)
-			itemValidator := newValidatorForPath(itemSpec, v.pkg, subFolderPath, v.warningsAsErrors, v.mode)
-			subErrs := itemValidator.Validate()
+			itemValidator := newValidatorForPath(itemSpec, v.pkg, itemPath, v.mode)
+			itemValidator.stats = v.stats
+			start := time.Now()
+			subErrs := itemValidator.Validate(ctx)
+			v.subfoldersDuration += time.Since(start)
+			v.subfoldersFindings += len(subErrs)
 			if len(subErrs) > 0 {
 				errs = append(errs, subErrs...)
 			}
@@ -155,33 +183,33 @@ func (v *validator) Validate() specerrors.ValidationErrors {
 		} else {
 			if itemSpec.IsDir() {
 				errs = append(errs,
-					specerrors.NewStructuredErrorf("[%s] is a file but is expected to be a folder", v.pkg.Path(fileName)),
+					specerrors.NewStructuredError(fmt.Errorf("[%s] is a file but is expected to be a folder", v.pkg.Path(fileName)), specerrors.CodeUnexpectedItem).WithFile(itemPath),
 				)
 				continue
 			}
 
 			if itemForbiddenInMode(itemSpec, v.mode) {
-				errs = append(errs, specerrors.NewStructuredErrorf(
+				errs = append(errs, specerrors.NewStructuredError(fmt.Errorf(
 					"file %q: %s-only file is not allowed in %s packages",
-					v.pkg.Path(path.Join(v.folderPath, fileName)),
+					v.pkg.Path(itemPath),
 					itemSpec.ValidationMode(),
 					string(v.mode),
-				))
+				), specerrors.CodeItemNotAllowedInMode).WithFile(itemPath))
 				continue
 			}
 
-			itemPath := path.Join(v.folderPath, file.Name())
 			itemValidationErrs := validateFile(itemSpec, v.pkg, itemPath)
+			v.filesRead++
 			for _, ive := range itemValidationErrs {
 				errs = append(errs,
-					specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", v.pkg.Path(itemPath), ive),
+					specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", v.pkg.Path(itemPath), ive).WithFile(itemPath),
 				)
 			}
 
 			info, err := fs.Stat(v.pkg, itemPath)
 			if err != nil {
 				errs = append(errs,
-					specerrors.NewStructuredErrorf("failed to obtain file size for \"%s\": %w", v.pkg.Path(itemPath), err),
+					specerrors.NewStructuredError(fmt.Errorf("failed to obtain file size for \"%s\": %w", v.pkg.Path(itemPath), err), specerrors.CodeUnreadablePackage).WithFile(itemPath),
 				)
 			} else {
 				v.totalContents++
@@ -192,7 +220,7 @@ func (v *validator) Validate() specerrors.ValidationErrors {
 
 	if sizeLimit := v.spec.MaxTotalSize(); sizeLimit > 0 && v.totalSize > sizeLimit {
 		errs = append(errs,
-			specerrors.NewStructuredErrorf("folder [%s] exceeds the total size limit of %s", v.pkg.Path(v.folderPath), sizeLimit),
+			specerrors.NewStructuredError(fmt.Errorf("folder [%s] exceeds the total size limit of %s", v.pkg.Path(v.folderPath), sizeLimit), specerrors.CodeSizeLimitExceeded).WithFile(v.folderPath),
 		)
 	}
 
@@ -204,7 +232,7 @@ func (v *validator) Validate() specerrors.ValidationErrors {
 
 		fileFound, err := matchingFileExists(itemSpec, files)
 		if err != nil {
-			errs = append(errs, specerrors.NewStructuredError(err, specerrors.UnassignedCode))
+			errs = append(errs, specerrors.NewStructuredError(err, specerrors.CodeUnreadablePackage).WithFile(v.folderPath))
 			continue
 		}
 
@@ -215,7 +243,7 @@ func (v *validator) Validate() specerrors.ValidationErrors {
 			} else if itemSpec.Pattern() != "" {
 				err = fmt.Errorf("expecting to find %s matching pattern [%s] in folder [%s]", itemSpec.Type(), itemSpec.Pattern(), v.pkg.Path(v.folderPath))
 			}
-			errs = append(errs, specerrors.NewStructuredError(err, specerrors.UnassignedCode))
+			errs = append(errs, specerrors.NewStructuredError(err, specerrors.CodeRequiredItemMissing).WithFile(v.folderPath))
 		}
 	}
 	return errs
@@ -224,35 +252,17 @@ func (v *validator) Validate() specerrors.ValidationErrors {
 func (v *validator) findItemSpec(folderItemName string) (spectypes.ItemSpec, error) {
 	isLink, folderItemName := checkLink(folderItemName)
 	for _, itemSpec := range v.spec.Contents() {
-		if itemSpec.Name() != "" && itemSpec.Name() == folderItemName {
-			return itemSpec, nil
+		isMatch, err := spectypes.MatchName(itemSpec, folderItemName, v.pkg.Name)
+		if err != nil {
+			return nil, err
 		}
-		if itemSpec.Pattern() != "" {
-			isMatch, err := regexp.MatchString(strings.ReplaceAll(itemSpec.Pattern(), "{PACKAGE_NAME}", v.pkg.Name), folderItemName)
-			if err != nil {
-				return nil, fmt.Errorf("invalid folder item spec pattern: %w", err)
-			}
-			if isMatch {
-				var isForbidden bool
-				for _, forbidden := range itemSpec.ForbiddenPatterns() {
-					isForbidden, err = regexp.MatchString(forbidden, folderItemName)
-					if err != nil {
-						return nil, fmt.Errorf("invalid forbidden pattern for folder item: %w", err)
-					}
-
-					if isForbidden {
-						break
-					}
-				}
-
-				if !isForbidden {
-					if isLink && !itemSpec.AllowLink() {
-						return nil, fmt.Errorf("item [%s] is a link but is not allowed", folderItemName)
-					}
-					return itemSpec, nil
-				}
-			}
+		if !isMatch {
+			continue
+		}
+		if isLink && itemSpec.Name() != folderItemName && !itemSpec.AllowLink() {
+			return nil, fmt.Errorf("item [%s] is a link but is not allowed", folderItemName)
 		}
+		return itemSpec, nil
 	}
 
 	// No item spec found
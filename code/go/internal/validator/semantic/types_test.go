@@ -5,16 +5,15 @@
 package semantic
 
 import (
-	"encoding/json"
 	"path"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"gopkg.in/yaml.v3"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 )
 
 func TestListFieldsFiles(t *testing.T) {
@@ -135,48 +134,13 @@ func TestListFieldsFiles(t *testing.T) {
 			pkgRootPath := path.Join("..", "..", "..", "..", "..", "test", "packages", c.pkgName)
 
 			fsys := fspath.DirFS(pkgRootPath)
-			fieldFilesMetadata, err := listFieldsFiles(fsys)
+			fieldsFiles, err := packages.NewModel(fsys).FieldsFiles()
 			require.NoError(t, err)
 
-			require.Len(t, fieldFilesMetadata, len(c.expected))
+			require.Len(t, fieldsFiles, len(c.expected))
 
-			for i, metadata := range fieldFilesMetadata {
-				assert.Equal(t, c.expected[i], metadata)
-			}
-		})
-	}
-}
-
-func TestRuntimeUnmarshal(t *testing.T) {
-	t.Run("json", func(t *testing.T) {
-		testRuntimeUnmarshalFormat(t, json.Unmarshal)
-	})
-	t.Run("yaml", func(t *testing.T) {
-		testRuntimeUnmarshalFormat(t, yaml.Unmarshal)
-	})
-}
-
-func testRuntimeUnmarshalFormat(t *testing.T, unmarshaler func([]byte, interface{}) error) {
-	cases := []struct {
-		json     string
-		expected runtimeField
-		valid    bool
-	}{
-		{"true", runtimeField{enabled: true, script: ""}, true},
-		{"false", runtimeField{enabled: false, script: ""}, true},
-		{"42", runtimeField{enabled: true, script: "42"}, true},
-		{"\"doc['message'].value().doSomething()\"", runtimeField{enabled: true, script: "doc['message'].value().doSomething()"}, true},
-	}
-
-	for _, c := range cases {
-		t.Run(c.json, func(t *testing.T) {
-			var found runtimeField
-			err := unmarshaler([]byte(c.json), &found)
-			if c.valid {
-				require.NoError(t, err)
-				assert.Equal(t, c.expected, found)
-			} else {
-				require.Error(t, err)
+			for i, fieldsFile := range fieldsFiles {
+				assert.Equal(t, c.expected[i], newFieldFileMetadata(fsys, fieldsFile))
 			}
 		})
 	}
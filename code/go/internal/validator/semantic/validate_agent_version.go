@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Masterminds/semver/v3"
@@ -20,20 +21,20 @@ var (
 )
 
 // ValidateMinimumAgentVersion checks that the package manifest includes the agent.version condition.
-func ValidateMinimumAgentVersion(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateMinimumAgentVersion(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	manifest, err := readManifest(fsys)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("manifest.yml")}
 	}
 
 	agentVersionCondition, err := getAgentVersionCondition(*manifest)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(manifest.Path())}
 	}
 
 	if agentVersionCondition != "" {
 		if _, err := semver.NewConstraint(agentVersionCondition); err != nil {
-			return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w: %w", fsys.Path(manifest.Name()), errInvalidAgentVersionCondition, err)}
+			return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w: %w", fsys.Path(manifest.Name()), errInvalidAgentVersionCondition, err).WithFile(manifest.Path())}
 		}
 	}
 
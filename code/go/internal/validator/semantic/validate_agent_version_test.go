@@ -73,7 +73,7 @@ conditions:
 			require.NoError(t, err)
 
 			fsys := fspath.DirFS(tempDir)
-			errs := ValidateMinimumAgentVersion(fsys)
+			errs := ValidateMinimumAgentVersion(t.Context(), fsys)
 
 			if c.expectedErr != nil {
 				require.Len(t, errs, 1)
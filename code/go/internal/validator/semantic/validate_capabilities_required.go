@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
 	"path"
 	"slices"
@@ -15,7 +16,7 @@ import (
 )
 
 // ValidateCapabilitiesRequired verifies that the required capabilities are added in package manifest
-func ValidateCapabilitiesRequired(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateCapabilitiesRequired(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	err := ensureSecurityRulesHasSecurityCapability(fsys)
 	if err != nil {
 		return err
@@ -27,7 +28,7 @@ func ensureSecurityRulesHasSecurityCapability(fsys fspath.FS) specerrors.Validat
 	securityRuleFilePaths := path.Join("kibana", "security_rule", "*.json")
 	files, err := pkgpath.Files(fsys, securityRuleFilePaths)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("error finding Kibana security_rule folder: %w", err)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("error finding Kibana security_rule folder: %w", err).WithFile(path.Join("kibana", "security_rule"))}
 	}
 	if len(files) == 0 {
 		return nil
@@ -35,12 +36,12 @@ func ensureSecurityRulesHasSecurityCapability(fsys fspath.FS) specerrors.Validat
 
 	capabilities, err := readCapabilities(fsys)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("manifest.yml")}
 	}
 
 	if !slices.Contains(capabilities, "security") {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: found security rule assets in package but security capability is missing", fsys.Path("manifest.yml")),
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: found security rule assets in package but security capability is missing", fsys.Path("manifest.yml")).WithFile("manifest.yml"),
 		}
 	}
 
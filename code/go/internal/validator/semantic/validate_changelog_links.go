@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
@@ -36,10 +37,10 @@ func (e ChangelogLinkError) Error() string {
 
 // ValidateChangelogLinks returns validation errors if the link(s) do not have a valid PR github.com link.
 // If the link is not a github.com link this validation is skipped and does not return an error.
-func ValidateChangelogLinks(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateChangelogLinks(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	changelogLinks, err := readChangelogLinks(fsys)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("changelog.yml")}
 	}
 	return ensureLinksAreValid(changelogLinks)
 }
@@ -65,14 +66,14 @@ func ensureLinksAreValid(links []string) specerrors.ValidationErrors {
 		linkURL, err := url.Parse(link)
 		if err != nil {
 			errs.Append(specerrors.ValidationErrors{
-				specerrors.NewStructuredErrorf("invalid URL %v", err),
+				specerrors.NewStructuredErrorf("invalid URL %v", err).WithFile("changelog.yml"),
 			})
 			continue
 		}
 		for _, vl := range validateLinks {
 			if strings.Contains(linkURL.Host, vl.domain) {
 				if err = vl.validateLink(linkURL); err != nil {
-					errs.Append(specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)})
+					errs.Append(specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("changelog.yml")})
 				}
 			}
 		}
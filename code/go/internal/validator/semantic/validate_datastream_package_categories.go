@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,10 +17,17 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/validator/common"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
-const packageRegistryCategoriesURL = "https://raw.githubusercontent.com/elastic/package-registry/v1.38.0/categories/categories.yml"
+// packageRegistryCategoriesURL is the location of the categories of the package
+// registry, it is a variable so tests can replace it.
+var packageRegistryCategoriesURL = "https://raw.githubusercontent.com/elastic/package-registry/v1.38.0/categories/categories.yml"
+
+// registryCategoriesTimeout is the maximum time spent fetching the categories, callers
+// can set shorter limits with the context.
+const registryCategoriesTimeout = 10 * time.Second
 
 type registryCategories struct {
 	Categories map[string]struct {
@@ -30,9 +38,17 @@ type registryCategories struct {
 	} `yaml:"categories"`
 }
 
-func fetchRegistryCategoryToParentMap() (map[string]string, error) {
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(packageRegistryCategoriesURL)
+func fetchRegistryCategoryToParentMap(ctx context.Context) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, registryCategoriesTimeout)
+	defer cancel()
+
+	common.LoggerFrom(ctx).Debug("fetching package registry categories", "url", packageRegistryCategoriesURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, packageRegistryCategoriesURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", packageRegistryCategoriesURL, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch categories from package registry: %w", err)
 	}
@@ -97,12 +113,14 @@ func readPackageManifestTypeAndCategories(fsys fspath.FS) (string, []string, err
 // categories include all parent-level equivalent categories present in any data stream
 // manifest. Parent categories are determined by fetching the package registry
 // categories.yml. Data stream manifests without a categories field are skipped.
-func ValidateDatastreamPackageCategories(fsys fspath.FS) specerrors.ValidationErrors {
+// Failures fetching the categories are reported with their own code, as they
+// don't mean that the package is invalid.
+func ValidateDatastreamPackageCategories(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	manifestPath := "manifest.yml"
 	pkgType, pkgCategories, err := readPackageManifestTypeAndCategories(fsys)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 
 	if pkgType != integrationPackageType {
@@ -112,17 +130,19 @@ func ValidateDatastreamPackageCategories(fsys fspath.FS) specerrors.ValidationEr
 	dsCategories, err := readDataStreamManifestCategories(fsys)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 
 	if len(dsCategories) == 0 {
 		return nil
 	}
 
-	categoryToParent, err := fetchRegistryCategoryToParentMap()
+	categoryToParent, err := fetchRegistryCategoryToParentMap(ctx)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to load registry categories: %w", fsys.Path(manifestPath), err)}
+			specerrors.NewStructuredError(
+				fmt.Errorf("categories of file \"%s\" could not be validated: failed to load registry categories: %w", fsys.Path(manifestPath), err),
+				specerrors.CodeRemoteResourceUnavailable).WithFile(manifestPath)}
 	}
 
 	var errs specerrors.ValidationErrors
@@ -138,7 +158,7 @@ func ValidateDatastreamPackageCategories(fsys fspath.FS) specerrors.ValidationEr
 					dsName,
 					dsCat,
 					fsys.Path(dsManifestPath),
-				))
+				).WithFile(manifestPath))
 				continue
 			}
 			if !slices.Contains(pkgCategories, parent) && !slices.Contains(missingCats, parent) {
@@ -156,7 +176,7 @@ func ValidateDatastreamPackageCategories(fsys fspath.FS) specerrors.ValidationEr
 				missingCats,
 				dsName,
 				fsys.Path(dsManifestPath),
-			))
+			).WithFile(manifestPath))
 		}
 	}
 
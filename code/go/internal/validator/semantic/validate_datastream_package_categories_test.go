@@ -5,12 +5,15 @@
 package semantic
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 func TestValidateDatastreamPackageCategories(t *testing.T) {
@@ -201,7 +204,7 @@ type: logs
 			dir := t.TempDir()
 			tc.setup(t, dir)
 
-			errs := ValidateDatastreamPackageCategories(fspath.DirFS(dir))
+			errs := ValidateDatastreamPackageCategories(t.Context(), fspath.DirFS(dir))
 
 			if len(tc.expectedErrs) == 0 {
 				assert.Empty(t, errs)
@@ -214,3 +217,32 @@ type: logs
 		})
 	}
 }
+
+func TestValidateDatastreamPackageCategoriesRegistryUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	originalURL := packageRegistryCategoriesURL
+	packageRegistryCategoriesURL = server.URL
+	t.Cleanup(func() { packageRegistryCategoriesURL = originalURL })
+
+	dir := t.TempDir()
+	writeManifest(t, dir, `
+type: integration
+categories:
+  - security
+`)
+	writeDataStreamManifest(t, dir, "mylogs", `
+title: My Logs
+categories:
+  - security
+type: logs
+`)
+
+	errs := ValidateDatastreamPackageCategories(t.Context(), fspath.DirFS(dir))
+	require.Len(t, errs, 1)
+	assert.Equal(t, specerrors.CodeRemoteResourceUnavailable, errs[0].Code())
+	assert.Contains(t, errs[0].Error(), "could not be validated: failed to load registry categories: unexpected HTTP 503")
+}
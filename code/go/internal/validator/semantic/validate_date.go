@@ -5,13 +5,15 @@
 package semantic
 
 import (
+	"context"
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 // ValidateDateFields verifies if date fields are of one of the expected types.
-func ValidateDateFields(fsys fspath.FS) specerrors.ValidationErrors {
-	return validateFields(fsys, validateDateField)
+func ValidateDateFields(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
+	return validateFields(fsys, model, validateDateField)
 }
 
 func validateDateField(metadata fieldFileMetadata, f field) specerrors.ValidationErrors {
@@ -19,7 +21,7 @@ func validateDateField(metadata fieldFileMetadata, f field) specerrors.Validatio
 		return specerrors.ValidationErrors{
 			specerrors.NewStructuredErrorf(
 				`file "%s" is invalid: field "%s" of type %s can't set date_format. date_format is allowed for date field type only`,
-				metadata.fullFilePath, f.Name, f.Type),
+				metadata.fullFilePath, f.Name, f.Type).WithFile(metadata.filePath),
 		}
 	}
 
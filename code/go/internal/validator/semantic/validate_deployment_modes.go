@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 
@@ -45,23 +46,23 @@ type deploymentModesInput struct {
 
 // ValidateDeploymentModes ensures that for each deployment mode enabled in a policy template,
 // there is at least one input that supports that deployment mode.
-func ValidateDeploymentModes(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateDeploymentModes(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	manifestPath := "manifest.yml"
 	d, err := fs.ReadFile(fsys, manifestPath)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to read manifest: %w", fsys.Path(manifestPath), err)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to read manifest: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 
 	var manifest deploymentModesManifest
 	err = yaml.Unmarshal(d, &manifest)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 
 	var errs specerrors.ValidationErrors
 
 	if err := validateAgentlessReleaseDeployment(manifest); err != nil {
-		errs = append(errs, specerrors.NewStructuredError(fmt.Errorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err), specerrors.UnassignedCode))
+		errs = append(errs, specerrors.NewStructuredError(fmt.Errorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err), specerrors.UnassignedCode).WithFile(manifestPath))
 	}
 
 	for _, template := range manifest.PolicyTemplates {
@@ -98,7 +99,7 @@ func ValidateDeploymentModes(fsys fspath.FS) specerrors.ValidationErrors {
 
 			if !hasSupport {
 				err := fmt.Errorf("file \"%s\" is invalid: policy template \"%s\" enables deployment mode \"%s\" but no input supports this mode", fsys.Path(manifestPath), template.Name, enabledMode)
-				errs = append(errs, specerrors.NewStructuredError(err, specerrors.UnassignedCode))
+				errs = append(errs, specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(manifestPath))
 			}
 		}
 
@@ -119,7 +120,7 @@ func ValidateDeploymentModes(fsys fspath.FS) specerrors.ValidationErrors {
 				}
 				if !found {
 					err := fmt.Errorf("file \"%s\" is invalid: input \"%s\" in policy template \"%s\" specifies unsupported deployment mode \"%s\"", fsys.Path(manifestPath), input.Type, template.Name, mode)
-					errs = append(errs, specerrors.NewStructuredError(err, specerrors.UnassignedCode))
+					errs = append(errs, specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(manifestPath))
 				}
 			}
 		}
@@ -201,7 +201,7 @@ policy_templates:
 			require.NoError(t, err)
 
 			fsys := fspath.DirFS(tempDir)
-			errs := ValidateDeploymentModes(fsys)
+			errs := ValidateDeploymentModes(t.Context(), fsys)
 
 			if len(c.expectedErrs) == 0 {
 				assert.Empty(t, errs)
@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"io/fs"
 
 	"gopkg.in/yaml.v3"
@@ -26,7 +27,7 @@ type deprecatedInfo struct {
 }
 
 // ValidateDeprecatedReplacedBy checks that when deprecated.replaced_by is used, the required fields are set.
-func ValidateDeprecatedReplacedBy(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateDeprecatedReplacedBy(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	errs := validatePackageManifestDeprecatedReplacedBy(fsys)
 	dsErrs := validateDataStreamsDeprecatedReplacedBy(fsys)
 
@@ -50,34 +51,34 @@ func validatePackageManifestDeprecatedReplacedBy(fsys fspath.FS) specerrors.Vali
 	data, err := fs.ReadFile(fsys, manifestPath)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 
 	var m manifest
 	err = yaml.Unmarshal(data, &m)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 	var errs specerrors.ValidationErrors
 	if m.Deprecated != nil && m.Deprecated.ReplacedBy != nil {
 		rb := m.Deprecated.ReplacedBy
 		if rb.Package == "" {
-			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: deprecated.replaced_by.package must be specified when deprecated.replaced_by is used", fsys.Path(manifestPath)))
+			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: deprecated.replaced_by.package must be specified when deprecated.replaced_by is used", fsys.Path(manifestPath)).WithFile(manifestPath))
 		}
 	}
 	for _, pt := range m.PolicyTemplates {
 		if pt.Deprecated != nil && pt.Deprecated.ReplacedBy != nil {
 			rb := pt.Deprecated.ReplacedBy
 			if rb.PolicyTemplate == "" {
-				errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: policy_template deprecated.replaced_by.policy_template must be specified when deprecated.replaced_by is used", fsys.Path(manifestPath)))
+				errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: policy_template deprecated.replaced_by.policy_template must be specified when deprecated.replaced_by is used", fsys.Path(manifestPath)).WithFile(manifestPath))
 			}
 		}
 		for _, input := range pt.Inputs {
 			if input.Deprecated != nil && input.Deprecated.ReplacedBy != nil {
 				rb := input.Deprecated.ReplacedBy
 				if rb.Input == "" {
-					errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: input deprecated.replaced_by.input must be specified when deprecated.replaced_by is used", fsys.Path(manifestPath)))
+					errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: input deprecated.replaced_by.input must be specified when deprecated.replaced_by is used", fsys.Path(manifestPath)).WithFile(manifestPath))
 				}
 			}
 		}
@@ -100,26 +101,26 @@ func validateDataStreamsDeprecatedReplacedBy(fsys fspath.FS) specerrors.Validati
 	dsManifests, err := fs.Glob(fsys, "data_stream/*/manifest.yml")
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("error while searching for data stream manifests: %w", err)}
+			specerrors.NewStructuredErrorf("error while searching for data stream manifests: %w", err).WithFile("data_stream")}
 	}
 
 	var errs specerrors.ValidationErrors
 	for _, dsManifestPath := range dsManifests {
 		data, err := fs.ReadFile(fsys, dsManifestPath)
 		if err != nil {
-			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(dsManifestPath), err))
+			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(dsManifestPath), err).WithFile(dsManifestPath))
 			continue
 		}
 		var sm streamManifest
 		err = yaml.Unmarshal(data, &sm)
 		if err != nil {
-			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(dsManifestPath), err))
+			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(dsManifestPath), err).WithFile(dsManifestPath))
 			continue
 		}
 		if sm.Deprecated != nil && sm.Deprecated.ReplacedBy != nil {
 			rb := sm.Deprecated.ReplacedBy
 			if rb.DataStream == "" {
-				errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: deprecated.replaced_by.data_stream must be specified when deprecated.replaced_by is used", fsys.Path(dsManifestPath)))
+				errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: deprecated.replaced_by.data_stream must be specified when deprecated.replaced_by is used", fsys.Path(dsManifestPath)).WithFile(dsManifestPath))
 			}
 		}
 
@@ -128,7 +129,7 @@ func validateDataStreamsDeprecatedReplacedBy(fsys fspath.FS) specerrors.Validati
 				if v.Deprecated != nil && v.Deprecated.ReplacedBy != nil {
 					rb := v.Deprecated.ReplacedBy
 					if rb.Variable == "" {
-						errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: variable deprecated.replaced_by.variable must be specified when deprecated.replaced_by is used", fsys.Path(dsManifestPath)))
+						errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: variable deprecated.replaced_by.variable must be specified when deprecated.replaced_by is used", fsys.Path(dsManifestPath)).WithFile(dsManifestPath))
 					}
 				}
 			}
@@ -5,15 +5,17 @@
 package semantic
 
 import (
+	"context"
 	"strings"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 // ValidateDimensionFields verifies if dimension fields are of one of the expected types.
-func ValidateDimensionFields(fsys fspath.FS) specerrors.ValidationErrors {
-	return validateFields(fsys, validateDimensionField)
+func ValidateDimensionFields(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
+	return validateFields(fsys, model, validateDimensionField)
 }
 
 func validateDimensionField(metadata fieldFileMetadata, f field) specerrors.ValidationErrors {
@@ -26,7 +28,7 @@ func validateDimensionField(metadata fieldFileMetadata, f field) specerrors.Vali
 		return specerrors.ValidationErrors{
 			specerrors.NewStructuredErrorf(
 				`file "%s" is invalid: field "%s" of type %s can't be a dimension, allowed types for dimensions: %s`,
-				metadata.fullFilePath, f.Name, f.Type, strings.Join(allowedDimensionTypes, ", ")),
+				metadata.fullFilePath, f.Name, f.Type, strings.Join(allowedDimensionTypes, ", ")).WithFile(metadata.filePath),
 		}
 	}
 
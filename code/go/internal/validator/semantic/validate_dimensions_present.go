@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 	"path"
@@ -12,13 +13,14 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 // ValidateDimensionsPresent verifies if dimension fields are of one of the expected types.
-func ValidateDimensionsPresent(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateDimensionsPresent(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
 	dimensionPresent := make(map[string]struct{})
-	errs := validateFields(fsys, func(metadata fieldFileMetadata, f field) specerrors.ValidationErrors {
+	errs := validateFields(fsys, model, func(metadata fieldFileMetadata, f field) specerrors.ValidationErrors {
 		if f.Dimension {
 			dimensionPresent[metadata.dataStream] = struct{}{}
 		}
@@ -30,19 +32,19 @@ func ValidateDimensionsPresent(fsys fspath.FS) specerrors.ValidationErrors {
 
 	dataStreams, err := listDataStreams(fsys)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(dataStreamDir)}
 	}
 	for _, dataStream := range dataStreams {
 		tsEnabled, err := isTimeSeriesModeEnabled(fsys, dataStream)
 		if err != nil {
-			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(path.Join("data_stream", dataStream, "manifest.yml"))}
 		}
 		_, hasDimensions := dimensionPresent[dataStream]
 		if tsEnabled && !hasDimensions {
 			errs = append(errs, specerrors.NewStructuredErrorf(
 				`file "%s" is invalid: time series mode enabled but no dimensions configured`,
 				fsys.Path("data_stream", dataStream, "manifest.yml"),
-			))
+			).WithFile(path.Join("data_stream", dataStream, "manifest.yml")))
 		}
 	}
 	return errs
@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -35,14 +36,14 @@ type Section struct {
 }
 
 // ValidateDocsStructure validates the structure of documentation files against enforced sections.
-func ValidateDocsStructure(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateDocsStructure(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	config, err := shouldValidateDocsStructure(fsys)
 	if err != nil {
 		return specerrors.ValidationErrors{
 			specerrors.NewStructuredError(
 				fmt.Errorf("failed to determine if documentation structure validation should be performed: %w", err),
 				specerrors.UnassignedCode,
-			),
+			).WithFile("validation.yml"),
 		}
 	}
 	if config == nil {
@@ -55,7 +56,7 @@ func ValidateDocsStructure(fsys fspath.FS) specerrors.ValidationErrors {
 			specerrors.NewStructuredError(
 				fmt.Errorf("failed to read enforced documentation structure configuration: %w", err),
 				specerrors.UnassignedCode,
-			),
+			).WithFile("validation.yml"),
 		}
 	}
 
@@ -66,7 +67,7 @@ func ValidateDocsStructure(fsys fspath.FS) specerrors.ValidationErrors {
 			specerrors.NewStructuredError(
 				err,
 				specerrors.UnassignedCode,
-			),
+			).WithFile("docs"),
 		}
 	}
 	return nil
@@ -113,7 +113,7 @@ func TestValidateDocsStructure(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateDocsStructure(fspath.DirFS(tt.pkgRoot))
+			err := ValidateDocsStructure(t.Context(), fspath.DirFS(tt.pkgRoot))
 			assert.True(t, compareErrors(tt.expectError, tt.expectedError, err), "Error does not match expected")
 		})
 	}
@@ -5,15 +5,14 @@
 package semantic
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
-	"reflect"
 	"time"
 
-	"gopkg.in/yaml.v3"
-
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
@@ -23,141 +22,87 @@ import (
 //
 // It examines both the root manifest.yml file and all data stream manifests
 // to find and validate duration variables.
-func ValidateDurationVariables(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateDurationVariables(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
+
 	// Load main manifest vars.
-	data, err := fs.ReadFile(fsys, "manifest.yml")
-	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("%s failed to read manifest: %w", fsys.Path("manifest.yml"), err)}
+	manifest, err := model.Manifest()
+	if packages.IsParseError(err) {
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("%s is invalid: failed to parse manifest: %w", fsys.Path("manifest.yml"), err).WithFile("manifest.yml")}
 	}
-
-	var manifest durationManifest
-	err = yaml.Unmarshal(data, &manifest)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("%s is invalid: failed to parse manifest: %w", fsys.Path("manifest.yml"), err)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("%s failed to read manifest: %w", fsys.Path("manifest.yml"), err).WithFile("manifest.yml")}
 	}
-	annotateFileMetadata(fsys.Path("manifest.yml"), manifest)
-	vars := manifest.allVars()
+	vars := manifestVars("manifest.yml", manifest)
 
-	dsManifests, err := fs.Glob(fsys, "data_stream/*/manifest.yml")
+	dataStreams, err := model.DataStreams()
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("failed to list data streams: %w", err)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("failed to list data streams: %w", err).WithFile(dataStreamDir)}
 	}
 
 	// Load data stream manifest vars.
-	for _, path := range dsManifests {
-		data, err := fs.ReadFile(fsys, path)
-		if err != nil {
-			return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("%s failed to read data stream manifest: %w", fsys.Path(path), err)}
+	for _, dataStream := range dataStreams {
+		path := dataStream.ManifestPath()
+		manifest, err := dataStream.Manifest()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if packages.IsParseError(err) {
+			return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("%s is invalid: failed to parse data stream manifest: %w", fsys.Path(path), err).WithFile(path)}
 		}
-
-		var manifest durationDataStreamManifest
-		err = yaml.Unmarshal(data, &manifest)
 		if err != nil {
-			return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("%s is invalid: failed to parse data stream manifest: %w", fsys.Path(path), err)}
+			return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("%s failed to read data stream manifest: %w", fsys.Path(path), err).WithFile(path)}
 		}
-		annotateFileMetadata(fsys.Path(path), manifest)
-		vars = append(vars, manifest.allVars()...)
+		vars = append(vars, dataStreamManifestVars(path, manifest)...)
 	}
 
 	// Validate duration vars.
 	var errs specerrors.ValidationErrors
 	for _, v := range vars {
-		if err := validateDurationVar(v); err != nil {
-			errs = append(errs, specerrors.NewStructuredErrorf("%s:%d:%d error in variable %q: %w", v.Node.Path, v.Node.Line, v.Node.Col, v.Name, err))
+		if err := validateDurationVar(v.Var); err != nil {
+			errs = append(errs, specerrors.NewStructuredErrorf("%s:%d:%d error in variable %q: %w", fsys.Path(v.file), v.Position.Line, v.Position.Column, v.Name, err).
+				WithFile(v.file).
+				WithPosition(v.Position.Line, v.Position.Column))
 		}
 	}
 
 	return errs
 }
 
-// nodeMetadata contains location information for YAML nodes in a file.
-// This helps pinpoint the exact location of validation errors.
-type nodeMetadata struct {
-	// Path to the file containing the node
-	Path string
-	// Line number (1-based)
-	Line int
-	// Column number (1-based)
-	Col int
-}
-
-// durationVar represents a variable with duration constraints from a manifest
-// file. It includes fields for the variable name, type, duration constraints,
-// default value, and location information within the source file.
-type durationVar struct {
-	// Variable name
-	Name string `yaml:"name"`
-	// Variable type (must be "duration" for validation)
-	Type string `yaml:"type"`
-	// Minimum allowed duration (optional)
-	MinDuration *string `yaml:"min_duration,omitempty"`
-	// Maximum allowed duration (optional)
-	MaxDuration *string `yaml:"max_duration,omitempty"`
-	// Default value (optional)
-	Default any `yaml:"default,omitempty"`
+// fileVar is a variable with the path of the file where it is defined.
+type fileVar struct {
+	packages.Var
 
-	// Location information in the source file
-	Node nodeMetadata `yaml:"-"`
+	// file is the path of the file relative to the package root.
+	file string
 }
 
-// UnmarshalYAML implements the yaml.Unmarshaler interface for durationVar. It
-// unmarshals the YAML data into a durationVar struct and also captures the
-// position information from the yaml.Node.
-func (v *durationVar) UnmarshalYAML(node *yaml.Node) error {
-	// Prevent recursion by creating a type alias that doesn't implement Unmarshaler.
-	type notVar durationVar
-	x := (*notVar)(v)
-
-	if err := node.Decode(&x); err != nil {
-		return err
+// manifestVars collects all variables from a manifest including those at the
+// top level, within policy templates, and nested inside inputs.
+func manifestVars(file string, m *packages.Manifest) []fileVar {
+	var out []fileVar
+	add := func(vars []packages.Var) {
+		for _, v := range vars {
+			out = append(out, fileVar{Var: v, file: file})
+		}
 	}
-	v.Node.Line = node.Line
-	v.Node.Col = node.Column
-	return nil
-}
-
-// durationManifest represents the structure of a package manifest file focusing
-// on the elements that may contain duration variables.
-type durationManifest struct {
-	Vars            []durationVar `yaml:"vars"` // Top-level variables
-	PolicyTemplates []struct {
-		Vars   []durationVar `yaml:"vars"` // Policy template variables
-		Inputs []struct {
-			Vars []durationVar `yaml:"vars"` // Input variables
-		} `yaml:"inputs"`
-	} `yaml:"policy_templates"`
-}
-
-// allVars collects all variables from a manifest including those at the
-// top level, within policy templates, and nested inside inputs. It returns a
-// flattened slice of all variables for easier processing.
-func (m *durationManifest) allVars() []durationVar {
-	var out []durationVar
-	out = append(out, m.Vars...)
+	add(m.Vars)
 	for _, t := range m.PolicyTemplates {
-		out = append(out, t.Vars...)
+		add(t.Vars)
 		for _, i := range t.Inputs {
-			out = append(out, i.Vars...)
+			add(i.Vars)
 		}
 	}
 	return out
 }
 
-// durationDataStreamManifest represents the structure of a data stream manifest
-// file focusing on the stream elements that may contain duration variables.
-type durationDataStreamManifest struct {
-	Streams []struct {
-		Vars []durationVar `yaml:"vars"` // Stream variables
-	} `yaml:"streams"`
-}
-
-// allVars collects all variables from a data stream manifest. It
-// returns a flattened slice of all variables from all streams for easier
-// processing.
-func (m *durationDataStreamManifest) allVars() []durationVar {
-	var out []durationVar
+// dataStreamManifestVars collects all variables from all the streams of a data
+// stream manifest.
+func dataStreamManifestVars(file string, m *packages.DataStreamManifest) []fileVar {
+	var out []fileVar
 	for _, s := range m.Streams {
-		out = append(out, s.Vars...)
+		for _, v := range s.Vars {
+			out = append(out, fileVar{Var: v, file: file})
+		}
 	}
 	return out
 }
@@ -169,7 +114,7 @@ func (m *durationDataStreamManifest) allVars() []durationVar {
 // It parses the duration strings, validates their format, and ensures they meet
 // the ordering constraints. Multiple validation errors may be returned together
 // using errors.Join if the variable has multiple constraint violations.
-func validateDurationVar(v durationVar) error {
+func validateDurationVar(v packages.Var) error {
 	// Only validate variables of the type "duration".
 	if v.Type != "duration" {
 		return nil
@@ -236,53 +181,3 @@ func validateDurationVar(v durationVar) error {
 
 	return errors.Join(errs...)
 }
-
-// annotateFileMetadata recursively sets the file path on any nodeMetadata fields
-// found within the provided value. This ensures that all node metadata entries
-// contain the correct file path for accurate error reporting.
-//
-// It uses reflection to traverse the object structure and annotate all
-// nodeMetadata instances with the provided file path.
-func annotateFileMetadata(file string, v any) {
-	fileAnnotator{Name: file}.Annotate(reflect.ValueOf(v))
-}
-
-// fileAnnotator is a helper type for annotating node metadata with a file name.
-// It keeps track of the file name being applied to nodeMetadata instances.
-type fileAnnotator struct {
-	// Name is the file path to be applied to nodeMetadata instances
-	Name string
-}
-
-// Annotate recursively traverses the provided reflect.Value and sets the Name
-// field on any nodeMetadata objects it finds. It handles various value kinds,
-// including pointers, structs, slices, and maps.
-func (a fileAnnotator) Annotate(val reflect.Value) {
-	// Need an addressable value to edit the metadata value.
-	if val.CanAddr() && val.CanSet() {
-		if m, ok := val.Addr().Interface().(*nodeMetadata); ok {
-			m.Path = a.Name
-			return
-		}
-	}
-
-	switch val.Kind() {
-	case reflect.Pointer:
-		a.Annotate(val.Elem())
-	case reflect.Struct:
-		for i := 0; i < val.NumField(); i++ {
-			valueField := val.Field(i)
-			a.Annotate(valueField)
-		}
-	case reflect.Slice:
-		for i := 0; i < val.Len(); i++ {
-			a.Annotate(val.Index(i))
-		}
-	case reflect.Map:
-		iter := val.MapRange()
-		for iter.Next() {
-			// NOTE: This can only edit the map value if it is addressable (i.e., a pointer).
-			a.Annotate(iter.Value())
-		}
-	}
-}
@@ -11,17 +11,18 @@ import (
 	"testing"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 )
 
 func TestValidateDurationVar(t *testing.T) {
 	tests := []struct {
 		name     string
-		variable durationVar
+		variable packages.Var
 		wantErrs bool
 	}{
 		{
 			name: "non-duration type",
-			variable: durationVar{
+			variable: packages.Var{
 				Name: "test_var",
 				Type: "text",
 			},
@@ -29,7 +30,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "valid duration - no constraints",
-			variable: durationVar{
+			variable: packages.Var{
 				Name: "test_var",
 				Type: "duration",
 			},
@@ -37,7 +38,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "valid duration - with default",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:    "test_var",
 				Type:    "duration",
 				Default: "10s",
@@ -46,7 +47,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "valid duration - with min",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				MinDuration: strPtr("5s"),
@@ -55,7 +56,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "valid duration - with max",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				MaxDuration: strPtr("30s"),
@@ -64,7 +65,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "valid duration - with min and default",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				MinDuration: strPtr("5s"),
@@ -74,7 +75,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "valid duration - with default and max",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				Default:     "10s",
@@ -84,7 +85,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "valid duration - with min and max",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				MinDuration: strPtr("5s"),
@@ -94,7 +95,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "valid duration - with min, default, and max",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				MinDuration: strPtr("5s"),
@@ -105,7 +106,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "valid duration - with equal min and default",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				MinDuration: strPtr("10s"),
@@ -115,7 +116,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "valid duration - with equal default and max",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				Default:     "30s",
@@ -125,7 +126,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "valid duration - with equal min and max",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				MinDuration: strPtr("10s"),
@@ -135,7 +136,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "valid duration - with all equal",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				MinDuration: strPtr("10s"),
@@ -146,7 +147,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "invalid duration - negative min",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				MinDuration: strPtr("-5s"),
@@ -155,7 +156,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "invalid duration - min > default",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				MinDuration: strPtr("15s"),
@@ -165,7 +166,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "invalid duration - default > max",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				Default:     "40s",
@@ -175,7 +176,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "invalid duration - min > max",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				MinDuration: strPtr("40s"),
@@ -185,7 +186,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "invalid duration - multiple violations",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				MinDuration: strPtr("40s"),
@@ -196,7 +197,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "invalid duration - unparseable min",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				MinDuration: strPtr("invalid"),
@@ -205,7 +206,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "invalid duration - unparseable default",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:    "test_var",
 				Type:    "duration",
 				Default: strPtr("invalid"),
@@ -214,7 +215,7 @@ func TestValidateDurationVar(t *testing.T) {
 		},
 		{
 			name: "invalid duration - unparseable max",
-			variable: durationVar{
+			variable: packages.Var{
 				Name:        "test_var",
 				Type:        "duration",
 				MaxDuration: strPtr("invalid"),
@@ -303,7 +304,7 @@ streams:
 		filepath.Join("data_stream", "foo", "manifest.yml") + `:8:9 error in variable "dwell_time": min_duration "50ms50ms" greater than default "5ms"`,
 	}
 
-	errs := ValidateDurationVariables(fspath.DirFS(d))
+	errs := ValidateDurationVariables(t.Context(), fspath.DirFS(d), packages.NewModel(fspath.DirFS(d)))
 	if len(errs) != len(want) {
 		t.Fatalf("Expected %d errors, got %d", len(want), len(errs))
 	}
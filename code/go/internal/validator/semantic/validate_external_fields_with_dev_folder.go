@@ -5,22 +5,24 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/internal/pkgpath"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 // ValidateExternalFieldsWithDevFolder verifies there is no field with external key if there is no _dev/build/build.yml definition
-func ValidateExternalFieldsWithDevFolder(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateExternalFieldsWithDevFolder(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
 
 	const buildPath = "_dev/build/build.yml"
 	buildFilePathDefined := true
 	f, err := pkgpath.Files(fsys, buildPath)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("not able to read _dev/build/build.yml: %w", err),
+			specerrors.NewStructuredErrorf("not able to read _dev/build/build.yml: %w", err).WithFile(buildPath),
 		}
 	}
 
@@ -32,7 +34,7 @@ func ValidateExternalFieldsWithDevFolder(fsys fspath.FS) specerrors.ValidationEr
 	if buildFilePathDefined {
 		dependencies, err := readDevBuildDependenciesKeys(f[0])
 		if err != nil {
-			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(buildPath)}
 		}
 		for _, dep := range dependencies {
 			mapDependencies[dep] = struct{}{}
@@ -48,7 +50,7 @@ func ValidateExternalFieldsWithDevFolder(fsys fspath.FS) specerrors.ValidationEr
 			return specerrors.ValidationErrors{
 				specerrors.NewStructuredErrorf(
 					"file \"%s\" is invalid: field %s with external key defined (%q) but no _dev/build/build.yml found",
-					metadata.fullFilePath, f.Name, f.External),
+					metadata.fullFilePath, f.Name, f.External).WithFile(metadata.filePath),
 			}
 		}
 
@@ -56,12 +58,12 @@ func ValidateExternalFieldsWithDevFolder(fsys fspath.FS) specerrors.ValidationEr
 			return specerrors.ValidationErrors{
 				specerrors.NewStructuredErrorf(
 					"file \"%s\" is invalid: field %s with external key defined (%q) but no definition found for it (_dev/build/build.yml)",
-					metadata.fullFilePath, f.Name, f.External),
+					metadata.fullFilePath, f.Name, f.External).WithFile(metadata.filePath),
 			}
 		}
 		return nil
 	}
-	return validateFields(fsys, validateFunc)
+	return validateFields(fsys, model, validateFunc)
 }
 
 func readDevBuildDependenciesKeys(f pkgpath.File) ([]string, error) {
@@ -5,25 +5,27 @@
 package semantic
 
 import (
+	"context"
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 // ValidateFieldGroups verifies if field groups don't have units and metric types defined.
-func ValidateFieldGroups(fsys fspath.FS) specerrors.ValidationErrors {
-	return validateFields(fsys, validateFieldUnit)
+func ValidateFieldGroups(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
+	return validateFields(fsys, model, validateFieldUnit)
 }
 
 func validateFieldUnit(metadata fieldFileMetadata, f field) specerrors.ValidationErrors {
 	if f.Type == "group" && f.Unit != "" {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf(`file "%s" is invalid: field "%s" can't have unit property'`, metadata.fullFilePath, f.Name),
+			specerrors.NewStructuredErrorf(`file "%s" is invalid: field "%s" can't have unit property'`, metadata.fullFilePath, f.Name).WithFile(metadata.filePath),
 		}
 	}
 
 	if f.Type == "group" && f.MetricType != "" {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf(`file "%s" is invalid: field "%s" can't have metric type property'`, metadata.fullFilePath, f.Name),
+			specerrors.NewStructuredErrorf(`file "%s" is invalid: field "%s" can't have metric type property'`, metadata.fullFilePath, f.Name).WithFile(metadata.filePath),
 		}
 	}
 
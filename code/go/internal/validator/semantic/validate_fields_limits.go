@@ -5,18 +5,22 @@
 package semantic
 
 import (
+	"context"
+	"path"
+
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 // ValidateFieldsLimits verifies limits on fields.
-func ValidateFieldsLimits(limit int) func(fspath.FS) specerrors.ValidationErrors {
-	return func(fsys fspath.FS) specerrors.ValidationErrors {
-		return validateFieldsLimits(fsys, limit)
+func ValidateFieldsLimits(limit int) func(context.Context, fspath.FS, *packages.Model) specerrors.ValidationErrors {
+	return func(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
+		return validateFieldsLimits(fsys, model, limit)
 	}
 }
 
-func validateFieldsLimits(fsys fspath.FS, limit int) specerrors.ValidationErrors {
+func validateFieldsLimits(fsys fspath.FS, model *packages.Model, limit int) specerrors.ValidationErrors {
 	counts := make(map[string]int)
 	// Created a new map to avoid collisions with data stream names
 	transformCounts := make(map[string]int)
@@ -37,7 +41,7 @@ func validateFieldsLimits(fsys fspath.FS, limit int) specerrors.ValidationErrors
 		return nil
 	}
 
-	err := validateFields(fsys, countField)
+	err := validateFields(fsys, model, countField)
 	if err != nil {
 		return err
 	}
@@ -45,15 +49,15 @@ func validateFieldsLimits(fsys fspath.FS, limit int) specerrors.ValidationErrors
 	for id, count := range counts {
 		if count > limit {
 			if id != "" {
-				errs = append(errs, specerrors.NewStructuredErrorf("data stream %s has more than %d fields (%d)", id, limit, count))
+				errs = append(errs, specerrors.NewStructuredErrorf("data stream %s has more than %d fields (%d)", id, limit, count).WithFile(path.Join("data_stream", id, "fields")))
 			} else {
-				errs = append(errs, specerrors.NewStructuredErrorf("input package has more than %d fields (%d)", limit, count))
+				errs = append(errs, specerrors.NewStructuredErrorf("input package has more than %d fields (%d)", limit, count).WithFile("fields"))
 			}
 		}
 	}
 	for id, count := range transformCounts {
 		if count > limit {
-			errs = append(errs, specerrors.NewStructuredErrorf("transform %s has more than %d fields (%d)", id, limit, count))
+			errs = append(errs, specerrors.NewStructuredErrorf("transform %s has more than %d fields (%d)", id, limit, count).WithFile(path.Join("elasticsearch", "transform", id, "fields")))
 		}
 	}
 	return errs
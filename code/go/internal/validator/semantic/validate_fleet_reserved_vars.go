@@ -5,15 +5,13 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
-	"io/fs"
-	"path"
 	"slices"
 	"strings"
 
-	"gopkg.in/yaml.v3"
-
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
@@ -39,7 +37,7 @@ type reservedVarRule struct {
 	allowedScopes []varScope
 	// validate checks type and eligibility constraints at an allowed scope.
 	// May be nil if only scope enforcement is needed.
-	validate func(v varDef, ctx varValidationContext) specerrors.ValidationErrors
+	validate func(v packages.Var, ctx varValidationContext) specerrors.ValidationErrors
 }
 
 // isAllowedAt reports whether scope is in the rule's allowedScopes.
@@ -63,21 +61,17 @@ var reservedVarRules = map[string]reservedVarRule{
 	datasetVarName: {allowedScopes: []varScope{scopeStream}, validate: validateDatasetVar},
 }
 
-type varDef struct {
-	Name string `yaml:"name"`
-	Type string `yaml:"type"`
-}
-
 // varValidationContext carries all available context at the point a variable is
 // validated. filePath, contextStr, manifest, and scope are always set.
 // policyTemplate is set at scopePolicyTemplate, scopeInput, and scopeStream
 // for input packages. stream is set at scopeStream only.
 type varValidationContext struct {
-	manifest       packageManifest
+	manifest       *packages.Manifest
 	scope          varScope
 	filePath       string
+	file           string
 	contextStr     string
-	policyTemplate *policyTemplate
+	policyTemplate *packages.PolicyTemplate
 	stream         *normalizedStream
 }
 
@@ -91,59 +85,20 @@ type normalizedStream struct {
 	dynamicSignalTypes bool
 }
 
-// policyInput parses the type and vars from an integration policy template input entry.
-type policyInput struct {
-	Type string   `yaml:"type"`
-	Vars []varDef `yaml:"vars"`
-}
-
-// policyTemplate parses fields from a package policy template. Input (singular)
-// is used by input packages; Inputs (plural) is used by integration packages.
-type policyTemplate struct {
-	Name               string        `yaml:"name"`
-	Input              string        `yaml:"input"`
-	Type               string        `yaml:"type"`
-	DynamicSignalTypes bool          `yaml:"dynamic_signal_types"`
-	Vars               []varDef      `yaml:"vars"`
-	Inputs             []policyInput `yaml:"inputs"`
-}
-
-// packageManifest captures all fields needed for reserved variable validation
-// across both input and integration packages.
-type packageManifest struct {
-	Type            string           `yaml:"type"`
-	Vars            []varDef         `yaml:"vars"`
-	PolicyTemplates []policyTemplate `yaml:"policy_templates"`
-}
-
-// streamEntry parses the fields needed from a data stream manifest stream entry.
-type streamEntry struct {
-	Input              string   `yaml:"input"`
-	DynamicSignalTypes bool     `yaml:"dynamic_signal_types"`
-	Vars               []varDef `yaml:"vars"`
-}
-
 // ValidateFleetReservedVars validates that Fleet-reserved variables, when
 // explicitly defined in package manifests, conform to Fleet's expectations.
-func ValidateFleetReservedVars(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateFleetReservedVars(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
 	manifestPath := "manifest.yml"
-	data, err := fs.ReadFile(fsys, manifestPath)
+	manifest, err := model.Manifest()
 	if err != nil {
-		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to read manifest: %w", fsys.Path(manifestPath), err)}
-	}
-
-	var manifest packageManifest
-	if err := yaml.Unmarshal(data, &manifest); err != nil {
-		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err)}
+		return specerrors.ValidationErrors{reservedVarsManifestError(fsys, manifestPath, err)}
 	}
 
 	switch manifest.Type {
 	case inputPackageType:
 		return validateInputReservedVars(fsys, manifest)
 	case integrationPackageType:
-		return validateIntegrationReservedVars(fsys, manifest)
+		return validateIntegrationReservedVars(fsys, model, manifest)
 	}
 
 	return nil
@@ -157,20 +112,22 @@ type reservedVarChecker struct {
 }
 
 // check validates every var in vars using the provided context.
-func (c *reservedVarChecker) check(vars []varDef, ctx varValidationContext) {
+func (c *reservedVarChecker) check(vars []packages.Var, ctx varValidationContext) {
 	for _, v := range vars {
 		c.errs = append(c.errs, validateReservedVar(v, ctx)...)
 	}
 }
 
-func validateInputReservedVars(fsys fspath.FS, manifest packageManifest) specerrors.ValidationErrors {
-	filePath := fsys.Path("manifest.yml")
+func validateInputReservedVars(fsys fspath.FS, manifest *packages.Manifest) specerrors.ValidationErrors {
+	file := "manifest.yml"
+	filePath := fsys.Path(file)
 	c := &reservedVarChecker{}
 
 	c.check(manifest.Vars, varValidationContext{
 		manifest:   manifest,
 		scope:      scopeRoot,
 		filePath:   filePath,
+		file:       file,
 		contextStr: "package root vars",
 	})
 
@@ -182,6 +139,7 @@ func validateInputReservedVars(fsys fspath.FS, manifest packageManifest) specerr
 			manifest:       manifest,
 			scope:          scopeStream,
 			filePath:       filePath,
+			file:           file,
 			contextStr:     fmt.Sprintf("policy template %q", pt.Name),
 			policyTemplate: &pt,
 			stream:         &stream,
@@ -191,14 +149,16 @@ func validateInputReservedVars(fsys fspath.FS, manifest packageManifest) specerr
 	return c.errs
 }
 
-func validateIntegrationReservedVars(fsys fspath.FS, manifest packageManifest) specerrors.ValidationErrors {
-	filePath := fsys.Path("manifest.yml")
+func validateIntegrationReservedVars(fsys fspath.FS, model *packages.Model, manifest *packages.Manifest) specerrors.ValidationErrors {
+	file := "manifest.yml"
+	filePath := fsys.Path(file)
 	c := &reservedVarChecker{}
 
 	c.check(manifest.Vars, varValidationContext{
 		manifest:   manifest,
 		scope:      scopeRoot,
 		filePath:   filePath,
+		file:       file,
 		contextStr: "package root vars",
 	})
 
@@ -207,6 +167,7 @@ func validateIntegrationReservedVars(fsys fspath.FS, manifest packageManifest) s
 			manifest:       manifest,
 			scope:          scopePolicyTemplate,
 			filePath:       filePath,
+			file:           file,
 			contextStr:     fmt.Sprintf("policy template %q vars", pt.Name),
 			policyTemplate: &pt,
 		})
@@ -215,33 +176,23 @@ func validateIntegrationReservedVars(fsys fspath.FS, manifest packageManifest) s
 				manifest:       manifest,
 				scope:          scopeInput,
 				filePath:       filePath,
+				file:           file,
 				contextStr:     fmt.Sprintf("policy template %q input %q vars", pt.Name, input.Type),
 				policyTemplate: &pt,
 			})
 		}
 	}
 
-	dataStreams, err := listDataStreams(fsys)
+	dataStreams, err := model.DataStreams()
 	if err != nil {
-		return append(c.errs, specerrors.NewStructuredErrorf("failed to list data streams: %w", err))
+		return append(c.errs, specerrors.NewStructuredErrorf("failed to list data streams: %w", err).WithFile(dataStreamDir))
 	}
 
 	for _, ds := range dataStreams {
-		manifestPath := path.Join(dataStreamDir, ds, "manifest.yml")
-		data, err := fs.ReadFile(fsys, manifestPath)
+		manifestPath := ds.ManifestPath()
+		dsManifest, err := ds.Manifest()
 		if err != nil {
-			c.errs = append(c.errs, specerrors.NewStructuredErrorf(
-				"file \"%s\" is invalid: failed to read manifest: %w", fsys.Path(manifestPath), err))
-			continue
-		}
-
-		var dsManifest struct {
-			Type    string        `yaml:"type"`
-			Streams []streamEntry `yaml:"streams"`
-		}
-		if err := yaml.Unmarshal(data, &dsManifest); err != nil {
-			c.errs = append(c.errs, specerrors.NewStructuredErrorf(
-				"file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err))
+			c.errs = append(c.errs, reservedVarsManifestError(fsys, manifestPath, err))
 			continue
 		}
 
@@ -252,6 +203,7 @@ func validateIntegrationReservedVars(fsys fspath.FS, manifest packageManifest) s
 				manifest:   manifest,
 				scope:      scopeStream,
 				filePath:   dsFilePath,
+				file:       manifestPath,
 				contextStr: fmt.Sprintf("stream with input type %q", entry.Input),
 				stream:     &stream,
 			})
@@ -261,7 +213,17 @@ func validateIntegrationReservedVars(fsys fspath.FS, manifest packageManifest) s
 	return c.errs
 }
 
-func normalizeInputStream(pt policyTemplate) normalizedStream {
+// reservedVarsManifestError returns the error for a manifest that cannot be read or parsed.
+func reservedVarsManifestError(fsys fspath.FS, manifestPath string, err error) *specerrors.StructuredError {
+	if packages.IsParseError(err) {
+		return specerrors.NewStructuredErrorf(
+			"file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)
+	}
+	return specerrors.NewStructuredErrorf(
+		"file \"%s\" is invalid: failed to read manifest: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)
+}
+
+func normalizeInputStream(pt packages.PolicyTemplate) normalizedStream {
 	return normalizedStream{
 		inputType:          pt.Input,
 		dataStreamType:     pt.Type,
@@ -269,7 +231,7 @@ func normalizeInputStream(pt policyTemplate) normalizedStream {
 	}
 }
 
-func normalizeIntegrationStream(entry streamEntry, dsType string) normalizedStream {
+func normalizeIntegrationStream(entry packages.Stream, dsType string) normalizedStream {
 	return normalizedStream{
 		inputType:          entry.Input,
 		dataStreamType:     dsType,
@@ -280,7 +242,7 @@ func normalizeIntegrationStream(entry streamEntry, dsType string) normalizedStre
 // validateReservedVar is the single entry point for all reserved variable
 // validation. It checks scope constraints via the rule's allowedScopes and,
 // when the scope is valid, runs per-variable content validation.
-func validateReservedVar(v varDef, ctx varValidationContext) specerrors.ValidationErrors {
+func validateReservedVar(v packages.Var, ctx varValidationContext) specerrors.ValidationErrors {
 	rule, ok := reservedVarRules[v.Name]
 	if !ok {
 		return nil
@@ -291,7 +253,7 @@ func validateReservedVar(v varDef, ctx varValidationContext) specerrors.Validati
 	if !rule.isAllowedAt(ctx.scope) {
 		errs = append(errs, specerrors.NewStructuredErrorf(
 			"file \"%s\" is invalid: %s: variable \"%s\" must only be declared at %s",
-			ctx.filePath, ctx.contextStr, v.Name, rule.scopeViolationMsg()))
+			ctx.filePath, ctx.contextStr, v.Name, rule.scopeViolationMsg()).WithFile(ctx.file))
 	}
 
 	if rule.isAllowedAt(ctx.scope) && rule.validate != nil {
@@ -305,20 +267,20 @@ func validateReservedVar(v varDef, ctx varValidationContext) specerrors.Validati
 //   - defined on an otelcol input
 //   - of type "bool"
 //   - only present on "traces" data streams or when "dynamic_signal_types" is true
-func validateUseAPMVar(v varDef, ctx varValidationContext) specerrors.ValidationErrors {
+func validateUseAPMVar(v packages.Var, ctx varValidationContext) specerrors.ValidationErrors {
 	stream := ctx.stream
 	var errs specerrors.ValidationErrors
 
 	if stream.inputType != otelcolInputType {
-		errs = append(errs, newReservedVarError(ctx.filePath, ctx.contextStr, useAPMVarName,
+		errs = append(errs, newReservedVarError(ctx, useAPMVarName,
 			fmt.Sprintf("%q input", otelcolInputType), fmt.Sprintf("%q", stream.inputType)))
 	}
 	if v.Type != "bool" {
-		errs = append(errs, newReservedVarError(ctx.filePath, ctx.contextStr, useAPMVarName,
+		errs = append(errs, newReservedVarError(ctx, useAPMVarName,
 			`type "bool"`, fmt.Sprintf("%q", v.Type)))
 	}
 	if stream.dataStreamType != tracesDataStreamType && !stream.dynamicSignalTypes {
-		errs = append(errs, newReservedVarError(ctx.filePath, ctx.contextStr, useAPMVarName,
+		errs = append(errs, newReservedVarError(ctx, useAPMVarName,
 			fmt.Sprintf("%q data stream type or \"dynamic_signal_types: true\"", tracesDataStreamType),
 			fmt.Sprintf("%q data stream type", stream.dataStreamType)))
 	}
@@ -327,10 +289,10 @@ func validateUseAPMVar(v varDef, ctx varValidationContext) specerrors.Validation
 }
 
 // validateDatasetVar enforces that data_stream.dataset is of type "text".
-func validateDatasetVar(v varDef, ctx varValidationContext) specerrors.ValidationErrors {
+func validateDatasetVar(v packages.Var, ctx varValidationContext) specerrors.ValidationErrors {
 	if v.Type != "text" {
 		return specerrors.ValidationErrors{
-			newReservedVarError(ctx.filePath, ctx.contextStr, datasetVarName,
+			newReservedVarError(ctx, datasetVarName,
 				`type "text"`, fmt.Sprintf("%q", v.Type)),
 		}
 	}
@@ -340,8 +302,8 @@ func validateDatasetVar(v varDef, ctx varValidationContext) specerrors.Validatio
 // newReservedVarError constructs a validation error for a Fleet-reserved
 // variable that doesn't satisfy its constraint. expected describes the
 // requirement (e.g. `type "bool"`) and got is the actual value found.
-func newReservedVarError(filePath, contextStr, varName, expected, got string) *specerrors.StructuredError {
+func newReservedVarError(ctx varValidationContext, varName, expected, got string) *specerrors.StructuredError {
 	return specerrors.NewStructuredErrorf(
 		"file \"%s\" is invalid: %s: variable \"%s\" must be %s, got %s",
-		filePath, contextStr, varName, expected, got)
+		ctx.filePath, ctx.contextStr, varName, expected, got).WithFile(ctx.file)
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 )
 
 func TestValidateFleetReservedVars(t *testing.T) {
@@ -24,7 +25,7 @@ type: content
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateFleetReservedVars(fspath.DirFS(d))
+		errs := ValidateFleetReservedVars(t.Context(), fspath.DirFS(d), packages.NewModel(fspath.DirFS(d)))
 		require.Empty(t, errs, "expected no validation errors for non-input/integration package types")
 	})
 
@@ -44,7 +45,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateFleetReservedVars(fspath.DirFS(d))
+		errs := ValidateFleetReservedVars(t.Context(), fspath.DirFS(d), packages.NewModel(fspath.DirFS(d)))
 		require.Empty(t, errs, "expected no validation errors for non-reserved variable names")
 	})
 
@@ -63,7 +64,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateFleetReservedVars(fspath.DirFS(d))
+		errs := ValidateFleetReservedVars(t.Context(), fspath.DirFS(d), packages.NewModel(fspath.DirFS(d)))
 		require.Len(t, errs, 2, "expected both input type and variable type violations to be reported")
 		assert.Contains(t, errs[0].Error(), `variable "use_apm" must be "otelcol" input, got "logfile"`)
 		assert.Contains(t, errs[1].Error(), `variable "use_apm" must be type "bool", got "text"`)
@@ -84,7 +85,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateFleetReservedVars(fspath.DirFS(d))
+		errs := ValidateFleetReservedVars(t.Context(), fspath.DirFS(d), packages.NewModel(fspath.DirFS(d)))
 		require.Len(t, errs, 1, "expected one eligibility violation to be reported")
 		assert.Contains(t, errs[0].Error(), `variable "use_apm" must be "traces" data stream type or "dynamic_signal_types: true", got "logs" data stream type`)
 	})
@@ -105,7 +106,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateFleetReservedVars(fspath.DirFS(d))
+		errs := ValidateFleetReservedVars(t.Context(), fspath.DirFS(d), packages.NewModel(fspath.DirFS(d)))
 		require.Empty(t, errs, "expected no errors when dynamic_signal_types is true")
 	})
 
@@ -124,7 +125,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateFleetReservedVars(fspath.DirFS(d))
+		errs := ValidateFleetReservedVars(t.Context(), fspath.DirFS(d), packages.NewModel(fspath.DirFS(d)))
 		require.Len(t, errs, 1, "expected one scope violation for root-level reserved var in input package")
 		assert.Contains(t, errs[0].Error(), `package root vars: variable "data_stream.dataset" must only be declared at stream level`)
 	})
@@ -150,7 +151,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateFleetReservedVars(fspath.DirFS(d))
+		errs := ValidateFleetReservedVars(t.Context(), fspath.DirFS(d), packages.NewModel(fspath.DirFS(d)))
 		require.Len(t, errs, 3, "expected scope violations at root, policy template, and input levels")
 		assert.Contains(t, errs[0].Error(), `package root vars: variable "use_apm" must only be declared at stream level`)
 		assert.Contains(t, errs[1].Error(), `policy template "sample" vars: variable "data_stream.dataset" must only be declared at stream level`)
@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"errors"
 	"io/fs"
 	"os"
@@ -24,7 +25,7 @@ var (
 // ValidateStaticHandlebarsFiles validates all Handlebars (.hbs) files in the package filesystem.
 // It returns a list of validation errors if any Handlebars files are invalid.
 // hbs are located in both the package root and data stream directories under the agent folder.
-func ValidateStaticHandlebarsFiles(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateStaticHandlebarsFiles(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 
 	// template files are placed at /agent/input directory or
@@ -37,7 +38,7 @@ func ValidateStaticHandlebarsFiles(fsys fspath.FS) specerrors.ValidationErrors {
 	datastreamEntries, err := fs.ReadDir(fsys, "data_stream")
 	if err != nil && !errors.Is(err, fs.ErrNotExist) {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("error reading data_stream directory: %w", err),
+			specerrors.NewStructuredErrorf("error reading data_stream directory: %w", err).WithFile(dataStreamDir),
 		}
 	}
 	for _, dsEntry := range datastreamEntries {
@@ -59,7 +60,7 @@ func validateTemplateDir(fsys fspath.FS, dir string) specerrors.ValidationErrors
 	entries, err := fs.ReadDir(fsys, dir)
 	if err != nil && !errors.Is(err, fs.ErrNotExist) {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("error trying to read :%s", dir),
+			specerrors.NewStructuredErrorf("error trying to read :%s", dir).WithFile(dir),
 		}
 	}
 	var errs specerrors.ValidationErrors
@@ -67,7 +68,7 @@ func validateTemplateDir(fsys fspath.FS, dir string) specerrors.ValidationErrors
 		if path.Ext(entry.Name()) == ".hbs" {
 			err := validateStaticHandlebarsEntry(fsys, dir, entry.Name())
 			if err != nil {
-				errs = append(errs, specerrors.NewStructuredErrorf("%w: error validating %s: %w", errInvalidHandlebarsTemplate, path.Join(dir, entry.Name()), err))
+				errs = append(errs, specerrors.NewStructuredErrorf("%w: error validating %s: %w", errInvalidHandlebarsTemplate, path.Join(dir, entry.Name()), err).WithFile(path.Join(dir, entry.Name())))
 			}
 			continue
 		}
@@ -75,12 +76,12 @@ func validateTemplateDir(fsys fspath.FS, dir string) specerrors.ValidationErrors
 			linkFilePath := path.Join(dir, entry.Name())
 			linkFile, err := linkedfiles.NewLinkedFile(fsys.Path(linkFilePath))
 			if err != nil {
-				errs = append(errs, specerrors.NewStructuredErrorf("error reading linked file %s: %w", linkFilePath, err))
+				errs = append(errs, specerrors.NewStructuredErrorf("error reading linked file %s: %w", linkFilePath, err).WithFile(linkFilePath))
 				continue
 			}
 			err = validateStaticHandlebarsEntry(fsys, dir, linkFile.IncludedFilePath)
 			if err != nil {
-				errs = append(errs, specerrors.NewStructuredErrorf("%w: error validating %s: %w", errInvalidHandlebarsTemplate, path.Join(dir, linkFile.IncludedFilePath), err))
+				errs = append(errs, specerrors.NewStructuredErrorf("%w: error validating %s: %w", errInvalidHandlebarsTemplate, path.Join(dir, linkFile.IncludedFilePath), err).WithFile(linkFilePath))
 			}
 		}
 	}
@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 	"path"
@@ -18,17 +19,17 @@ import (
 
 // ValidateILMPolicyPresent produces an error if the indicated ILM policy
 // is not defined in the data stream.
-func ValidateILMPolicyPresent(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateILMPolicyPresent(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	dataStreams, err := listDataStreams(fsys)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(dataStreamDir)}
 	}
 
 	var errs specerrors.ValidationErrors
 	for _, dataStream := range dataStreams {
 		err = validateILMPolicyInDataStream(fsys, dataStream)
 		if err != nil {
-			errs = append(errs, specerrors.NewStructuredError(err, specerrors.UnassignedCode))
+			errs = append(errs, specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(path.Join(dataStreamDir, dataStream, "manifest.yml")))
 		}
 	}
 	return errs
@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"io/fs"
 
 	"gopkg.in/yaml.v3"
@@ -47,7 +48,7 @@ type integrationPackageManifestDynamic struct {
 }
 
 // ValidateInputDynamicSignalTypes validates that dynamic_signal_types field is only used with otelcol input type
-func ValidateInputDynamicSignalTypes(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateInputDynamicSignalTypes(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 
 	// Validate package manifest
@@ -55,7 +56,7 @@ func ValidateInputDynamicSignalTypes(fsys fspath.FS) specerrors.ValidationErrors
 	data, err := fs.ReadFile(fsys, manifestPath)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to read manifest: %w", fsys.Path(manifestPath), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to read manifest: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 
 	// Try to determine package type first
@@ -65,7 +66,7 @@ func ValidateInputDynamicSignalTypes(fsys fspath.FS) specerrors.ValidationErrors
 	err = yaml.Unmarshal(data, &typeCheck)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 
 	switch typeCheck.Type {
@@ -90,7 +91,7 @@ func validateInputPackageDynamicSignalTypes(fsys fspath.FS, data []byte, manifes
 	err := yaml.Unmarshal(data, &manifest)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 
 	for _, policyTemplate := range manifest.PolicyTemplates {
@@ -102,14 +103,14 @@ func validateInputPackageDynamicSignalTypes(fsys fspath.FS, data []byte, manifes
 		if policyTemplate.Input != otelcolInputType {
 			errs = append(errs, specerrors.NewStructuredErrorf(
 				"file \"%s\" is invalid: policy template \"%s\": dynamic_signal_types is only allowed when input is 'otelcol', got '%s'",
-				fsys.Path(manifestPath), policyTemplate.Name, policyTemplate.Input))
+				fsys.Path(manifestPath), policyTemplate.Name, policyTemplate.Input).WithFile(manifestPath))
 			continue
 		}
 		// Must not have type field set
 		if policyTemplate.Type != "" {
 			errs = append(errs, specerrors.NewStructuredErrorf(
 				"file \"%s\" is invalid: policy template \"%s\": type field must not be set when dynamic_signal_types is true",
-				fsys.Path(manifestPath), policyTemplate.Name))
+				fsys.Path(manifestPath), policyTemplate.Name).WithFile(manifestPath))
 		}
 	}
 
@@ -123,7 +124,7 @@ func validateIntegrationPackageDynamicSignalTypes(fsys fspath.FS, data []byte, m
 	err := yaml.Unmarshal(data, &manifest)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 
 	for _, policyTemplate := range manifest.PolicyTemplates {
@@ -136,7 +137,7 @@ func validateIntegrationPackageDynamicSignalTypes(fsys fspath.FS, data []byte, m
 			if input.Type != otelcolInputType {
 				errs = append(errs, specerrors.NewStructuredErrorf(
 					"file \"%s\" is invalid: policy template \"%s\": input type \"%s\": dynamic_signal_types is only allowed when input is 'otelcol'",
-					fsys.Path(manifestPath), policyTemplate.Name, input.Type))
+					fsys.Path(manifestPath), policyTemplate.Name, input.Type).WithFile(manifestPath))
 			}
 		}
 	}
@@ -160,7 +161,7 @@ func validateDataStreamManifests(fsys fspath.FS) specerrors.ValidationErrors {
 	dataStreams, err := listDataStreams(fsys)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("failed to list data streams: %w", err)}
+			specerrors.NewStructuredErrorf("failed to list data streams: %w", err).WithFile(dataStreamDir)}
 	}
 
 	for _, dataStream := range dataStreams {
@@ -168,7 +169,7 @@ func validateDataStreamManifests(fsys fspath.FS) specerrors.ValidationErrors {
 		data, err := fs.ReadFile(fsys, manifestPath)
 		if err != nil {
 			errs = append(errs, specerrors.NewStructuredErrorf(
-				"file \"%s\" is invalid: failed to read manifest: %w", fsys.Path(manifestPath), err))
+				"file \"%s\" is invalid: failed to read manifest: %w", fsys.Path(manifestPath), err).WithFile(manifestPath))
 			continue
 		}
 
@@ -176,7 +177,7 @@ func validateDataStreamManifests(fsys fspath.FS) specerrors.ValidationErrors {
 		err = yaml.Unmarshal(data, &manifest)
 		if err != nil {
 			errs = append(errs, specerrors.NewStructuredErrorf(
-				"file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err))
+				"file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err).WithFile(manifestPath))
 			continue
 		}
 
@@ -189,7 +190,7 @@ func validateDataStreamManifests(fsys fspath.FS) specerrors.ValidationErrors {
 			if stream.Input != otelcolInputType {
 				errs = append(errs, specerrors.NewStructuredErrorf(
 					"file \"%s\" is invalid: stream with input type \"%s\": dynamic_signal_types is only allowed when input is '%s'",
-					fsys.Path(manifestPath), stream.Input, otelcolInputType))
+					fsys.Path(manifestPath), stream.Input, otelcolInputType).WithFile(manifestPath))
 			}
 		}
 	}
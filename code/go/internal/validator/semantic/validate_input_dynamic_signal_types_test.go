@@ -30,7 +30,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputDynamicSignalTypes(fspath.DirFS(d))
+		errs := ValidateInputDynamicSignalTypes(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs, "expected no validation errors")
 	})
 
@@ -48,7 +48,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputDynamicSignalTypes(fspath.DirFS(d))
+		errs := ValidateInputDynamicSignalTypes(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs, "expected no validation errors")
 	})
 
@@ -65,7 +65,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputDynamicSignalTypes(fspath.DirFS(d))
+		errs := ValidateInputDynamicSignalTypes(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs, "expected no validation errors")
 	})
 
@@ -83,7 +83,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputDynamicSignalTypes(fspath.DirFS(d))
+		errs := ValidateInputDynamicSignalTypes(t.Context(), fspath.DirFS(d))
 		require.NotEmpty(t, errs, "expected validation errors")
 		assert.Len(t, errs, 1)
 		assert.Contains(t, errs[0].Error(), "dynamic_signal_types is only allowed when input is 'otelcol'")
@@ -100,7 +100,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputDynamicSignalTypes(fspath.DirFS(d))
+		errs := ValidateInputDynamicSignalTypes(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs, "expected no validation errors for non-input packages without field")
 	})
 
@@ -123,7 +123,7 @@ policy_templates:
 		err = os.Mkdir(d+"/data_stream", 0o755)
 		require.NoError(t, err)
 
-		errs := ValidateInputDynamicSignalTypes(fspath.DirFS(d))
+		errs := ValidateInputDynamicSignalTypes(t.Context(), fspath.DirFS(d))
 		require.NotEmpty(t, errs, "expected validation errors for non-otelcol with dynamic_signal_types")
 		assert.Len(t, errs, 1)
 		assert.Contains(t, errs[0].Error(), "dynamic_signal_types is only allowed when input is 'otelcol'")
@@ -141,7 +141,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputDynamicSignalTypes(fspath.DirFS(d))
+		errs := ValidateInputDynamicSignalTypes(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs, "expected no validation errors")
 	})
 
@@ -163,7 +163,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputDynamicSignalTypes(fspath.DirFS(d))
+		errs := ValidateInputDynamicSignalTypes(t.Context(), fspath.DirFS(d))
 		require.NotEmpty(t, errs, "expected validation errors")
 		assert.Len(t, errs, 1)
 		assert.Contains(t, errs[0].Error(), "dynamic_signal_types is only allowed when input is 'otelcol'")
@@ -186,7 +186,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputDynamicSignalTypes(fspath.DirFS(d))
+		errs := ValidateInputDynamicSignalTypes(t.Context(), fspath.DirFS(d))
 		require.NotEmpty(t, errs, "expected validation errors")
 		assert.Len(t, errs, 1)
 		assert.Contains(t, errs[0].Error(), "type field must not be set when dynamic_signal_types is true")
@@ -206,7 +206,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputDynamicSignalTypes(fspath.DirFS(d))
+		errs := ValidateInputDynamicSignalTypes(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs, "expected no validation errors when type is present without dynamic_signal_types")
 	})
 
@@ -224,7 +224,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputDynamicSignalTypes(fspath.DirFS(d))
+		errs := ValidateInputDynamicSignalTypes(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs, "expected no validation errors when type is present with dynamic_signal_types: false")
 	})
 
@@ -251,7 +251,7 @@ streams:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputDynamicSignalTypes(fspath.DirFS(d))
+		errs := ValidateInputDynamicSignalTypes(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs, "expected no validation errors for data stream with otelcol")
 	})
 
@@ -278,7 +278,7 @@ streams:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputDynamicSignalTypes(fspath.DirFS(d))
+		errs := ValidateInputDynamicSignalTypes(t.Context(), fspath.DirFS(d))
 		require.NotEmpty(t, errs, "expected validation errors for data stream with non-otelcol")
 		assert.Len(t, errs, 1)
 		assert.Contains(t, errs[0].Error(), "dynamic_signal_types is only allowed when input is 'otelcol'")
@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"errors"
 	"io/fs"
 	"path"
@@ -35,27 +36,27 @@ type inputPackageManifest struct { // package manifest
 }
 
 // ValidateInputPackagesPolicyTemplates validates the policy template entries of an input package
-func ValidateInputPackagesPolicyTemplates(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateInputPackagesPolicyTemplates(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 
 	manifestPath := "manifest.yml"
 	data, err := fs.ReadFile(fsys, manifestPath)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %ww", fsys.Path(manifestPath), errFailedToReadManifest)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %ww", fsys.Path(manifestPath), errFailedToReadManifest).WithFile(manifestPath)}
 	}
 
 	var manifest inputPackageManifest
 	err = yaml.Unmarshal(data, &manifest)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), errFailedToParseManifest)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), errFailedToParseManifest).WithFile(manifestPath)}
 	}
 
 	if manifest.Type != inputPackageType {
 		return specerrors.ValidationErrors{
 			specerrors.NewStructuredErrorf("file \"%s\" is invalid: expected package type \"%s\", got \"%s\": %w",
-				fsys.Path(manifestPath), inputPackageType, manifest.Type, errInvalidPackageType)}
+				fsys.Path(manifestPath), inputPackageType, manifest.Type, errInvalidPackageType).WithFile(manifestPath)}
 	}
 
 	for _, policyTemplate := range manifest.PolicyTemplates {
@@ -63,7 +64,7 @@ func ValidateInputPackagesPolicyTemplates(fsys fspath.FS) specerrors.ValidationE
 		if err != nil {
 			errs = append(errs, specerrors.NewStructuredErrorf(
 				"file \"%s\" is invalid: policy template \"%s\" references template_path \"%s\": %w",
-				fsys.Path(manifestPath), policyTemplate.Name, policyTemplate.TemplatePath, err))
+				fsys.Path(manifestPath), policyTemplate.Name, policyTemplate.TemplatePath, err).WithFile(manifestPath))
 		}
 	}
 
@@ -32,7 +32,7 @@ policy_templates:
 		err = os.WriteFile(filepath.Join(d, "agent", "input", "udp.yml.hbs"), []byte("# UDP template"), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputPackagesPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateInputPackagesPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs, "expected no validation errors")
 
 	})
@@ -49,7 +49,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputPackagesPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateInputPackagesPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.NotEmpty(t, errs, "expected no validation errors")
 
 		assert.Len(t, errs, 1)
@@ -69,7 +69,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputPackagesPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateInputPackagesPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.NotEmpty(t, errs, "expected validation errors")
 		assert.Len(t, errs, 1)
 		assert.ErrorIs(t, errs[0], errTemplateNotFound)
@@ -88,7 +88,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateInputPackagesPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateInputPackagesPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.NotEmpty(t, errs, "expected validation errors")
 		assert.Len(t, errs, 1)
 		assert.ErrorIs(t, errs[0], errInvalidPackageType)
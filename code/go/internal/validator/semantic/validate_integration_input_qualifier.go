@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 
@@ -33,18 +34,18 @@ type integrationPackageManifestQualifier struct {
 // multiple inputs of the same type, all of them must have a name set. Without
 // names, Fleet cannot distinguish them, leading to the ambiguity this field
 // aims to solve.
-func ValidateIntegrationInputQualifier(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateIntegrationInputQualifier(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	manifestPath := "manifest.yml"
 	data, err := fs.ReadFile(fsys, manifestPath)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to read manifest: %w", fsys.Path(manifestPath), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to read manifest: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 
 	var manifest integrationPackageManifestQualifier
 	if err := yaml.Unmarshal(data, &manifest); err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 
 	if manifest.Type != integrationPackageType {
@@ -70,7 +71,7 @@ func validateInputQualifiers(fsys fspath.FS, manifest integrationPackageManifest
 				errs = append(errs, specerrors.NewStructuredError(
 					fmt.Errorf("file \"%s\" is invalid: policy template \"%s\": input with type \"%s\" must have a name when multiple inputs of the same type are present",
 						fsys.Path(manifestPath), policyTemplate.Name, input.Type),
-					specerrors.CodeIntegrationInputQualifierRequired))
+					specerrors.CodeIntegrationInputQualifierRequired).WithFile(manifestPath))
 			}
 		}
 	}
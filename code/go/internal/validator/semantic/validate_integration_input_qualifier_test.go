@@ -116,7 +116,7 @@ policy_templates:
 			err := os.WriteFile(filepath.Join(d, "manifest.yml"), []byte(tc.manifest), 0o644)
 			require.NoError(t, err)
 
-			errs := ValidateIntegrationInputQualifier(fspath.DirFS(d))
+			errs := ValidateIntegrationInputQualifier(t.Context(), fspath.DirFS(d))
 
 			if len(tc.expectedErrs) == 0 {
 				require.Empty(t, errs)
@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"io/fs"
 
 	"gopkg.in/yaml.v3"
@@ -15,7 +16,7 @@ import (
 
 // ValidateIntegrationInputsDeprecation checks that if all inputs in an integration package are deprecated,
 // then the integration package itself must be marked as deprecated.
-func ValidateIntegrationInputsDeprecation(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateIntegrationInputsDeprecation(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 
 	type manifest struct {
 		Type       string `yaml:"type,omitempty"`
@@ -37,14 +38,14 @@ func ValidateIntegrationInputsDeprecation(fsys fspath.FS) specerrors.ValidationE
 	data, err := fs.ReadFile(fsys, manifestPath)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 
 	var m manifest
 	err = yaml.Unmarshal(data, &m)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 	// skip if not an integration package
 	if m.Type != integrationPackageType {
@@ -68,7 +69,7 @@ func ValidateIntegrationInputsDeprecation(fsys fspath.FS) specerrors.ValidationE
 	}
 	if deprecated == total && total > 0 {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: all inputs are deprecated but the integration package is not marked as deprecated", fsys.Path(manifestPath)),
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: all inputs are deprecated but the integration package is not marked as deprecated", fsys.Path(manifestPath)).WithFile(manifestPath),
 		}
 	}
 
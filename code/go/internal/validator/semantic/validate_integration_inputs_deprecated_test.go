@@ -34,7 +34,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationInputsDeprecation(fspath.DirFS(d))
+		errs := ValidateIntegrationInputsDeprecation(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs, "expected no validation errors")
 
 	})
@@ -53,7 +53,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationInputsDeprecation(fspath.DirFS(d))
+		errs := ValidateIntegrationInputsDeprecation(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs, "expected no validation errors")
 	})
 
@@ -75,7 +75,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationInputsDeprecation(fspath.DirFS(d))
+		errs := ValidateIntegrationInputsDeprecation(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs, "expected no validation errors")
 	})
 
@@ -93,7 +93,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationInputsDeprecation(fspath.DirFS(d))
+		errs := ValidateIntegrationInputsDeprecation(t.Context(), fspath.DirFS(d))
 		require.NotEmpty(t, errs, "expected validation errors")
 		require.Len(t, errs, 1)
 		assert.ErrorContains(t, errs[0], "all inputs are deprecated but the integration package is not marked as deprecated")
@@ -111,7 +111,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationInputsDeprecation(fspath.DirFS(d))
+		errs := ValidateIntegrationInputsDeprecation(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs, "expected no validation errors")
 	})
 
@@ -130,7 +130,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationInputsDeprecation(fspath.DirFS(d))
+		errs := ValidateIntegrationInputsDeprecation(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs, "expected no validation errors")
 	})
 
@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -62,21 +63,21 @@ func (e *dataStreamManifestReadError) Unwrap() error { return e.err }
 // ValidateIntegrationPolicyTemplates validates agent input and stream template files for
 // integration packages, following Fleet/EPM resolution (template_paths before template_path;
 // stream default stream.yml.hbs when neither is set on a stream).
-func ValidateIntegrationPolicyTemplates(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateIntegrationPolicyTemplates(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 
 	manifestPath := "manifest.yml"
 	data, err := fs.ReadFile(fsys, manifestPath)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %ww", fsys.Path(manifestPath), errFailedToReadManifest)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %ww", fsys.Path(manifestPath), errFailedToReadManifest).WithFile(manifestPath)}
 	}
 
 	var manifest integrationPackageManifest
 	err = yaml.Unmarshal(data, &manifest)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), errFailedToParseManifest)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), errFailedToParseManifest).WithFile(manifestPath)}
 	}
 
 	if manifest.Type != integrationPackageType {
@@ -88,10 +89,10 @@ func ValidateIntegrationPolicyTemplates(fsys fspath.FS) specerrors.ValidationErr
 		var dsReadErr *dataStreamManifestReadError
 		if errors.As(err, &dsReadErr) {
 			return specerrors.ValidationErrors{
-				specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(dsReadErr.relPath), dsReadErr.err)}
+				specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(dsReadErr.relPath), dsReadErr.err).WithFile(dsReadErr.relPath)}
 		}
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("invalid data stream manifests: %w", err)}
+			specerrors.NewStructuredErrorf("invalid data stream manifests: %w", err).WithFile(dataStreamDir)}
 	}
 
 	errs = append(errs, validateAllDataStreamStreamTemplates(fsys, dataStreamsManifestMap)...)
@@ -100,7 +101,7 @@ func ValidateIntegrationPolicyTemplates(fsys fspath.FS) specerrors.ValidationErr
 		if err := validateIntegrationPolicyTemplateInputs(fsys, policyTemplate); err != nil {
 			errs = append(errs, specerrors.NewStructuredErrorf(
 				"file \"%s\" is invalid: policy template \"%s\": %w",
-				fsys.Path(manifestPath), policyTemplate.Name, err))
+				fsys.Path(manifestPath), policyTemplate.Name, err).WithFile(manifestPath))
 		}
 	}
 
@@ -153,7 +154,7 @@ func validateAllDataStreamStreamTemplates(fsys fspath.FS, dsMap map[string]dataS
 			if err := validateSingleDataStreamStreamTemplates(fsys, dsDir, s); err != nil {
 				errs = append(errs, specerrors.NewStructuredErrorf(
 					"file \"%s\" is invalid: data stream \"%s\" stream input %q: %w",
-					fsys.Path(dsManifestPath), dsDir, s.Input, err))
+					fsys.Path(dsManifestPath), dsDir, s.Input, err).WithFile(dsManifestPath))
 			}
 		}
 	}
@@ -89,7 +89,7 @@ streams:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs)
 	})
 
@@ -113,7 +113,7 @@ streams:
 		err = os.WriteFile(filepath.Join(d, "data_stream", "logs", "agent", "stream", "access.yml.hbs"), []byte(`x`), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.Len(t, errs, 1)
 		require.Contains(t, errs[0].Error(), `data stream "data_stream/logs" stream input "nginx/error": template file not found`)
 	})
@@ -133,7 +133,7 @@ streams:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs)
 	})
 
@@ -152,7 +152,7 @@ streams:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs)
 	})
 
@@ -177,7 +177,7 @@ streams:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs)
 	})
 
@@ -211,7 +211,7 @@ streams:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.Len(t, errs, 1)
 		require.Contains(t, errs[0].Error(), `data stream "data_stream/logs" stream input "logfile": template file not found`)
 	})
@@ -251,7 +251,7 @@ streams:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs)
 	})
 
@@ -269,7 +269,7 @@ streams:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs)
 	})
 
@@ -290,7 +290,7 @@ streams:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs)
 	})
 
@@ -309,7 +309,7 @@ streams:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.Len(t, errs, 1)
 		require.Contains(t, errs[0].Error(), "template file not found")
 	})
@@ -320,7 +320,7 @@ func TestValidateIntegrationPolicyTemplates_NonIntegrationType(t *testing.T) {
 	err := os.WriteFile(filepath.Join(d, "manifest.yml"), []byte(`type: input`), 0o644)
 	require.NoError(t, err)
 
-	errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+	errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 	require.Nil(t, errs)
 }
 
@@ -350,7 +350,7 @@ streams:
 	err = os.WriteFile(filepath.Join(d, "data_stream", "logs", "agent", "stream", "access.yml.hbs"), []byte("template"), 0o644)
 	require.NoError(t, err)
 
-	errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+	errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 	require.Empty(t, errs)
 }
 
@@ -379,7 +379,7 @@ streams:
 	err = os.WriteFile(filepath.Join(d, "data_stream", "logs", "agent", "stream", "stream.yml.hbs"), []byte("template"), 0o644)
 	require.NoError(t, err)
 
-	errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+	errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 	require.Empty(t, errs)
 }
 func TestValidateIntegrationPolicyTemplates_ComposableInputs(t *testing.T) {
@@ -396,7 +396,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs)
 	})
 
@@ -419,7 +419,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.Empty(t, errs)
 	})
 
@@ -440,7 +440,7 @@ policy_templates:
 `), 0o644)
 		require.NoError(t, err)
 
-		errs := ValidateIntegrationPolicyTemplates(fspath.DirFS(d))
+		errs := ValidateIntegrationPolicyTemplates(t.Context(), fspath.DirFS(d))
 		require.Len(t, errs, 1)
 		require.Contains(t, errs[0].Error(), "template file not found")
 	})
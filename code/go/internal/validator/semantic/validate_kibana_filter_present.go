@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,13 +27,13 @@ var (
 
 // ValidateKibanaFilterPresent checks that all the dashboards included in a package
 // contain a filter, so only data related to its datasets is queried.
-func ValidateKibanaFilterPresent(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateKibanaFilterPresent(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 
 	filePaths := path.Join("kibana", "dashboard", "*.json")
 	dashboardFiles, err := pkgpath.Files(fsys, filePaths)
 	if err != nil {
-		errs = append(errs, specerrors.NewStructuredErrorf("error finding Kibana dashboard files: %w", err))
+		errs = append(errs, specerrors.NewStructuredErrorf("error finding Kibana dashboard files: %w", err).WithFile("kibana"))
 		return errs
 	}
 	for _, file := range dashboardFiles {
@@ -45,7 +46,7 @@ func ValidateKibanaFilterPresent(fsys fspath.FS) specerrors.ValidationErrors {
 			errs = append(errs,
 				specerrors.NewStructuredError(
 					fmt.Errorf("file \"%s\" is invalid: expected filter in dashboard: %w", fsys.Path(file.Path()), err),
-					code),
+					code).WithFile(file.Path()),
 			)
 		}
 	}
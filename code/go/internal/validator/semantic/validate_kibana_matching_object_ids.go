@@ -5,11 +5,12 @@
 package semantic
 
 import (
+	"context"
 	"path"
 	"strings"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
-	"github.com/elastic/package-spec/v3/code/go/internal/pkgpath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
@@ -17,48 +18,42 @@ import (
 // object files that define IDs not matching the file's name. That is, it returns
 // validation errors if a Kibana object file, foo.json, in the package defines
 // an object ID other than foo inside it.
-func ValidateKibanaObjectIDs(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateKibanaObjectIDs(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 
-	filePaths := path.Join("kibana", "*", "*.json")
-	objectFiles, err := pkgpath.Files(fsys, filePaths)
+	objects, err := model.KibanaObjects()
 	if err != nil {
-		errs = append(errs, specerrors.NewStructuredErrorf("error finding Kibana object files: %w", err))
+		errs = append(errs, specerrors.NewStructuredErrorf("error finding Kibana object files: %w", err).WithFile("kibana"))
 		return errs
 	}
 
-	for _, objectFile := range objectFiles {
-		filePath := objectFile.Path()
+	for _, object := range objects {
+		filePath := object.Path
 
-		objectID, err := objectFile.Values("$.id")
+		definition, err := object.Definition()
 		if err != nil {
-			errs = append(errs, specerrors.NewStructuredErrorf("unable to get Kibana object ID in file [%s]: %w", fsys.Path(filePath), err))
+			errs = append(errs, specerrors.NewStructuredErrorf("unable to get Kibana object ID in file [%s]: %w", fsys.Path(filePath), err).WithFile(filePath))
 			continue
 		}
+		objectID := definition.ID
 
 		// Special case: object is of type 'security_rule'
-		if path.Base(path.Dir(filePath)) == "security_rule" {
-			ruleID, err := objectFile.Values("$.attributes.rule_id")
-			if err != nil {
-				errs = append(errs, specerrors.NewStructuredErrorf("unable to get rule ID in file [%s]: %w", fsys.Path(filePath), err))
+		if object.AssetType == "security_rule" {
+			rawRuleID, found := definition.Attributes["rule_id"]
+			if !found {
+				errs = append(errs, specerrors.NewStructuredErrorf("unable to get rule ID in file [%s]", fsys.Path(filePath)).WithFile(filePath))
 				continue
 			}
 
-			objectIDValue, ok := objectID.(string)
+			ruleID, ok := rawRuleID.(string)
 			if !ok {
-				errs = append(errs, specerrors.NewStructuredErrorf("expect object ID to be a string: %w", err))
+				errs = append(errs, specerrors.NewStructuredErrorf("expect rule ID to be a string").WithFile(filePath))
 				continue
 			}
 
-			ruleIDValue, ok := ruleID.(string)
-			if !ok {
-				errs = append(errs, specerrors.NewStructuredErrorf("expect rule ID to be a string: %w", err))
-				continue
-			}
-
-			if !strings.HasPrefix(objectIDValue, ruleIDValue) {
+			if !strings.HasPrefix(objectID, ruleID) {
 				errs = append(errs,
-					specerrors.NewStructuredErrorf("kibana object ID [%s] should start with rule ID [%s]", objectIDValue, ruleIDValue))
+					specerrors.NewStructuredErrorf("kibana object ID [%s] should start with rule ID [%s]", objectID, ruleID).WithFile(filePath))
 				continue
 			}
 		}
@@ -69,7 +64,7 @@ func ValidateKibanaObjectIDs(fsys fspath.FS) specerrors.ValidationErrors {
 		fileID := strings.Replace(fileName, fileExt, "", -1)
 		if fileID != objectID {
 			errs = append(errs,
-				specerrors.NewStructuredErrorf("kibana object file [%s] defines non-matching ID [%s]", fsys.Path(filePath), objectID))
+				specerrors.NewStructuredErrorf("kibana object file [%s] defines non-matching ID [%s]", fsys.Path(filePath), objectID).WithFile(filePath))
 		}
 	}
 
@@ -5,12 +5,12 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
-	"path"
 	"slices"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
-	"github.com/elastic/package-spec/v3/code/go/internal/pkgpath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
@@ -18,6 +18,7 @@ type objectReference struct {
 	objectType string
 	objectID   string
 	filePath   string
+	file       string
 }
 
 var exceptionAssets = []string{
@@ -29,39 +30,43 @@ var exceptionAssets = []string{
 // returns validation errors if a Kibana object file in the package references another
 // Kibana object with ID i, but no Kibana object file for object ID i is found in the
 // package.
-func ValidateKibanaNoDanglingObjectIDs(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateKibanaNoDanglingObjectIDs(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 
 	installedIDs := []objectReference{}
 	referencedIDs := []objectReference{}
 
-	filePaths := path.Join("kibana", "*", "*.json")
-	objectFiles, err := pkgpath.Files(fsys, filePaths)
+	objects, err := model.KibanaObjects()
 	if err != nil {
-		errs = append(errs, specerrors.NewStructuredErrorf("error finding Kibana object files: %w", err))
+		errs = append(errs, specerrors.NewStructuredErrorf("error finding Kibana object files: %w", err).WithFile("kibana"))
 		return errs
 	}
-	for _, objectFile := range objectFiles {
-		filePath := objectFile.Path()
+	for _, object := range objects {
+		filePath := object.Path
 
-		currentReference, err := getCurrentObjectReference(objectFile, fsys.Path(filePath))
+		definition, err := object.Definition()
 		if err != nil {
 			errs = append(errs,
-				specerrors.NewStructuredErrorf("unable to create reference from file [%s]: %w", fsys.Path(filePath), err),
+				specerrors.NewStructuredErrorf("unable to create reference from file [%s]: %w", fsys.Path(filePath), err).WithFile(filePath),
 			)
+			continue
 		}
 
-		installedIDs = append(installedIDs, currentReference)
+		installedIDs = append(installedIDs, objectReference{
+			objectID:   definition.ID,
+			objectType: definition.Type,
+			filePath:   fsys.Path(filePath),
+			file:       filePath,
+		})
 
-		referencedObjects, err := getReferencesListFromCurrentObject(objectFile, fsys.Path(filePath))
-		if err != nil {
-			errs = append(errs,
-				specerrors.NewStructuredErrorf("unable to create referenced objects from file [%s]: %w", fsys.Path(filePath), err),
-			)
-			continue
+		for _, reference := range filterReferences(definition.References, exceptionAssets) {
+			referencedIDs = append(referencedIDs, objectReference{
+				objectID:   reference.ID,
+				objectType: reference.Type,
+				filePath:   fsys.Path(filePath),
+				file:       filePath,
+			})
 		}
-
-		referencedIDs = append(referencedIDs, referencedObjects...)
 	}
 
 	if len(referencedIDs) == 0 {
@@ -83,89 +88,21 @@ func ValidateKibanaNoDanglingObjectIDs(fsys fspath.FS) specerrors.ValidationErro
 			errs = append(errs,
 				specerrors.NewStructuredError(
 					fmt.Errorf("file \"%s\" is invalid: dangling reference found: %s (%s)", reference.filePath, reference.objectID, reference.objectType),
-					specerrors.CodeKibanaDanglingObjectsIDs))
+					specerrors.CodeKibanaDanglingObjectsIDs).WithFile(reference.file))
 		}
 	}
 
 	return errs
 }
 
-func getCurrentObjectReference(asset pkgpath.File, filePath string) (objectReference, error) {
-	var reference objectReference
-
-	valueID, err := asset.Values("$.id")
-	if err != nil {
-		return reference, fmt.Errorf("unable to get ID field : %w", err)
-	}
-	stringValueID, ok := valueID.(string)
-	if !ok {
-		return reference, fmt.Errorf("expect value ID to be a string: %w", err)
-	}
-
-	valueType, err := asset.Values("$.type")
-	if err != nil {
-		return reference, fmt.Errorf("unable to get Type field : %w", err)
-	}
-	stringValueType, ok := valueType.(string)
-	if !ok {
-		return reference, fmt.Errorf("expect value Type to be a string: %w", err)
-	}
-
-	reference = objectReference{
-		objectID:   stringValueID,
-		objectType: stringValueType,
-		filePath:   filePath,
-	}
-
-	return reference, nil
-}
-
-func getReferencesListFromCurrentObject(asset pkgpath.File, filePath string) ([]objectReference, error) {
-	referencedIDs := []objectReference{}
-	objectReferences, err := asset.Values(`$.references`)
-	if err != nil {
-		// no references key in dashboard json
-		return referencedIDs, nil
-	}
-
-	references, err := filterReferences(objectReferences, exceptionAssets)
-	if err != nil {
-		return nil, fmt.Errorf("error getting references: %w", err)
-	}
-
-	if len(references) == 0 {
-		return referencedIDs, nil
-	}
-
-	for _, reference := range references {
-		referencedIDs = append(referencedIDs, objectReference{
-			objectID:   reference.ID,
-			objectType: reference.Type,
-			filePath:   filePath,
-		})
-	}
-
-	return referencedIDs, nil
-}
-
-func filterReferences(val interface{}, exceptions []string) ([]reference, error) {
-	allReferences, err := toReferenceSlice(val)
-	if err != nil {
-		return []reference{}, fmt.Errorf("unable to convert references: %w", err)
-	}
-
-	if len(allReferences) == 0 {
-		return []reference{}, nil
-	}
-
+// filterReferences returns the references whose types are not in the exceptions.
+func filterReferences(allReferences []reference, exceptions []string) []reference {
 	var references []reference
 	for _, reference := range allReferences {
 		if slices.Contains(exceptions, reference.Type) {
 			continue
 		}
 		references = append(references, reference)
-
 	}
-	return references, nil
-
+	return references
 }
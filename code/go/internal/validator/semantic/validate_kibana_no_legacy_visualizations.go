@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"path"
 
 	"github.com/elastic/kbncontent"
@@ -15,7 +16,7 @@ import (
 )
 
 // ValidateKibanaNoLegacyVisualizations reports legacy Kibana visualizations in a package.
-func ValidateKibanaNoLegacyVisualizations(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateKibanaNoLegacyVisualizations(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 
 	// Collect by-reference visualizations for reference later.
@@ -28,19 +29,19 @@ func ValidateKibanaNoLegacyVisualizations(fsys fspath.FS) specerrors.ValidationE
 
 		visJSON, err := file.Values("$")
 		if err != nil {
-			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: error getting JSON: %w", filePath, err))
+			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: error getting JSON: %w", filePath, err).WithFile(file.Path()))
 			continue
 		}
 
 		visMap, ok := visJSON.(map[string]interface{})
 		if !ok {
-			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: JSON of unexpected type %T", filePath, visJSON))
+			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: JSON of unexpected type %T", filePath, visJSON).WithFile(file.Path()))
 			continue
 		}
 
 		desc, err := kbncontent.DescribeVisualizationSavedObject(visMap)
 		if err != nil {
-			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: error describing visualization saved object: %w", filePath, err))
+			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: error describing visualization saved object: %w", filePath, err).WithFile(file.Path()))
 			continue
 		}
 
@@ -49,14 +50,14 @@ func ValidateKibanaNoLegacyVisualizations(fsys fspath.FS) specerrors.ValidationE
 			if result, err := desc.Editor(); err == nil {
 				editor = result
 			}
-			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: found legacy visualization \"%s\" (%s, %s)", filePath, desc.Title(), desc.SemanticType(), editor))
+			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: found legacy visualization \"%s\" (%s, %s)", filePath, desc.Title(), desc.SemanticType(), editor).WithFile(file.Path()))
 		}
 	}
 
 	dashboardFilePaths := path.Join("kibana", "dashboard", "*.json")
 	dashboardFiles, err := pkgpath.Files(fsys, dashboardFilePaths)
 	if err != nil {
-		errs = append(errs, specerrors.NewStructuredErrorf("error finding Kibana dashboard files: %w", err))
+		errs = append(errs, specerrors.NewStructuredErrorf("error finding Kibana dashboard files: %w", err).WithFile("kibana"))
 		return errs
 	}
 
@@ -65,19 +66,19 @@ func ValidateKibanaNoLegacyVisualizations(fsys fspath.FS) specerrors.ValidationE
 
 		dashboardJSON, err := file.Values("$")
 		if err != nil {
-			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: error getting dashboard JSON: %w", filePath, err))
+			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: error getting dashboard JSON: %w", filePath, err).WithFile(file.Path()))
 			continue
 		}
 
 		dashboardTitle, err := kbncontent.GetDashboardTitle(dashboardJSON)
 		if err != nil {
-			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: error fetching dashboard title: %w", filePath, err))
+			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: error fetching dashboard title: %w", filePath, err).WithFile(file.Path()))
 			continue
 		}
 
 		visualizations, err := kbncontent.DescribeByValueDashboardPanels(dashboardJSON)
 		if err != nil {
-			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: error describing dashboard panels: %w", filePath, err))
+			errs = append(errs, specerrors.NewStructuredErrorf("file \"%s\" is invalid: error describing dashboard panels: %w", filePath, err).WithFile(file.Path()))
 			continue
 		}
 
@@ -87,7 +88,7 @@ func ValidateKibanaNoLegacyVisualizations(fsys fspath.FS) specerrors.ValidationE
 				if result, err := desc.Editor(); err == nil {
 					editor = result
 				}
-				err := specerrors.NewStructuredErrorf("file \"%s\" is invalid: \"%s\" contains legacy visualization: \"%s\" (%s, %s)", filePath, dashboardTitle, desc.Title(), desc.SemanticType(), editor)
+				err := specerrors.NewStructuredErrorf("file \"%s\" is invalid: \"%s\" contains legacy visualization: \"%s\" (%s, %s)", filePath, dashboardTitle, desc.Title(), desc.SemanticType(), editor).WithFile(file.Path())
 				errs = append(errs, err)
 			}
 		}
@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -36,7 +37,7 @@ type sharedTagYML struct {
 // ValidateKibanaTagDuplicates checks for duplicate Kibana tag names
 // between the kibana/tags.yml file and the tags defined in the package's kibana/tag/*.json files.
 // It returns a list of validation errors if any duplicates are found.
-func ValidateKibanaTagDuplicates(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateKibanaTagDuplicates(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 	sharedTagNames, verr := getValidatedSharedKibanaTags(fsys)
 	if len(verr) > 0 {
@@ -61,12 +62,12 @@ func getValidatedSharedKibanaTags(fsys fspath.FS) ([]string, specerrors.Validati
 		if errors.Is(err, fs.ErrNotExist) {
 			return nil, nil
 		}
-		return nil, specerrors.ValidationErrors{specerrors.NewStructuredErrorf("error reading file %s: %v", tagsPath, err)}
+		return nil, specerrors.ValidationErrors{specerrors.NewStructuredErrorf("error reading file %s: %v", tagsPath, err).WithFile(tagsPath)}
 	}
 	var sharedKibanaTags []sharedTagYML
 	err = yaml.Unmarshal(b, &sharedKibanaTags)
 	if err != nil {
-		return nil, specerrors.ValidationErrors{specerrors.NewStructuredErrorf("error unmarshaling file %s: %v", tagsPath, err)}
+		return nil, specerrors.ValidationErrors{specerrors.NewStructuredErrorf("error unmarshaling file %s: %v", tagsPath, err).WithFile(tagsPath)}
 	}
 
 	tags := make([]string, 0)
@@ -75,7 +76,7 @@ func getValidatedSharedKibanaTags(fsys fspath.FS) ([]string, specerrors.Validati
 	for _, tag := range sharedKibanaTags {
 		if slices.Contains(tags, tag.Name) {
 			errs = append(errs, specerrors.NewStructuredError(
-				fmt.Errorf("file \"%s\" is invalid: duplicate tag name '%s' found", fsys.Path(tagsPath), tag.Name), specerrors.CodeKibanaTagDuplicates))
+				fmt.Errorf("file \"%s\" is invalid: duplicate tag name '%s' found", fsys.Path(tagsPath), tag.Name), specerrors.CodeKibanaTagDuplicates).WithFile(tagsPath))
 			continue
 		}
 		tags = append(tags, tag.Name)
@@ -89,7 +90,7 @@ func validateKibanaPackageTagsDuplicates(fsys fspath.FS, sharedTagNames []string
 		if errors.Is(err, fs.ErrNotExist) {
 			return nil
 		}
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("error reading kibana/tag directory: %v", err)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("error reading kibana/tag directory: %v", err).WithFile(path.Join("kibana", "tag"))}
 	}
 
 	tags := make([]string, 0)
@@ -102,13 +103,13 @@ func validateKibanaPackageTagsDuplicates(fsys fspath.FS, sharedTagNames []string
 		filePath := path.Join("kibana", "tag", entry.Name())
 		b, err := fs.ReadFile(fsys, filePath)
 		if err != nil {
-			errs = append(errs, specerrors.NewStructuredErrorf("error reading file %s: %v", fsys.Path(filePath), err))
+			errs = append(errs, specerrors.NewStructuredErrorf("error reading file %s: %v", fsys.Path(filePath), err).WithFile(filePath))
 			continue
 		}
 		var pkgTag packageSpecTag
 		err = json.Unmarshal(b, &pkgTag)
 		if err != nil {
-			errs = append(errs, specerrors.NewStructuredErrorf("error unmarshaling file %s: %v", fsys.Path(filePath), err))
+			errs = append(errs, specerrors.NewStructuredErrorf("error unmarshaling file %s: %v", fsys.Path(filePath), err).WithFile(filePath))
 			continue
 		}
 		// skip non-tag types
@@ -119,12 +120,12 @@ func validateKibanaPackageTagsDuplicates(fsys fspath.FS, sharedTagNames []string
 		// validate if the tag is already defined in other json file
 		if slices.Contains(tags, pkgTag.Attributes.Name) {
 			errs = append(errs, specerrors.NewStructuredError(
-				fmt.Errorf("file \"%s\" is invalid: duplicate package tag name '%s' found", fsys.Path(filePath), pkgTag.Attributes.Name), specerrors.CodeKibanaTagDuplicates))
+				fmt.Errorf("file \"%s\" is invalid: duplicate package tag name '%s' found", fsys.Path(filePath), pkgTag.Attributes.Name), specerrors.CodeKibanaTagDuplicates).WithFile(filePath))
 			continue
 		}
 		if slices.Contains(sharedTagNames, pkgTag.Attributes.Name) {
 			errs = append(errs, specerrors.NewStructuredError(
-				fmt.Errorf("file \"%s\" is invalid: tag name '%s' is already defined in tags.yml", fsys.Path(filePath), pkgTag.Attributes.Name), specerrors.CodeKibanaTagDuplicates))
+				fmt.Errorf("file \"%s\" is invalid: tag name '%s' is already defined in tags.yml", fsys.Path(filePath), pkgTag.Attributes.Name), specerrors.CodeKibanaTagDuplicates).WithFile(filePath))
 			continue
 		}
 		tags = append(tags, pkgTag.Attributes.Name)
@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 
@@ -17,36 +18,36 @@ import (
 )
 
 // ValidateMinimumKibanaVersion ensures the minimum kibana version for a given package is the expected one
-func ValidateMinimumKibanaVersion(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateMinimumKibanaVersion(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
 	pkg, err := packages.NewPackageFromFS(fsys.Path(), fsys)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("manifest.yml")}
 	}
 
 	manifest, err := readManifest(fsys)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("manifest.yml")}
 	}
 
 	kibanaVersionCondition, err := getKibanaVersionCondition(*manifest)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("manifest.yml")}
 	}
 
 	var errs specerrors.ValidationErrors
 	err = validateMinimumKibanaVersionInputPackages(pkg.Type, *pkg.Version, kibanaVersionCondition)
 	if err != nil {
-		errs = append(errs, specerrors.NewStructuredError(err, specerrors.UnassignedCode))
+		errs = append(errs, specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("manifest.yml"))
 	}
 
-	err = validateMinimumKibanaVersionRuntimeFields(fsys, *pkg.Version, kibanaVersionCondition)
+	err = validateMinimumKibanaVersionRuntimeFields(fsys, model, *pkg.Version, kibanaVersionCondition)
 	if err != nil {
-		errs = append(errs, specerrors.NewStructuredError(err, specerrors.UnassignedCode))
+		errs = append(errs, specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("manifest.yml"))
 	}
 
 	err = validateMinimumKibanaVersionSavedObjectTags(fsys, pkg.Type, *pkg.Version, kibanaVersionCondition)
 	if err != nil {
-		errs = append(errs, specerrors.NewStructuredError(err, specerrors.CodeMinimumKibanaVersion))
+		errs = append(errs, specerrors.NewStructuredError(err, specerrors.CodeMinimumKibanaVersion).WithFile("manifest.yml"))
 	}
 
 	if errs != nil {
@@ -77,9 +78,9 @@ func validateMinimumKibanaVersionInputPackages(packageType string, packageVersio
 
 // validateMinimumKibanaVersionRuntimeFields ensures the minimum kibana version if the package defines any runtime field,
 // then the kibana version condition for the package must be >= 8.10.0
-func validateMinimumKibanaVersionRuntimeFields(fsys fspath.FS, packageVersion semver.Version, kibanaVersionCondition string) error {
+func validateMinimumKibanaVersionRuntimeFields(fsys fspath.FS, model *packages.Model, packageVersion semver.Version, kibanaVersionCondition string) error {
 	const minimumKibanaVersion = "8.10.0"
-	errs := validateFields(fsys, validateNoRuntimeFields)
+	errs := validateFields(fsys, model, validateNoRuntimeFields)
 	if len(errs) == 0 {
 		return nil
 	}
@@ -178,9 +179,9 @@ func kibanaVersionConditionIsGreaterThanOrEqualTo(kibanaVersionCondition, minimu
 }
 
 func validateNoRuntimeFields(metadata fieldFileMetadata, f field) specerrors.ValidationErrors {
-	if f.Runtime.isEnabled() {
+	if f.Runtime.Enabled() {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("%v file contains a field %s with runtime key defined (%s)", metadata.fullFilePath, f.Name, f.Runtime),
+			specerrors.NewStructuredErrorf("%v file contains a field %s with runtime key defined (%s)", metadata.fullFilePath, f.Name, f.Runtime).WithFile(metadata.filePath),
 		}
 	}
 	return nil
@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"io/fs"
 	"path"
 	"strings"
@@ -34,10 +35,10 @@ type embeddedEcsDataStreamManifest struct {
 // when import_mappings is enabled. They must not appear in source packages and are
 // rejected in source validation mode. Built packages (where these keys are expected)
 // should be validated with ModeBuild, not ModeSource.
-func ValidateNoEmbeddedEcsInDynamicTemplates(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateNoEmbeddedEcsInDynamicTemplates(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	dataStreams, err := listDataStreams(fsys)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(dataStreamDir)}
 	}
 
 	var errs specerrors.ValidationErrors
@@ -53,14 +54,14 @@ func checkDataStreamManifestForEmbeddedEcs(fsys fspath.FS, manifestPath string)
 	data, err := fs.ReadFile(fsys, manifestPath)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to read manifest: %w", fsys.Path(manifestPath), err),
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to read manifest: %w", fsys.Path(manifestPath), err).WithFile(manifestPath),
 		}
 	}
 
 	var manifest embeddedEcsDataStreamManifest
 	if err := yaml.Unmarshal(data, &manifest); err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err),
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(manifestPath), err).WithFile(manifestPath),
 		}
 	}
 
@@ -71,7 +72,7 @@ func checkDataStreamManifestForEmbeddedEcs(fsys fspath.FS, manifestPath string)
 				errs = append(errs, specerrors.NewStructuredErrorf(
 					"file \"%s\" is invalid: dynamic template %q starts with \"_embedded_ecs\"; this key is auto-injected at build time and must not appear in source packages",
 					fsys.Path(manifestPath), key,
-				))
+				).WithFile(manifestPath))
 			}
 		}
 	}
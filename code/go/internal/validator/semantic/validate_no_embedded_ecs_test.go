@@ -150,7 +150,7 @@ elasticsearch:
 			}
 
 			fsys := fspath.DirFS(tempDir)
-			errs := ValidateNoEmbeddedEcsInDynamicTemplates(fsys)
+			errs := ValidateNoEmbeddedEcsInDynamicTemplates(t.Context(), fsys)
 
 			if !tc.expectErrors {
 				assert.Nil(t, errs, "expected no errors but got: %v", errs)
@@ -184,6 +184,6 @@ func TestListDataStreamsIgnoresNonDirectories(t *testing.T) {
 	require.NoError(t, os.WriteFile(filepath.Join(dataStreamDir, ".DS_Store"), []byte(""), 0644))
 
 	fsys := fspath.DirFS(tempDir)
-	errs := ValidateNoEmbeddedEcsInDynamicTemplates(fsys)
+	errs := ValidateNoEmbeddedEcsInDynamicTemplates(t.Context(), fsys)
 	assert.Nil(t, errs, "stray files under data_stream/ should produce no errors, got: %v", errs)
 }
@@ -5,7 +5,9 @@
 package semantic
 
 import (
+	"context"
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
@@ -13,7 +15,7 @@ import (
 // The build process materializes ECS field references — once built, fields
 // should carry full definitions, not external pointers. A built package must
 // not contain any fields with external: ecs when validated with ModeBuild.
-func ValidateNoExternalFields(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateNoExternalFields(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
 	validateFunc := func(metadata fieldFileMetadata, f field) specerrors.ValidationErrors {
 		if f.External == "" {
 			return nil
@@ -22,8 +24,8 @@ func ValidateNoExternalFields(fsys fspath.FS) specerrors.ValidationErrors {
 			specerrors.NewStructuredErrorf(
 				"file \"%s\" is invalid: field %s has external: %s reference; external fields must be materialized before packaging",
 				metadata.fullFilePath, f.Name, f.External,
-			),
+			).WithFile(metadata.filePath),
 		}
 	}
-	return validateFields(fsys, validateFunc)
+	return validateFields(fsys, model, validateFunc)
 }
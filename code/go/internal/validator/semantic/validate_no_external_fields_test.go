@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 )
 
 func TestValidateNoExternalFields(t *testing.T) {
@@ -74,7 +75,7 @@ func TestValidateNoExternalFields(t *testing.T) {
 			}
 
 			fsys := fspath.DirFS(tempDir)
-			errs := ValidateNoExternalFields(fsys)
+			errs := ValidateNoExternalFields(t.Context(), fsys, packages.NewModel(fsys))
 
 			if !tc.expectErrors {
 				assert.Nil(t, errs, "expected no errors but got: %v", errs)
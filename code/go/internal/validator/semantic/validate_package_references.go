@@ -5,36 +5,33 @@
 package semantic
 
 import (
+	"context"
 	"slices"
 
 	"github.com/Masterminds/semver/v3"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
-	"github.com/elastic/package-spec/v3/code/go/internal/pkgpath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 // ValidatePackageReferences checks that package references in policy templates and data streams
 // are listed in the manifest's requires section and are of the correct type (input packages only).
-func ValidatePackageReferences(fsys fspath.FS) specerrors.ValidationErrors {
-	manifest, err := readManifest(fsys)
+func ValidatePackageReferences(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
+	manifest, err := model.Manifest()
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path("manifest.yml"), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path("manifest.yml"), err).WithFile("manifest.yml")}
 	}
 
 	// Build lists of required packages by type
-	requiredPackages, err := getRequiredPackagesByType(*manifest)
-	if err != nil {
-		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path("manifest.yml"), err)}
-	}
+	requiredPackages := getRequiredPackagesByType(manifest)
 
 	// Validate policy template input package references
-	errs := validatePolicyTemplatePackageReferences(fsys, *manifest, requiredPackages)
+	errs := validatePolicyTemplatePackageReferences(fsys, manifest, requiredPackages)
 
 	// Validate data stream stream package references
-	errs = append(errs, validateDataStreamPackageReferences(fsys, requiredPackages)...)
+	errs = append(errs, validateDataStreamPackageReferences(model, requiredPackages)...)
 
 	// Validate restrictions on version formats
 	errs = append(errs, validateFixedVersions(fsys, requiredPackages)...)
@@ -54,81 +51,30 @@ type requiredPackage struct {
 	version string
 }
 
-func getRequiredPackagesByType(manifest pkgpath.File) (requiredPackages, error) {
-	packages := requiredPackages{}
-
-	// Get input packages from requires.input
-	inputPackages, err := manifest.Values("$.requires.input")
-	if err == nil && inputPackages != nil {
-		packages.input = extractPackageNamesFromRequires(inputPackages)
+func getRequiredPackagesByType(manifest *packages.Manifest) requiredPackages {
+	return requiredPackages{
+		input:   extractPackageNamesFromRequires(manifest.Requires.Input),
+		content: extractPackageNamesFromRequires(manifest.Requires.Content),
 	}
-
-	// Get content packages from requires.content
-	contentPackages, err := manifest.Values("$.requires.content")
-	if err == nil && contentPackages != nil {
-		packages.content = extractPackageNamesFromRequires(contentPackages)
-	}
-
-	return packages, nil
 }
 
-func extractPackageNamesFromRequires(packages interface{}) []requiredPackage {
-	pkgArray, ok := packages.([]interface{})
-	if !ok {
-		return nil
-	}
-
+func extractPackageNamesFromRequires(requirements []packages.PackageRequirement) []requiredPackage {
 	var result []requiredPackage
-	for _, pkg := range pkgArray {
-		pkgMap, ok := pkg.(map[string]interface{})
-		if !ok {
+	for _, requirement := range requirements {
+		if requirement.Package == "" {
 			continue
 		}
-
-		name, ok := pkgMap["package"].(string)
-		if !ok {
-			continue
-		}
-
-		version, _ := pkgMap["version"].(string)
-
-		result = append(result, requiredPackage{name: name, version: version})
+		result = append(result, requiredPackage{name: requirement.Package, version: requirement.Version})
 	}
 	return result
 }
 
-func validatePolicyTemplatePackageReferences(fsys fspath.FS, manifest pkgpath.File, requiredPackages requiredPackages) specerrors.ValidationErrors {
+func validatePolicyTemplatePackageReferences(fsys fspath.FS, manifest *packages.Manifest, requiredPackages requiredPackages) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
-
-	policyTemplates, err := manifest.Values("$.policy_templates")
-	if err != nil || policyTemplates == nil {
-		return nil
-	}
-
-	policyTemplateArray, ok := policyTemplates.([]interface{})
-	if !ok {
-		return nil
-	}
-
-	for templateIndex, template := range policyTemplateArray {
-		templateMap, ok := template.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		inputs, ok := templateMap["inputs"].([]interface{})
-		if !ok {
-			continue
-		}
-
-		for inputIndex, input := range inputs {
-			inputMap, ok := input.(map[string]interface{})
-			if !ok {
-				continue
-			}
-
-			packageName, ok := inputMap["package"].(string)
-			if !ok || packageName == "" {
+	for templateIndex, template := range manifest.PolicyTemplates {
+		for inputIndex, input := range template.Inputs {
+			packageName := input.Package
+			if packageName == "" {
 				continue
 			}
 
@@ -140,7 +86,7 @@ func validatePolicyTemplatePackageReferences(fsys fspath.FS, manifest pkgpath.Fi
 			if slices.ContainsFunc(requiredPackages.content, equalName) {
 				errs = append(errs, specerrors.NewStructuredErrorf(
 					"file \"%s\" is invalid: policy_templates[%d].inputs[%d] references package \"%s\" which is a content package, only input packages allowed",
-					fsys.Path("manifest.yml"), templateIndex, inputIndex, packageName))
+					fsys.Path("manifest.yml"), templateIndex, inputIndex, packageName).WithFile("manifest.yml"))
 				continue
 			}
 
@@ -148,7 +94,7 @@ func validatePolicyTemplatePackageReferences(fsys fspath.FS, manifest pkgpath.Fi
 			if !slices.ContainsFunc(requiredPackages.input, equalName) {
 				errs = append(errs, specerrors.NewStructuredErrorf(
 					"file \"%s\" is invalid: policy_templates[%d].inputs[%d] references package \"%s\" which is not listed in requires section",
-					fsys.Path("manifest.yml"), templateIndex, inputIndex, packageName))
+					fsys.Path("manifest.yml"), templateIndex, inputIndex, packageName).WithFile("manifest.yml"))
 			}
 		}
 	}
@@ -156,33 +102,25 @@ func validatePolicyTemplatePackageReferences(fsys fspath.FS, manifest pkgpath.Fi
 	return errs
 }
 
-func validateDataStreamPackageReferences(fsys fspath.FS, requiredPackages requiredPackages) specerrors.ValidationErrors {
-	dataStreamManifests, err := pkgpath.Files(fsys, "data_stream/*/manifest.yml")
+func validateDataStreamPackageReferences(model *packages.Model, requiredPackages requiredPackages) specerrors.ValidationErrors {
+	dataStreams, err := model.DataStreams()
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("error while searching for data stream manifests: %w", err)}
+			specerrors.NewStructuredErrorf("error while searching for data stream manifests: %w", err).WithFile(dataStreamDir)}
 	}
 
 	var errs specerrors.ValidationErrors
-	for _, dataStreamManifest := range dataStreamManifests {
-		streams, err := dataStreamManifest.Values("$.streams")
-		if err != nil || streams == nil {
-			continue
-		}
-
-		streamArray, ok := streams.([]interface{})
-		if !ok {
+	for _, dataStream := range dataStreams {
+		manifest, err := dataStream.Manifest()
+		if err != nil {
+			// Errors reading data stream manifests are reported by other validations.
 			continue
 		}
 
-		for streamIndex, stream := range streamArray {
-			streamMap, ok := stream.(map[string]interface{})
-			if !ok {
-				continue
-			}
-
-			packageName, ok := streamMap["package"].(string)
-			if !ok || packageName == "" {
+		manifestPath := dataStream.ManifestPath()
+		for streamIndex, stream := range manifest.Streams {
+			packageName := stream.Package
+			if packageName == "" {
 				continue
 			}
 
@@ -194,7 +132,7 @@ func validateDataStreamPackageReferences(fsys fspath.FS, requiredPackages requir
 			if slices.ContainsFunc(requiredPackages.content, equalName) {
 				errs = append(errs, specerrors.NewStructuredErrorf(
 					"file \"%s\" is invalid: streams[%d] references package \"%s\" which is a content package, only input packages allowed",
-					dataStreamManifest.Path(), streamIndex, packageName))
+					manifestPath, streamIndex, packageName).WithFile(manifestPath))
 				continue
 			}
 
@@ -202,7 +140,7 @@ func validateDataStreamPackageReferences(fsys fspath.FS, requiredPackages requir
 			if !slices.ContainsFunc(requiredPackages.input, equalName) {
 				errs = append(errs, specerrors.NewStructuredErrorf(
 					"file \"%s\" is invalid: streams[%d] references package \"%s\" which is not listed in manifest requires section",
-					dataStreamManifest.Path(), streamIndex, packageName))
+					manifestPath, streamIndex, packageName).WithFile(manifestPath))
 			}
 		}
 	}
@@ -224,7 +162,7 @@ func validateFixedVersions(fsys fspath.FS, requiredPackages requiredPackages) sp
 		if err != nil {
 			errs = append(errs, specerrors.NewStructuredErrorf(
 				"file \"%s\" is invalid: field requires.input.%d.version: version \"%s\" for package \"%s\" must be a valid semantic version, constraints are not allowed",
-				fsys.Path("manifest.yml"), i, p.version, p.name))
+				fsys.Path("manifest.yml"), i, p.version, p.name).WithFile("manifest.yml"))
 		}
 	}
 
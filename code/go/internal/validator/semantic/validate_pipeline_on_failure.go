@@ -5,13 +5,14 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
-	"io/fs"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
@@ -22,27 +23,44 @@ var requiredMessageValues = []string{
 	"_ingest.pipeline",
 }
 
-// ValidatePipelineOnFailure validates ingest pipeline global on_failure handlers.
-func ValidatePipelineOnFailure(fsys fspath.FS) specerrors.ValidationErrors {
+// Handlers added to the on_failure section of pipelines by fixes, indented as
+// items of a sequence starting at the first column.
+const (
+	setEventKindHandler = `- set:
+    field: event.kind
+    value: pipeline_error
+`
+	appendErrorMessageHandler = `- append:
+    field: error.message
+    value: >-
+      Processor '{{{ _ingest.on_failure_processor_type }}}'
+      with tag '{{{ _ingest.on_failure_processor_tag }}}'
+      in pipeline '{{{ _ingest.pipeline }}}'
+      failed with message '{{{ _ingest.on_failure_message }}}'
+`
+)
 
+// ValidatePipelineOnFailure validates ingest pipeline global on_failure handlers.
+func ValidatePipelineOnFailure(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
-	pipelineFiles, err := listPipelineFiles(fsys)
+	pipelines, err := model.IngestPipelines()
 	if err != nil {
 		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
 	}
 
-	for _, pipelineFile := range pipelineFiles {
-		content, err := fs.ReadFile(fsys, pipelineFile.filePath)
+	for _, pipeline := range pipelines {
+		pipelineFile := newPipelineFileMetadata(fsys, pipeline)
+		content, err := pipeline.Content()
 		if err != nil {
-			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(pipelineFile.filePath)}
 		}
 
-		var pipeline ingestPipeline
-		if err = yaml.Unmarshal(content, &pipeline); err != nil {
-			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		definition, err := pipeline.Definition()
+		if err != nil {
+			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(pipelineFile.filePath)}
 		}
 
-		if vErrs := validatePipelineOnFailure(&pipeline, pipelineFile.fullFilePath); len(vErrs) > 0 {
+		if vErrs := validatePipelineOnFailure(definition, content, pipelineFile); len(vErrs) > 0 {
 			errs = append(errs, vErrs...)
 		}
 	}
@@ -50,20 +68,20 @@ func ValidatePipelineOnFailure(fsys fspath.FS) specerrors.ValidationErrors {
 	return errs
 }
 
-func validatePipelineOnFailure(pipeline *ingestPipeline, filename string) specerrors.ValidationErrors {
+func validatePipelineOnFailure(pipeline *ingestPipeline, content []byte, pipelineFile pipelineFileMetadata) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 
-	if e := checkSetEventKind(pipeline, filename); len(e) > 0 {
+	if e := checkSetEventKind(pipeline, content, pipelineFile); len(e) > 0 {
 		errs = append(errs, e...)
 	}
-	if e := checkSetErrorMessage(pipeline, filename); len(e) > 0 {
+	if e := checkSetErrorMessage(pipeline, content, pipelineFile); len(e) > 0 {
 		errs = append(errs, e...)
 	}
 
 	return errs
 }
 
-func checkSetEventKind(pipeline *ingestPipeline, filename string) specerrors.ValidationErrors {
+func checkSetEventKind(pipeline *ingestPipeline, content []byte, pipelineFile pipelineFileMetadata) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 	var found bool
 
@@ -71,16 +89,18 @@ func checkSetEventKind(pipeline *ingestPipeline, filename string) specerrors.Val
 		if proc.Type != "set" {
 			continue
 		}
-		if s, ok := proc.GetAttributeString("field"); !ok || s != "event.kind" {
+		if s, ok := proc.StringAttribute("field"); !ok || s != "event.kind" {
 			continue
 		}
 
 		found = true
 
-		if s, ok := proc.GetAttributeString("value"); !ok || s != "pipeline_error" {
+		if s, ok := proc.StringAttribute("value"); !ok || s != "pipeline_error" {
 			errs = append(errs, specerrors.NewStructuredError(
-				fmt.Errorf("file %q is invalid: pipeline on_failure handler must set event.kind to \"pipeline_error\"", filename),
-				specerrors.CodePipelineOnFailureEventKind),
+				fmt.Errorf("file %q is invalid: pipeline on_failure handler must set event.kind to \"pipeline_error\"", pipelineFile.fullFilePath),
+				specerrors.CodePipelineOnFailureEventKind).
+				WithFile(pipelineFile.filePath).
+				WithPosition(proc.Position.Line, proc.Position.Column),
 			)
 		}
 
@@ -88,16 +108,19 @@ func checkSetEventKind(pipeline *ingestPipeline, filename string) specerrors.Val
 	}
 
 	if !found {
-		errs = append(errs, specerrors.NewStructuredError(
-			fmt.Errorf("file %q is invalid: pipeline on_failure handler must set event.kind to \"pipeline_error\"", filename),
-			specerrors.CodePipelineOnFailureEventKind),
-		)
+		err := specerrors.NewStructuredError(
+			fmt.Errorf("file %q is invalid: pipeline on_failure handler must set event.kind to \"pipeline_error\"", pipelineFile.fullFilePath),
+			specerrors.CodePipelineOnFailureEventKind).WithFile(pipelineFile.filePath)
+		if fix, ok := onFailureHandlerFix(content, pipelineFile, "set event.kind to \"pipeline_error\"", setEventKindHandler); ok {
+			err = err.WithFix(fix)
+		}
+		errs = append(errs, err)
 	}
 
 	return errs
 }
 
-func checkSetErrorMessage(pipeline *ingestPipeline, filename string) specerrors.ValidationErrors {
+func checkSetErrorMessage(pipeline *ingestPipeline, content []byte, pipelineFile pipelineFileMetadata) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 	var found bool
 
@@ -105,18 +128,20 @@ func checkSetErrorMessage(pipeline *ingestPipeline, filename string) specerrors.
 		if proc.Type != "set" && proc.Type != "append" {
 			continue
 		}
-		if s, ok := proc.GetAttributeString("field"); !ok || s != "error.message" {
+		if s, ok := proc.StringAttribute("field"); !ok || s != "error.message" {
 			continue
 		}
 
 		found = true
 
-		value, _ := proc.GetAttributeString("value")
+		value, _ := proc.StringAttribute("value")
 		for _, reqMessageValue := range requiredMessageValues {
 			if !strings.Contains(value, reqMessageValue) {
 				errs = append(errs, specerrors.NewStructuredError(
-					fmt.Errorf("file %q is invalid: pipeline on_failure error.message must include %q", filename, reqMessageValue),
-					specerrors.CodePipelineOnFailureMessage),
+					fmt.Errorf("file %q is invalid: pipeline on_failure error.message must include %q", pipelineFile.fullFilePath, reqMessageValue),
+					specerrors.CodePipelineOnFailureMessage).
+					WithFile(pipelineFile.filePath).
+					WithPosition(proc.Position.Line, proc.Position.Column),
 				)
 			}
 		}
@@ -125,11 +150,62 @@ func checkSetErrorMessage(pipeline *ingestPipeline, filename string) specerrors.
 	}
 
 	if !found {
-		errs = append(errs, specerrors.NewStructuredError(
-			fmt.Errorf("file %q is invalid: pipeline on_failure handler must set error.message", filename),
-			specerrors.CodePipelineOnFailureMessage),
-		)
+		err := specerrors.NewStructuredError(
+			fmt.Errorf("file %q is invalid: pipeline on_failure handler must set error.message", pipelineFile.fullFilePath),
+			specerrors.CodePipelineOnFailureMessage).WithFile(pipelineFile.filePath)
+		if fix, ok := onFailureHandlerFix(content, pipelineFile, "set error.message", appendErrorMessageHandler); ok {
+			err = err.WithFix(fix)
+		}
+		errs = append(errs, err)
 	}
 
 	return errs
 }
+
+// onFailureHandlerFix returns a fix that adds the handler to the on_failure
+// section of the pipeline, creating the section if needed. Only YAML pipelines
+// with on_failure sections in block sequences can be fixed.
+func onFailureHandlerFix(content []byte, pipelineFile pipelineFileMetadata, description, handler string) (specerrors.Fix, bool) {
+	if !isYAMLPipeline(pipelineFile.filePath) {
+		return specerrors.Fix{}, false
+	}
+
+	var pipeline struct {
+		OnFailure yaml.Node `yaml:"on_failure"`
+	}
+	if err := yaml.Unmarshal(content, &pipeline); err != nil {
+		return specerrors.Fix{}, false
+	}
+
+	var edit specerrors.Edit
+	switch onFailure := pipeline.OnFailure; {
+	case onFailure.Kind == 0:
+		text := "on_failure:\n" + indentLines(handler, "  ")
+		if len(content) > 0 && content[len(content)-1] != '\n' {
+			text = "\n" + text
+		}
+		edit = specerrors.Edit{Start: len(content), End: len(content), Text: text}
+	case onFailure.Kind == yaml.SequenceNode && onFailure.Style&yaml.FlowStyle == 0 && len(onFailure.Content) > 0:
+		indent := strings.Repeat(" ", onFailure.Column-1)
+		edit = specerrors.InsertLine(content, onFailure.Line, indentLines(handler, indent))
+	default:
+		return specerrors.Fix{}, false
+	}
+
+	return specerrors.Fix{
+		Description: fmt.Sprintf("Add an on_failure handler to %s", description),
+		File:        pipelineFile.filePath,
+		Edits:       []specerrors.Edit{edit},
+	}, true
+}
+
+// indentLines adds the indentation to each line of text.
+func indentLines(text, indent string) string {
+	lines := strings.SplitAfter(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "")
+}
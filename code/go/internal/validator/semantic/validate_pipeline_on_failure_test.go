@@ -11,6 +11,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 func TestValidatePipelineOnFailure(t *testing.T) {
@@ -124,7 +126,7 @@ on_failure:
 			err := yaml.Unmarshal([]byte(tc.pipeline), &pipeline)
 			require.NoError(t, err)
 
-			errors := validatePipelineOnFailure(&pipeline, "default.yml")
+			errors := validatePipelineOnFailure(&pipeline, []byte(tc.pipeline), pipelineFileMetadata{filePath: "default.yml", fullFilePath: "default.yml"})
 			assert.Len(t, errors, len(tc.errors))
 			for _, err := range errors {
 				assert.Contains(t, tc.errors, err.Error())
@@ -132,3 +134,59 @@ on_failure:
 		})
 	}
 }
+
+func TestPipelineOnFailureHandlerFix(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filePath string
+		pipeline string
+		edit     *specerrors.Edit
+	}{
+		{
+			name:     "no-on-failure",
+			filePath: "default.yml",
+			pipeline: "processors: []",
+			edit: &specerrors.Edit{Start: 14, End: 14, Text: "\non_failure:\n" +
+				"  - set:\n" +
+				"      field: event.kind\n" +
+				"      value: pipeline_error\n"},
+		},
+		{
+			name:     "existing-on-failure",
+			filePath: "default.yml",
+			pipeline: "on_failure:\n- remove:\n    field: foo\n",
+			edit: &specerrors.Edit{Start: 12, End: 12, Text: "- set:\n" +
+				"    field: event.kind\n" +
+				"    value: pipeline_error\n"},
+		},
+		{
+			name:     "flow-on-failure",
+			filePath: "default.yml",
+			pipeline: "on_failure: []\n",
+		},
+		{
+			name:     "json-pipeline",
+			filePath: "default.json",
+			pipeline: `{"processors": []}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var pipeline ingestPipeline
+			require.NoError(t, yaml.Unmarshal([]byte(tc.pipeline), &pipeline))
+
+			errs := checkSetEventKind(&pipeline, []byte(tc.pipeline), pipelineFileMetadata{filePath: tc.filePath, fullFilePath: tc.filePath})
+			require.Len(t, errs, 1)
+
+			fix := errs[0].(specerrors.ValidationFixError).Fix()
+			if tc.edit == nil {
+				assert.Nil(t, fix)
+				return
+			}
+			require.NotNil(t, fix)
+			assert.Equal(t, tc.filePath, fix.File)
+			assert.Equal(t, []specerrors.Edit{*tc.edit}, fix.Edits)
+		})
+	}
+}
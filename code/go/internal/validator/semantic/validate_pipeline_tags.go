@@ -5,35 +5,37 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
-	"io/fs"
-
-	"gopkg.in/yaml.v3"
+	"path"
+	"strings"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 // ValidatePipelineTags validates ingest pipeline processor tags.
-func ValidatePipelineTags(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidatePipelineTags(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
 	var errors specerrors.ValidationErrors
-	pipelineFiles, err := listPipelineFiles(fsys)
+	pipelines, err := model.IngestPipelines()
 	if err != nil {
 		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
 	}
 
-	for _, pipelineFile := range pipelineFiles {
-		content, err := fs.ReadFile(fsys, pipelineFile.filePath)
+	for _, pipeline := range pipelines {
+		pipelineFile := newPipelineFileMetadata(fsys, pipeline)
+		content, err := pipeline.Content()
 		if err != nil {
-			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(pipelineFile.filePath)}
 		}
 
-		var pipeline ingestPipeline
-		if err = yaml.Unmarshal(content, &pipeline); err != nil {
-			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		definition, err := pipeline.Definition()
+		if err != nil {
+			return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(pipelineFile.filePath)}
 		}
 
-		if vErrs := validatePipelineTags(&pipeline, pipelineFile.fullFilePath); len(vErrs) > 0 {
+		if vErrs := validatePipelineTags(definition, content, pipelineFile); len(vErrs) > 0 {
 			errors = append(errors, vErrs...)
 		}
 	}
@@ -41,44 +43,58 @@ func ValidatePipelineTags(fsys fspath.FS) specerrors.ValidationErrors {
 	return errors
 }
 
-func validatePipelineTags(pipeline *ingestPipeline, filename string) specerrors.ValidationErrors {
+func validatePipelineTags(pipeline *ingestPipeline, content []byte, pipelineFile pipelineFileMetadata) specerrors.ValidationErrors {
 	var errors specerrors.ValidationErrors
 
 	seen := map[string]struct{}{}
+	reserved := map[string]struct{}{}
+	collectPipelineTags(pipeline.Processors, reserved)
 	for _, proc := range pipeline.Processors {
-		procErrors := checkPipelineTag(&proc, seen, filename)
+		procErrors := checkPipelineTag(&proc, seen, reserved, content, pipelineFile)
 		errors = append(errors, procErrors...)
 	}
 
 	return errors
 }
 
-func checkPipelineTag(proc *processor, seen map[string]struct{}, filename string) specerrors.ValidationErrors {
+func checkPipelineTag(proc *processor, seen, reserved map[string]struct{}, content []byte, pipelineFile pipelineFileMetadata) specerrors.ValidationErrors {
 	var errors specerrors.ValidationErrors
 
 	for _, subProc := range proc.OnFailure {
-		subErrors := checkPipelineTag(&subProc, seen, filename)
+		subErrors := checkPipelineTag(&subProc, seen, reserved, content, pipelineFile)
 		errors = append(errors, subErrors...)
 	}
 
 	raw, ok := proc.Attributes["tag"]
 	if !ok {
-		errors = append(errors, specerrors.NewStructuredError(fmt.Errorf("file %q is invalid: %s processor at line %d missing required tag", filename, proc.Type, proc.position.line), specerrors.CodePipelineTagRequired))
+		err := specerrors.NewStructuredError(fmt.Errorf("file %q is invalid: %s processor at line %d missing required tag", pipelineFile.fullFilePath, proc.Type, proc.Position.Line), specerrors.CodePipelineTagRequired).
+			WithFile(pipelineFile.filePath).
+			WithPosition(proc.Position.Line, proc.Position.Column)
+		if fix, ok := pipelineTagFix(proc, reserved, content, pipelineFile); ok {
+			err = err.WithFix(fix)
+		}
+		errors = append(errors, err)
 		return errors
 	}
 
 	tag, ok := raw.(string)
 	if !ok {
-		errors = append(errors, specerrors.NewStructuredError(fmt.Errorf("file %q is invalid: %s processor at line %d has invalid tag value", filename, proc.Type, proc.position.line), specerrors.CodePipelineTagRequired))
+		errors = append(errors, specerrors.NewStructuredError(fmt.Errorf("file %q is invalid: %s processor at line %d has invalid tag value", pipelineFile.fullFilePath, proc.Type, proc.Position.Line), specerrors.CodePipelineTagRequired).
+			WithFile(pipelineFile.filePath).
+			WithPosition(proc.Position.Line, proc.Position.Column))
 		return errors
 	}
 	if tag == "" {
-		errors = append(errors, specerrors.NewStructuredError(fmt.Errorf("file %q is invalid: %s processor at line %d has empty tag value", filename, proc.Type, proc.position.line), specerrors.CodePipelineTagRequired))
+		errors = append(errors, specerrors.NewStructuredError(fmt.Errorf("file %q is invalid: %s processor at line %d has empty tag value", pipelineFile.fullFilePath, proc.Type, proc.Position.Line), specerrors.CodePipelineTagRequired).
+			WithFile(pipelineFile.filePath).
+			WithPosition(proc.Position.Line, proc.Position.Column))
 		return errors
 	}
 
 	if _, dup := seen[tag]; dup {
-		errors = append(errors, specerrors.NewStructuredErrorf("file %q is invalid: %s processor at line %d has duplicate tag value: %q", filename, proc.Type, proc.position.line, tag))
+		errors = append(errors, specerrors.NewStructuredErrorf("file %q is invalid: %s processor at line %d has duplicate tag value: %q", pipelineFile.fullFilePath, proc.Type, proc.Position.Line, tag).
+			WithFile(pipelineFile.filePath).
+			WithPosition(proc.Position.Line, proc.Position.Column))
 		return errors
 	}
 
@@ -86,3 +102,49 @@ func checkPipelineTag(proc *processor, seen map[string]struct{}, filename string
 
 	return errors
 }
+
+// collectPipelineTags adds the tags of the processors, and of their on_failure
+// handlers, to the given set.
+func collectPipelineTags(processors []processor, tags map[string]struct{}) {
+	for _, proc := range processors {
+		if tag, ok := proc.StringAttribute("tag"); ok {
+			tags[tag] = struct{}{}
+		}
+		collectPipelineTags(proc.OnFailure, tags)
+	}
+}
+
+// pipelineTagFix returns a fix that adds a tag to the processor, not used by
+// any other processor. Only processors defined in YAML files with their
+// attributes in block mappings can be fixed.
+func pipelineTagFix(proc *processor, reserved map[string]struct{}, content []byte, pipelineFile pipelineFileMetadata) (specerrors.Fix, bool) {
+	if !isYAMLPipeline(pipelineFile.filePath) || proc.AttributesPosition.Line == 0 {
+		return specerrors.Fix{}, false
+	}
+
+	var tag string
+	for i := 1; ; i++ {
+		tag = fmt.Sprintf("%s_%d", proc.Type, i)
+		if _, found := reserved[tag]; !found {
+			break
+		}
+	}
+	reserved[tag] = struct{}{}
+
+	indent := strings.Repeat(" ", proc.AttributesPosition.Column-1)
+	return specerrors.Fix{
+		Description: fmt.Sprintf("Add tag %q to the %s processor", tag, proc.Type),
+		File:        pipelineFile.filePath,
+		Edits: []specerrors.Edit{
+			specerrors.InsertLine(content, proc.AttributesPosition.Line, fmt.Sprintf("%stag: %s\n", indent, tag)),
+		},
+	}, true
+}
+
+func isYAMLPipeline(filePath string) bool {
+	switch path.Ext(filePath) {
+	case ".yml", ".yaml":
+		return true
+	}
+	return false
+}
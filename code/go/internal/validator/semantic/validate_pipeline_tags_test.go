@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
+
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 func TestValidatePipelineTags(t *testing.T) {
@@ -140,7 +142,7 @@ processors:
 			err := yaml.Unmarshal([]byte(tc.pipeline), &pipeline)
 			require.NoError(t, err)
 
-			errors := validatePipelineTags(&pipeline, "default.yml")
+			errors := validatePipelineTags(&pipeline, []byte(tc.pipeline), pipelineFileMetadata{filePath: "default.yml", fullFilePath: "default.yml"})
 			assert.Len(t, errors, len(tc.errors))
 			for _, err := range errors {
 				assert.Contains(t, tc.errors, err.Error())
@@ -148,3 +150,43 @@ processors:
 		})
 	}
 }
+
+func TestValidatePipelineTagsFix(t *testing.T) {
+	pipeline := `processors:
+  - set:
+      field: key1
+      value: value1
+      on_failure:
+        - remove:
+            field: key1
+  - set:
+      tag: set_1
+      field: key2
+      value: value2
+  - remove: {field: key2}
+`
+	var p ingestPipeline
+	require.NoError(t, yaml.Unmarshal([]byte(pipeline), &p))
+
+	errs := validatePipelineTags(&p, []byte(pipeline), pipelineFileMetadata{filePath: "default.yml", fullFilePath: "default.yml"})
+	require.Len(t, errs, 3)
+
+	var fixes []*specerrors.Fix
+	for _, err := range errs {
+		fixes = append(fixes, err.(specerrors.ValidationFixError).Fix())
+	}
+	assert.Equal(t, []*specerrors.Fix{
+		{
+			Description: `Add tag "remove_1" to the remove processor`,
+			File:        "default.yml",
+			Edits:       []specerrors.Edit{{Start: 95, End: 95, Text: "            tag: remove_1\n"}},
+		},
+		{
+			Description: `Add tag "set_2" to the set processor`,
+			File:        "default.yml",
+			Edits:       []specerrors.Edit{{Start: 21, End: 21, Text: "      tag: set_2\n"}},
+		},
+		// Processors with attributes in flow mappings are not fixed.
+		nil,
+	}, fixes)
+}
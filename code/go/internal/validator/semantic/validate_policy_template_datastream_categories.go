@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
 	"path"
 	"slices"
@@ -59,14 +60,14 @@ func readPackageManifestPolicyTemplates(fsys fspath.FS) (string, []policyTemplat
 // manifest.yml categories include all of the policy template's categories. Data streams
 // may declare additional categories beyond what the policy template specifies.
 // Data stream manifests without a categories field are skipped.
-func ValidatePolicyTemplateDatastreamCategories(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidatePolicyTemplateDatastreamCategories(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 
 	manifestPath := "manifest.yml"
 	pkgType, policyTemplates, err := readPackageManifestPolicyTemplates(fsys)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 
 	// only validate integration type packages
@@ -77,7 +78,7 @@ func ValidatePolicyTemplateDatastreamCategories(fsys fspath.FS) specerrors.Valid
 	dsCategories, err := readDataStreamManifestCategories(fsys)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path(manifestPath), err).WithFile(manifestPath)}
 	}
 
 	for _, pt := range policyTemplates {
@@ -104,7 +105,7 @@ func ValidatePolicyTemplateDatastreamCategories(fsys fspath.FS) specerrors.Valid
 					pt.Name,
 					missing,
 					fsys.Path(dsManifestPath),
-				))
+				).WithFile(manifestPath))
 			}
 		}
 	}
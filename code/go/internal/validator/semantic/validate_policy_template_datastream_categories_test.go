@@ -217,7 +217,7 @@ categories:
 			dir := t.TempDir()
 			tc.setup(t, dir)
 
-			errs := ValidatePolicyTemplateDatastreamCategories(fspath.DirFS(dir))
+			errs := ValidatePolicyTemplateDatastreamCategories(t.Context(), fspath.DirFS(dir))
 
 			if len(tc.expectedErrs) == 0 {
 				assert.Empty(t, errs)
@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 	"path"
@@ -18,15 +19,15 @@ import (
 
 // ValidateProfilesNonGA validates that the profiles data type is not used in GA packages,
 // as this data type is in technical preview and can be eventually removed.
-func ValidateProfilesNonGA(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateProfilesNonGA(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	manifestVersion, err := readManifestVersion(fsys)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("manifest.yml")}
 	}
 
 	semVer, err := semver.NewVersion(manifestVersion)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("manifest.yml")}
 	}
 
 	if semVer.Major() == 0 || semVer.Prerelease() != "" {
@@ -35,14 +36,14 @@ func ValidateProfilesNonGA(fsys fspath.FS) specerrors.ValidationErrors {
 
 	dataStreams, err := listDataStreams(fsys)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(dataStreamDir)}
 	}
 
 	var errs specerrors.ValidationErrors
 	for _, dataStream := range dataStreams {
 		err := validateProfilesTypeNotUsed(fsys, dataStream)
 		if err != nil {
-			errs = append(errs, specerrors.NewStructuredError(err, specerrors.UnassignedCode))
+			errs = append(errs, specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(path.Join(dataStreamDir, dataStream, "manifest.yml")))
 		}
 	}
 	return errs
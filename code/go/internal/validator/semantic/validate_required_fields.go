@@ -5,15 +5,18 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
+	"path"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 // ValidateRequiredFields validates that required fields are present and have the expected
 // types except for fields defined in transforms.
-func ValidateRequiredFields(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateRequiredFields(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
 	requiredFields := map[string]string{
 		"data_stream.type":      "constant_keyword",
 		"data_stream.dataset":   "constant_keyword",
@@ -21,7 +24,7 @@ func ValidateRequiredFields(fsys fspath.FS) specerrors.ValidationErrors {
 		"@timestamp":            "date",
 	}
 
-	return validateRequiredFields(fsys, requiredFields)
+	return validateRequiredFields(fsys, model, requiredFields)
 }
 
 type unexpectedTypeRequiredField struct {
@@ -53,7 +56,7 @@ func (e notFoundRequiredField) Error() string {
 	return message
 }
 
-func validateRequiredFields(fsys fspath.FS, requiredFields map[string]string) specerrors.ValidationErrors {
+func validateRequiredFields(fsys fspath.FS, model *packages.Model, requiredFields map[string]string) specerrors.ValidationErrors {
 	// map datastream/input package -> field name -> found
 	// if data stream is an empty string, it means it is an input package
 	foundFields := make(map[string]map[string]struct{})
@@ -90,13 +93,13 @@ func validateRequiredFields(fsys fspath.FS, requiredFields map[string]string) sp
 						expectedType: expectedType,
 					},
 					specerrors.UnassignedCode,
-				),
+				).WithFile(metadata.filePath),
 			}
 		}
 
 		return nil
 	}
-	errs := validateFields(fsys, checkField)
+	errs := validateFields(fsys, model, checkField)
 
 	// Validate that required fields exist in integration and input packages.
 	// Using the data streams found here, since all data streams must have a `fields` folder
@@ -104,6 +107,10 @@ func validateRequiredFields(fsys fspath.FS, requiredFields map[string]string) sp
 	// Fields folder is not mandatory for input packages, so we need to consider the case
 	// https://github.com/elastic/package-spec/pull/994
 	for dataStream, dataStreamFields := range foundFields {
+		fieldsDir := "fields"
+		if dataStream != "" {
+			fieldsDir = path.Join(dataStreamDir, dataStream, fieldsDir)
+		}
 		for requiredName, requiredType := range requiredFields {
 			if _, found := dataStreamFields[requiredName]; !found {
 				errs = append(errs,
@@ -114,7 +121,7 @@ func validateRequiredFields(fsys fspath.FS, requiredFields map[string]string) sp
 							dataStream:   dataStream,
 						},
 						specerrors.UnassignedCode,
-					),
+					).WithFile(fieldsDir),
 				)
 			}
 		}
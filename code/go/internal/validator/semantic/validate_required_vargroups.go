@@ -5,62 +5,46 @@
 package semantic
 
 import (
-	"io/fs"
-	"path"
+	"context"
 	"slices"
 
-	"gopkg.in/yaml.v3"
-
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 // ValidateRequiredVarGroups validates lists of optional required variables.
-func ValidateRequiredVarGroups(fsys fspath.FS) specerrors.ValidationErrors {
-	// Validate main manifest.
-	d, err := fs.ReadFile(fsys, "manifest.yml")
-	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to read manifest: %w", fsys.Path("manifest.yml"), err)}
-	}
+func ValidateRequiredVarGroups(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
 
-	var manifest requiredVarsManifest
-	err = yaml.Unmarshal(d, &manifest)
+	// Validate main manifest.
+	manifest, err := model.Manifest()
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path("manifest.yml"), err)}
+		return specerrors.ValidationErrors{requiredVarGroupsManifestError(fsys, "manifest.yml", err)}
 	}
-	errs := validateRequiredVarGroupsManifest(fsys.Path("manifest.yml"), manifest)
+	errs := withFile(validateRequiredVarGroupsManifest(fsys.Path("manifest.yml"), manifest), "manifest.yml")
 
 	// Validate data stream manifests.
-	dataStreams, err := listDataStreams(fsys)
+	dataStreams, err := model.DataStreams()
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("failed to list data streams: %w", err)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("failed to list data streams: %w", err).WithFile(dataStreamDir)}
 	}
 	for _, ds := range dataStreams {
-		errs = append(errs, validateDataStreamRequiredVarGroups(fsys, path.Join("data_stream", ds, "manifest.yml"), manifest)...)
+		errs = append(errs, validateDataStreamRequiredVarGroups(fsys, ds, manifest)...)
 	}
 
 	return errs
 }
 
-type requiredVarsManifestVar struct {
-	Name     string `yaml:"name"`
-	Required bool   `yaml:"required"`
-}
-
-type requiredVarsManifest struct {
-	Vars            []requiredVarsManifestVar `yaml:"vars"`
-	PolicyTemplates []struct {
-		Vars   []requiredVarsManifestVar `yaml:"vars"`
-		Inputs []struct {
-			Type         string                               `yaml:"type"`
-			Vars         []requiredVarsManifestVar            `yaml:"vars"`
-			RequiredVars map[string][]requiredVarsManifestVar `yaml:"required_vars"`
-		} `yaml:"inputs"`
-	} `yaml:"policy_templates"`
+// requiredVarGroupsManifestError returns the error for a manifest that cannot be read or parsed.
+func requiredVarGroupsManifestError(fsys fspath.FS, path string, err error) *specerrors.StructuredError {
+	if packages.IsParseError(err) {
+		return specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(path), err).WithFile(path)
+	}
+	return specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to read manifest: %w", fsys.Path(path), err).WithFile(path)
 }
 
-func (m requiredVarsManifest) findInputVars(inputType string) []requiredVarsManifestVar {
-	for _, template := range m.PolicyTemplates {
+func findInputVars(manifest *packages.Manifest, inputType string) []packages.Var {
+	for _, template := range manifest.PolicyTemplates {
 		for _, input := range template.Inputs {
 			if input.Type == inputType {
 				return input.Vars
@@ -70,10 +54,10 @@ func (m requiredVarsManifest) findInputVars(inputType string) []requiredVarsMani
 	return nil
 }
 
-func validateRequiredVarGroupsManifest(path string, manifest requiredVarsManifest) specerrors.ValidationErrors {
+func validateRequiredVarGroupsManifest(path string, manifest *packages.Manifest) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 	for _, template := range manifest.PolicyTemplates {
-		var vars []requiredVarsManifestVar
+		var vars []packages.Var
 		vars = append(vars, manifest.Vars...)
 		vars = append(vars, template.Vars...)
 		for _, input := range template.Inputs {
@@ -87,35 +71,22 @@ func validateRequiredVarGroupsManifest(path string, manifest requiredVarsManifes
 	return errs
 }
 
-func validateDataStreamRequiredVarGroups(fsys fspath.FS, path string, pkgManifest requiredVarsManifest) specerrors.ValidationErrors {
-	d, err := fs.ReadFile(fsys, path)
+func validateDataStreamRequiredVarGroups(fsys fspath.FS, dataStream *packages.DataStream, pkgManifest *packages.Manifest) specerrors.ValidationErrors {
+	path := dataStream.ManifestPath()
+	manifest, err := dataStream.Manifest()
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to read manifest: %w", fsys.Path(path), err)}
+		return specerrors.ValidationErrors{requiredVarGroupsManifestError(fsys, path, err)}
 	}
 
-	var manifest requiredVarsDataStreamManifest
-	err = yaml.Unmarshal(d, &manifest)
-	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(path), err)}
-	}
-
-	return validateDataStreamRequiredVarGroupsManifest(fsys.Path(path), manifest, pkgManifest)
-}
-
-type requiredVarsDataStreamManifest struct {
-	Streams []struct {
-		Input        string                               `yaml:"input"`
-		Vars         []requiredVarsManifestVar            `yaml:"vars"`
-		RequiredVars map[string][]requiredVarsManifestVar `yaml:"required_vars"`
-	} `yaml:"streams"`
+	return withFile(validateDataStreamRequiredVarGroupsManifest(fsys.Path(path), manifest, pkgManifest), path)
 }
 
-func validateDataStreamRequiredVarGroupsManifest(path string, manifest requiredVarsDataStreamManifest, pkgManifest requiredVarsManifest) specerrors.ValidationErrors {
+func validateDataStreamRequiredVarGroupsManifest(path string, manifest *packages.DataStreamManifest, pkgManifest *packages.Manifest) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 	for _, stream := range manifest.Streams {
 		vars := slices.Clone(stream.Vars)
 		vars = append(vars, pkgManifest.Vars...)
-		vars = append(vars, pkgManifest.findInputVars(stream.Input)...)
+		vars = append(vars, findInputVars(pkgManifest, stream.Input)...)
 		for _, varGroup := range stream.RequiredVars {
 			errs = append(errs,
 				validateRequiredVarsDefined(path, vars, varGroup)...)
@@ -124,13 +95,13 @@ func validateDataStreamRequiredVarGroupsManifest(path string, manifest requiredV
 	return errs
 }
 
-func validateRequiredVarsDefined(path string, vars, requiredVars []requiredVarsManifestVar) specerrors.ValidationErrors {
+func validateRequiredVarsDefined(path string, vars, requiredVars []packages.Var) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 	for _, requiredVar := range requiredVars {
 		if requiredVar.Name == "" {
 			continue
 		}
-		i := slices.IndexFunc(vars, func(v requiredVarsManifestVar) bool {
+		i := slices.IndexFunc(vars, func(v packages.Var) bool {
 			return requiredVar.Name == v.Name
 		})
 		if i < 0 {
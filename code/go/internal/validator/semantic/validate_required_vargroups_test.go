@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
+
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 )
 
 func TestValidateRequiredVarGroups(t *testing.T) {
@@ -137,11 +139,11 @@ policy_templates:
 
 	for _, c := range cases {
 		t.Run(c.title, func(t *testing.T) {
-			var manifest requiredVarsManifest
+			var manifest packages.Manifest
 			err := yaml.Unmarshal([]byte(c.manifest), &manifest)
 			require.NoError(t, err)
 
-			errors := validateRequiredVarGroupsManifest("manifest.yml", manifest)
+			errors := validateRequiredVarGroupsManifest("manifest.yml", &manifest)
 			assert.Len(t, errors, len(c.errors))
 			for _, err := range errors {
 				assert.Contains(t, c.errors, err.Error())
@@ -161,7 +163,7 @@ policy_templates:
       vars:
         - name: credentials
 `
-	var pkgManifest requiredVarsManifest
+	var pkgManifest packages.Manifest
 	err := yaml.Unmarshal([]byte(rawPkgManifest), &pkgManifest)
 	require.NoError(t, err)
 
@@ -265,11 +267,11 @@ streams:
 
 	for _, c := range cases {
 		t.Run(c.title, func(t *testing.T) {
-			var manifest requiredVarsDataStreamManifest
+			var manifest packages.DataStreamManifest
 			err := yaml.Unmarshal([]byte(c.manifest), &manifest)
 			require.NoError(t, err)
 
-			errors := validateDataStreamRequiredVarGroupsManifest("manifest.yml", manifest, pkgManifest)
+			errors := validateDataStreamRequiredVarGroupsManifest("manifest.yml", &manifest, &pkgManifest)
 			assert.Len(t, errors, len(c.errors))
 			for _, err := range errors {
 				assert.Contains(t, c.errors, err.Error())
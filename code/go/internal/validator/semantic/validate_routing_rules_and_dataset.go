@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 	"path"
@@ -18,10 +19,10 @@ import (
 
 // ValidateRoutingRulesAndDataset returns validation errors if there are routing rules defined in any dataStream
 // but that dataStream does not defines "dataset" field.
-func ValidateRoutingRulesAndDataset(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateRoutingRulesAndDataset(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	dataStreams, err := listDataStreams(fsys)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile(dataStreamDir)}
 	}
 
 	var errs specerrors.ValidationErrors
@@ -29,7 +30,7 @@ func ValidateRoutingRulesAndDataset(fsys fspath.FS) specerrors.ValidationErrors
 		anyRoutingRules, err := anyRoutingRulesInDataStream(fsys, dataStream)
 		if err != nil {
 			errs.Append(specerrors.ValidationErrors{
-				specerrors.NewStructuredErrorf("failed to find routing rules in data stream %q: %w", dataStream, err),
+				specerrors.NewStructuredErrorf("failed to find routing rules in data stream %q: %w", dataStream, err).WithFile(path.Join(dataStreamDir, dataStream, "routing_rules.yml")),
 			})
 			continue
 		}
@@ -39,7 +40,7 @@ func ValidateRoutingRulesAndDataset(fsys fspath.FS) specerrors.ValidationErrors
 		err = validateDatasetInDataStream(fsys, dataStream)
 		if err != nil {
 			errs.Append(specerrors.ValidationErrors{
-				specerrors.NewStructuredErrorf("routing rules defined in data stream %q but dataset field is missing: %w", dataStream, err),
+				specerrors.NewStructuredErrorf("routing rules defined in data stream %q but dataset field is missing: %w", dataStream, err).WithFile(path.Join(dataStreamDir, dataStream, "manifest.yml")),
 			})
 		}
 	}
@@ -5,13 +5,11 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
-	"io/fs"
-	"path"
-
-	"gopkg.in/yaml.v3"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
@@ -20,65 +18,30 @@ import (
 // - section names are unique within each scope
 // - vars that reference a section via the `section` attribute name a section
 // defined in the `sections` list at the same scope level
-func ValidateSections(fsys fspath.FS) specerrors.ValidationErrors {
-	d, err := fs.ReadFile(fsys, "manifest.yml")
-	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("failed to read file \"%s\": %w", fsys.Path("manifest.yml"), err)}
+func ValidateSections(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
+	manifest, err := model.Manifest()
+	if packages.IsParseError(err) {
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path("manifest.yml"), err).WithFile("manifest.yml")}
 	}
-
-	var manifest sectionsManifest
-	if err := yaml.Unmarshal(d, &manifest); err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path("manifest.yml"), err)}
+	if err != nil {
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("failed to read file \"%s\": %w", fsys.Path("manifest.yml"), err).WithFile("manifest.yml")}
 	}
 
-	errs := validateSectionsManifest(fsys.Path("manifest.yml"), manifest)
+	errs := withFile(validateSectionsManifest(fsys.Path("manifest.yml"), manifest), "manifest.yml")
 
 	// Validate data stream manifests.
-	dataStreams, err := listDataStreams(fsys)
+	dataStreams, err := model.DataStreams()
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("failed to list data streams: %w", err)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("failed to list data streams: %w", err).WithFile(dataStreamDir)}
 	}
 	for _, ds := range dataStreams {
-		errs = append(errs, validateDataStreamSections(fsys, path.Join("data_stream", ds, "manifest.yml"))...)
+		errs = append(errs, validateDataStreamSections(fsys, ds)...)
 	}
 
 	return errs
 }
 
-type sectionsVar struct {
-	Name    string `yaml:"name"`
-	Section string `yaml:"section"`
-}
-
-type manifestSection struct {
-	Name string `yaml:"name"`
-}
-
-type sectionsManifest struct {
-	Sections        []manifestSection `yaml:"sections"`
-	Vars            []sectionsVar     `yaml:"vars"`
-	PolicyTemplates []struct {
-		Sections []manifestSection `yaml:"sections"`
-		Vars     []sectionsVar     `yaml:"vars"`
-		Inputs   []struct {
-			Sections []manifestSection `yaml:"sections"`
-			Vars     []sectionsVar     `yaml:"vars"`
-		} `yaml:"inputs"`
-	} `yaml:"policy_templates"`
-}
-
-type sectionsDataStreamStream struct {
-	Title    string            `yaml:"title"`
-	Input    string            `yaml:"input"`
-	Sections []manifestSection `yaml:"sections"`
-	Vars     []sectionsVar     `yaml:"vars"`
-}
-
-type sectionsDataStreamManifest struct {
-	Streams []sectionsDataStreamStream `yaml:"streams"`
-}
-
-func validateSectionsManifest(filePath string, manifest sectionsManifest) specerrors.ValidationErrors {
+func validateSectionsManifest(filePath string, manifest *packages.Manifest) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 
 	errs = append(errs, validateSectionsScope(filePath, "package root", manifest.Sections, manifest.Vars)...)
@@ -93,18 +56,21 @@ func validateSectionsManifest(filePath string, manifest sectionsManifest) specer
 	return errs
 }
 
-func validateDataStreamSections(fsys fspath.FS, filePath string) specerrors.ValidationErrors {
-	d, err := fs.ReadFile(fsys, filePath)
+func validateDataStreamSections(fsys fspath.FS, dataStream *packages.DataStream) specerrors.ValidationErrors {
+	filePath := dataStream.ManifestPath()
+	manifest, err := dataStream.Manifest()
+	if packages.IsParseError(err) {
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(filePath), err).WithFile(filePath)}
+	}
 	if err != nil {
 		// File might not exist, which is fine.
 		return nil
 	}
 
-	var manifest sectionsDataStreamManifest
-	if err := yaml.Unmarshal(d, &manifest); err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(filePath), err)}
-	}
+	return withFile(validateDataStreamSectionsManifest(fsys.Path(filePath), manifest), filePath)
+}
 
+func validateDataStreamSectionsManifest(filePath string, manifest *packages.DataStreamManifest) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 	for i, stream := range manifest.Streams {
 		streamID := stream.Title
@@ -114,13 +80,12 @@ func validateDataStreamSections(fsys fspath.FS, filePath string) specerrors.Vali
 		if streamID == "" {
 			streamID = fmt.Sprintf("stream[%d]", i)
 		}
-		errs = append(errs, validateSectionsScope(fsys.Path(filePath), fmt.Sprintf("stream %q", streamID), stream.Sections, stream.Vars)...)
+		errs = append(errs, validateSectionsScope(filePath, fmt.Sprintf("stream %q", streamID), stream.Sections, stream.Vars)...)
 	}
-
 	return errs
 }
 
-func validateSectionsScope(filePath, scope string, sections []manifestSection, vars []sectionsVar) specerrors.ValidationErrors {
+func validateSectionsScope(filePath, scope string, sections []packages.Section, vars []packages.Var) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 
 	// Build set of defined section names, checking for duplicates.
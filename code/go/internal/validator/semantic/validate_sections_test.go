@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
+
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 )
 
 func TestValidateSectionsManifest(t *testing.T) {
@@ -145,11 +147,11 @@ policy_templates:
 
 	for _, c := range cases {
 		t.Run(c.title, func(t *testing.T) {
-			var manifest sectionsManifest
+			var manifest packages.Manifest
 			err := yaml.Unmarshal([]byte(c.manifest), &manifest)
 			require.NoError(t, err)
 
-			errors := validateSectionsManifest("manifest.yml", manifest)
+			errors := validateSectionsManifest("manifest.yml", &manifest)
 			assert.Len(t, errors, len(c.errors))
 			for _, err := range errors {
 				assert.Contains(t, c.errors, err.Error())
@@ -161,17 +163,17 @@ policy_templates:
 func TestValidateSectionsScope(t *testing.T) {
 	cases := []struct {
 		title    string
-		sections []manifestSection
-		vars     []sectionsVar
+		sections []packages.Section
+		vars     []packages.Var
 		errors   []string
 	}{
 		{
 			title: "valid: all section references resolve",
-			sections: []manifestSection{
+			sections: []packages.Section{
 				{Name: "auth_section"},
 				{Name: "advanced_section"},
 			},
-			vars: []sectionsVar{
+			vars: []packages.Var{
 				{Name: "username", Section: "auth_section"},
 				{Name: "timeout", Section: "advanced_section"},
 				{Name: "region"},
@@ -179,10 +181,10 @@ func TestValidateSectionsScope(t *testing.T) {
 		},
 		{
 			title: "valid: no vars with section attributes",
-			sections: []manifestSection{
+			sections: []packages.Section{
 				{Name: "auth_section"},
 			},
-			vars: []sectionsVar{
+			vars: []packages.Var{
 				{Name: "username"},
 			},
 		},
@@ -194,7 +196,7 @@ func TestValidateSectionsScope(t *testing.T) {
 		{
 			title:    "invalid: var references undefined section",
 			sections: nil,
-			vars: []sectionsVar{
+			vars: []packages.Var{
 				{Name: "username", Section: "missing_section"},
 			},
 			errors: []string{
@@ -203,7 +205,7 @@ func TestValidateSectionsScope(t *testing.T) {
 		},
 		{
 			title: "invalid: duplicate section name",
-			sections: []manifestSection{
+			sections: []packages.Section{
 				{Name: "auth_section"},
 				{Name: "auth_section"},
 			},
@@ -214,10 +216,10 @@ func TestValidateSectionsScope(t *testing.T) {
 		},
 		{
 			title: "invalid: multiple vars reference undefined sections",
-			sections: []manifestSection{
+			sections: []packages.Section{
 				{Name: "auth_section"},
 			},
-			vars: []sectionsVar{
+			vars: []packages.Var{
 				{Name: "username", Section: "auth_section"},
 				{Name: "api_key", Section: "missing_section"},
 				{Name: "token", Section: "another_missing"},
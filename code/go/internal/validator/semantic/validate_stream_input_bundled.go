@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"errors"
 	"io/fs"
 	"path"
@@ -51,7 +52,7 @@ type packageMaterializationManifest struct {
 //     have 'package:' (composable-input pattern, source-only).
 //   - manifest.yml: each policy_template input must have 'type:' set and must NOT
 //     have 'package:' (package-reference pattern, source-only).
-func ValidateStreamInputBundled(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateStreamInputBundled(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 
 	errs = append(errs, validateDataStreamStreamsBundled(fsys)...)
@@ -66,7 +67,7 @@ func validateDataStreamStreamsBundled(fsys fspath.FS) specerrors.ValidationError
 	dataStreams, err := listDataStreams(fsys)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("can't list data streams: %w", err),
+			specerrors.NewStructuredErrorf("can't list data streams: %w", err).WithFile(dataStreamDir),
 		}
 	}
 
@@ -81,7 +82,7 @@ func validateDataStreamStreamsBundled(fsys fspath.FS) specerrors.ValidationError
 			errs = append(errs, specerrors.NewStructuredErrorf(
 				"file %q: failed to read data stream manifest: %w",
 				fsys.Path(manifestRelPath), err,
-			))
+			).WithFile(manifestRelPath))
 			continue
 		}
 
@@ -90,7 +91,7 @@ func validateDataStreamStreamsBundled(fsys fspath.FS) specerrors.ValidationError
 			errs = append(errs, specerrors.NewStructuredErrorf(
 				"file %q: failed to parse data stream manifest: %w",
 				fsys.Path(manifestRelPath), err,
-			))
+			).WithFile(manifestRelPath))
 			continue
 		}
 
@@ -100,7 +101,7 @@ func validateDataStreamStreamsBundled(fsys fspath.FS) specerrors.ValidationError
 				errs = append(errs, specerrors.NewStructuredErrorf(
 					"file %q: stream[%d] has 'package:' which is source-only; build packages must use 'input:' + 'template_paths:'",
 					fullPath, i,
-				))
+				).WithFile(manifestRelPath))
 			}
 		}
 	}
@@ -118,7 +119,7 @@ func validatePolicyTemplateInputsBundled(fsys fspath.FS) specerrors.ValidationEr
 			return nil
 		}
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("reading %q: %w", fsys.Path(manifestRelPath), err),
+			specerrors.NewStructuredErrorf("reading %q: %w", fsys.Path(manifestRelPath), err).WithFile(manifestRelPath),
 		}
 	}
 
@@ -128,7 +129,7 @@ func validatePolicyTemplateInputsBundled(fsys fspath.FS) specerrors.ValidationEr
 			specerrors.NewStructuredErrorf(
 				"file %q: failed to parse manifest: %w",
 				fsys.Path(manifestRelPath), err,
-			),
+			).WithFile(manifestRelPath),
 		}
 	}
 
@@ -144,7 +145,7 @@ func validatePolicyTemplateInputsBundled(fsys fspath.FS) specerrors.ValidationEr
 				errs = append(errs, specerrors.NewStructuredErrorf(
 					"file %q: policy_template %q input[%d] has 'package:' which is source-only; build packages must use 'type:'",
 					fullPath, policyTemplate.Name, i,
-				))
+				).WithFile(manifestRelPath))
 			}
 		}
 	}
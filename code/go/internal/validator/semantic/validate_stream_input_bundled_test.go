@@ -133,7 +133,7 @@ func TestValidateStreamInputBundled(t *testing.T) {
 			}
 
 			fsys := fspath.DirFS(tempDir)
-			errs := ValidateStreamInputBundled(fsys)
+			errs := ValidateStreamInputBundled(t.Context(), fsys)
 
 			if !testCase.expectErrors {
 				assert.Nil(t, errs, "expected no errors but got: %v", errs)
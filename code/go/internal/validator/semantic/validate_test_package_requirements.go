@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -18,18 +19,18 @@ import (
 
 // ValidateTestPackageRequirements checks that package requirements in test configurations
 // reference packages listed in the manifest and that versions satisfy constraints.
-func ValidateTestPackageRequirements(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateTestPackageRequirements(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	manifest, err := readManifest(fsys)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path("manifest.yml"), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path("manifest.yml"), err).WithFile("manifest.yml")}
 	}
 
 	// Build map of required packages with their version constraints
 	requiredPackages, err := getRequiredPackagesWithConstraints(*manifest)
 	if err != nil {
 		return specerrors.ValidationErrors{
-			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path("manifest.yml"), err)}
+			specerrors.NewStructuredErrorf("file \"%s\" is invalid: %w", fsys.Path("manifest.yml"), err).WithFile("manifest.yml")}
 	}
 
 	var errs specerrors.ValidationErrors
@@ -119,7 +120,7 @@ func validateIntegrationTestRequirements(fsys fspath.FS, requiredPackages map[st
 						if pkgName != "" && version != "" {
 							err := validateTestRequirementPackageVersion(fsys.Path("_dev/test/config.yml"), testType, idx, pkgName, version, requiredPackages)
 							if err != nil {
-								errs = append(errs, err)
+								errs = append(errs, err.WithFile(config.Path()))
 							}
 							continue
 						}
@@ -128,7 +129,7 @@ func validateIntegrationTestRequirements(fsys fspath.FS, requiredPackages map[st
 						if source != "" {
 							err := validateTestRequirementSource(fsys.Path(), fsys.Path("_dev/test/config.yml"), source)
 							if err != nil {
-								errs = append(errs, err)
+								errs = append(errs, err.WithFile(config.Path()))
 							}
 							continue
 						}
@@ -170,7 +171,7 @@ func validateDataStreamTestRequirements(fsys fspath.FS, requiredPackages map[str
 						if pkgName != "" && version != "" {
 							err := validateTestRequirementPackageVersion(fsys.Path(config.Path()), "", idx, pkgName, version, requiredPackages)
 							if err != nil {
-								errs = append(errs, err)
+								errs = append(errs, err.WithFile(config.Path()))
 							}
 							continue
 						}
@@ -179,7 +180,7 @@ func validateDataStreamTestRequirements(fsys fspath.FS, requiredPackages map[str
 						if source != "" {
 							err := validateTestRequirementSource(fsys.Path(), fsys.Path(config.Path()), source)
 							if err != nil {
-								errs = append(errs, err)
+								errs = append(errs, err.WithFile(config.Path()))
 							}
 							continue
 						}
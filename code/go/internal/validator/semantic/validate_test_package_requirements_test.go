@@ -212,7 +212,7 @@ format_version: 3.6.0`,
 			require.NoError(t, err)
 
 			fsys := fspath.DirFS(pkgRoot)
-			errs := ValidateTestPackageRequirements(fsys)
+			errs := ValidateTestPackageRequirements(t.Context(), fsys)
 
 			if tc.expectError {
 				require.NotEmpty(t, errs, "expected validation errors but got none")
@@ -5,11 +5,13 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
@@ -20,13 +22,13 @@ type uniqueField struct {
 }
 
 // ValidateUniqueFields verifies that any field is defined only once on each data stream.
-func ValidateUniqueFields(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateUniqueFields(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
 	// data_stream -> field -> files
 	// if data stream is empty string, it means it is an input package
-	fields := make(map[string]map[uniqueField][]string)
+	fields := make(map[string]map[uniqueField][]fieldFileMetadata)
 	// transform -> field -> files
 	// Created a new map to avoid collisions with data stream names
-	transformFields := make(map[string]map[uniqueField][]string)
+	transformFields := make(map[string]map[uniqueField][]fieldFileMetadata)
 
 	countField := func(metadata fieldFileMetadata, f field) specerrors.ValidationErrors {
 		if len(f.Fields) > 0 {
@@ -37,31 +39,31 @@ func ValidateUniqueFields(fsys fspath.FS) specerrors.ValidationErrors {
 		if metadata.transform != "" {
 			transformMap, found := transformFields[metadata.transform]
 			if !found {
-				transformMap = make(map[uniqueField][]string)
+				transformMap = make(map[uniqueField][]fieldFileMetadata)
 				transformFields[metadata.transform] = transformMap
 			}
 			field := uniqueField{
 				name:      f.Name,
 				transform: metadata.transform,
 			}
-			transformMap[field] = append(transformMap[field], metadata.fullFilePath)
+			transformMap[field] = append(transformMap[field], metadata)
 			return nil
 		}
 
 		dsMap, found := fields[metadata.dataStream]
 		if !found {
-			dsMap = make(map[uniqueField][]string)
+			dsMap = make(map[uniqueField][]fieldFileMetadata)
 			fields[metadata.dataStream] = dsMap
 		}
 		field := uniqueField{
 			name:       f.Name,
 			dataStream: metadata.dataStream,
 		}
-		dsMap[field] = append(dsMap[field], metadata.fullFilePath)
+		dsMap[field] = append(dsMap[field], metadata)
 		return nil
 	}
 
-	err := validateFields(fsys, countField)
+	err := validateFields(fsys, model, countField)
 	if err != nil {
 		return err
 	}
@@ -70,13 +72,16 @@ func ValidateUniqueFields(fsys fspath.FS) specerrors.ValidationErrors {
 	for id, defs := range fields {
 		for field, files := range defs {
 			if len(files) > 1 {
-				sort.Strings(files)
+				sort.Slice(files, func(i, j int) bool {
+					return files[i].fullFilePath < files[j].fullFilePath
+				})
 				message := fmt.Sprintf("field %q is defined multiple times", field.name)
 				if id != "" && field.dataStream != "" {
 					message += fmt.Sprintf(" for data stream %q", id)
 				}
 				errs = append(errs,
-					specerrors.NewStructuredErrorf("%s, found in: %s", message, strings.Join(files, ", ")),
+					specerrors.NewStructuredErrorf("%s, found in: %s", message, strings.Join(fullFilePaths(files), ", ")).
+						WithFile(files[0].filePath),
 				)
 			}
 		}
@@ -85,13 +90,16 @@ func ValidateUniqueFields(fsys fspath.FS) specerrors.ValidationErrors {
 	for id, defs := range transformFields {
 		for field, files := range defs {
 			if len(files) > 1 {
-				sort.Strings(files)
+				sort.Slice(files, func(i, j int) bool {
+					return files[i].fullFilePath < files[j].fullFilePath
+				})
 				message := fmt.Sprintf("field %q is defined multiple times", field.name)
 				if id != "" && field.transform != "" {
 					message += fmt.Sprintf(" for transform %q", id)
 				}
 				errs = append(errs,
-					specerrors.NewStructuredErrorf("%s, found in: %s", message, strings.Join(files, ", ")),
+					specerrors.NewStructuredErrorf("%s, found in: %s", message, strings.Join(fullFilePaths(files), ", ")).
+						WithFile(files[0].filePath),
 				)
 			}
 		}
@@ -99,3 +107,11 @@ func ValidateUniqueFields(fsys fspath.FS) specerrors.ValidationErrors {
 
 	return errs
 }
+
+func fullFilePaths(files []fieldFileMetadata) []string {
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.fullFilePath
+	}
+	return paths
+}
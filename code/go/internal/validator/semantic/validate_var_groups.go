@@ -5,6 +5,7 @@
 package semantic
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 	"path"
@@ -21,24 +22,24 @@ import (
 // - var_group names are unique
 // - option names within each var_group are unique
 // - vars in a var_group must not have required: true (requirement is controlled by var_group)
-func ValidateVarGroups(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateVarGroups(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	// Validate main manifest.
 	d, err := fs.ReadFile(fsys, "manifest.yml")
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("failed to read file \"%s\": %w", fsys.Path("manifest.yml"), err)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("failed to read file \"%s\": %w", fsys.Path("manifest.yml"), err).WithFile("manifest.yml")}
 	}
 
 	var manifest varGroupsManifest
 	err = yaml.Unmarshal(d, &manifest)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path("manifest.yml"), err)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path("manifest.yml"), err).WithFile("manifest.yml")}
 	}
-	errs := validateVarGroupsManifest(fsys.Path("manifest.yml"), manifest)
+	errs := withFile(validateVarGroupsManifest(fsys.Path("manifest.yml"), manifest), "manifest.yml")
 
 	// Validate data stream manifests.
 	dataStreams, err := listDataStreams(fsys)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("failed to list data streams: %w", err)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("failed to list data streams: %w", err).WithFile(dataStreamDir)}
 	}
 	for _, ds := range dataStreams {
 		errs = append(errs, validateDataStreamVarGroups(fsys, path.Join("data_stream", ds, "manifest.yml"), manifest)...)
@@ -113,7 +114,7 @@ func validateDataStreamVarGroups(fsys fspath.FS, filePath string, pkgManifest va
 	var manifest varGroupsDataStreamManifest
 	err = yaml.Unmarshal(d, &manifest)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(filePath), err)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("file \"%s\" is invalid: failed to parse manifest: %w", fsys.Path(filePath), err).WithFile(filePath)}
 	}
 
 	var errs specerrors.ValidationErrors
@@ -142,7 +143,7 @@ func validateDataStreamVarGroups(fsys fspath.FS, filePath string, pkgManifest va
 			stream.VarGroups,
 			availableVars,
 		)
-		errs = append(errs, streamErrs...)
+		errs = append(errs, withFile(streamErrs, filePath)...)
 	}
 
 	return errs
@@ -5,11 +5,14 @@
 package semantic
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v3"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
 	"github.com/elastic/package-spec/v3/code/go/internal/pkgpath"
@@ -18,30 +21,34 @@ import (
 
 // ValidateVersionIntegrity returns validation errors if the version defined in manifest isn't referenced in the latest
 // entry of the changelog file.
-func ValidateVersionIntegrity(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateVersionIntegrity(ctx context.Context, fsys fspath.FS) specerrors.ValidationErrors {
 	manifestVersion, err := readManifestVersion(fsys)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("manifest.yml")}
 	}
 
 	changelogVersions, err := readChangelogVersions(fsys)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("changelog.yml")}
 	}
 
 	err = ensureUniqueVersions(changelogVersions)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("changelog.yml")}
 	}
 
 	err = ensureManifestVersionHasChangelogEntry(manifestVersion, changelogVersions)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		vErr := specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("changelog.yml")
+		if fix, ok := changelogEntryFix(fsys, manifestVersion, changelogVersions); ok {
+			vErr = vErr.WithFix(fix)
+		}
+		return specerrors.ValidationErrors{vErr}
 	}
 
 	err = ensureChangelogLatestVersionIsGreaterThanOthers(changelogVersions)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithFile("changelog.yml")}
 	}
 	return nil
 }
@@ -137,6 +144,47 @@ func ensureManifestVersionHasChangelogEntry(manifestVersion string, versions []s
 	return errors.New("current manifest version doesn't have changelog entry")
 }
 
+// changelogEntryFix returns a fix that adds an entry for the manifest version at
+// the top of the changelog. It can only be fixed this way if the manifest version
+// is greater than the latest version in the changelog. The description and link
+// of the change are not added, so the package is still invalid until they are
+// completed by the developer.
+func changelogEntryFix(fsys fspath.FS, manifestVersion string, versions []string) (specerrors.Fix, bool) {
+	version, err := semver.NewVersion(manifestVersion)
+	if err != nil {
+		return specerrors.Fix{}, false
+	}
+	if len(versions) > 0 {
+		latestVersion, err := semver.NewVersion(versions[0])
+		if err != nil || !version.GreaterThan(latestVersion) {
+			return specerrors.Fix{}, false
+		}
+	}
+
+	content, err := fs.ReadFile(fsys, "changelog.yml")
+	if err != nil {
+		return specerrors.Fix{}, false
+	}
+	var changelog yaml.Node
+	if err := yaml.Unmarshal(content, &changelog); err != nil || len(changelog.Content) != 1 {
+		return specerrors.Fix{}, false
+	}
+	entries := changelog.Content[0]
+	if entries.Kind != yaml.SequenceNode || entries.Style&yaml.FlowStyle != 0 || len(entries.Content) == 0 {
+		return specerrors.Fix{}, false
+	}
+
+	indent := strings.Repeat(" ", entries.Column-1)
+	entry := fmt.Sprintf("%[1]s- version: %[2]q\n"+
+		"%[1]s  changes:\n"+
+		"%[1]s    - type: enhancement\n", indent, manifestVersion)
+	return specerrors.Fix{
+		Description: fmt.Sprintf("Add a changelog entry for version %s, its description and link need to be completed", manifestVersion),
+		File:        "changelog.yml",
+		Edits:       []specerrors.Edit{specerrors.InsertLine(content, entries.Line, entry)},
+	}, true
+}
+
 func ensureChangelogLatestVersionIsGreaterThanOthers(versions []string) error {
 	if len(versions) == 0 {
 		return errors.New("no versions found in changelog")
@@ -5,9 +5,15 @@
 package semantic
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 func TestChangelogUniqueVersions(t *testing.T) {
@@ -186,3 +192,33 @@ func TestChangelogLatestVersionIsGreaterThanOthers(t *testing.T) {
 		})
 	}
 }
+
+func TestChangelogEntryFix(t *testing.T) {
+	changelog := `# newer versions go on top
+- version: "1.0.0"
+  changes:
+    - description: Initial release
+      type: enhancement
+      link: https://github.com/elastic/integrations/pull/1
+`
+	tempDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(tempDir, "changelog.yml"), []byte(changelog), 0644)
+	require.NoError(t, err)
+	fsys := fspath.DirFS(tempDir)
+
+	fix, ok := changelogEntryFix(fsys, "1.1.0", []string{"1.0.0"})
+	require.True(t, ok)
+	assert.Equal(t, "changelog.yml", fix.File)
+	assert.Equal(t, []specerrors.Edit{{
+		Start: 27,
+		End:   27,
+		Text: `- version: "1.1.0"
+  changes:
+    - type: enhancement
+`,
+	}}, fix.Edits)
+
+	// Entries for versions older than the latest one cannot be added on top.
+	_, ok = changelogEntryFix(fsys, "0.9.0", []string{"1.0.0"})
+	assert.False(t, ok)
+}
@@ -5,49 +5,43 @@
 package semantic
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"path"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
-	"github.com/elastic/package-spec/v3/code/go/internal/pkgpath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
-type reference struct {
-	ID   string
-	Name string
-	Type string
-}
+type reference = packages.KibanaReference
 
 // ValidateVisualizationsUsedByValue warns if there are any Kibana
 // Dashboard that defines visualizations by reference instead of value.
 // That is, it warns if a Kibana dashbaord file, foo.json,
 // defines some visualization using reference (containing an element of
 // "visualization" type inside references key).
-func ValidateVisualizationsUsedByValue(fsys fspath.FS) specerrors.ValidationErrors {
+func ValidateVisualizationsUsedByValue(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 
-	filePaths := path.Join("kibana", "dashboard", "*.json")
-	objectFiles, err := pkgpath.Files(fsys, filePaths)
+	objects, err := model.KibanaObjects()
 	if err != nil {
-		errs = append(errs, specerrors.NewStructuredErrorf("error finding Kibana Dashboard files: %w", err))
+		errs = append(errs, specerrors.NewStructuredErrorf("error finding Kibana Dashboard files: %w", err).WithFile("kibana"))
 		return errs
 	}
 
-	for _, objectFile := range objectFiles {
-		filePath := objectFile.Path()
-
-		objectReferences, err := objectFile.Values(`$.references`)
-		if err != nil {
-			// no references key in dashboard json
+	for _, object := range objects {
+		if object.AssetType != "dashboard" {
 			continue
 		}
+		filePath := object.Path
 
-		references, err := anyReference(objectReferences)
+		definition, err := object.Definition()
 		if err != nil {
-			errs = append(errs, specerrors.NewStructuredErrorf("error getting references in file: %s: %w", fsys.Path(filePath), err))
+			errs = append(errs, specerrors.NewStructuredErrorf("error getting references in file: %s: %w", fsys.Path(filePath), err).WithFile(filePath))
+			continue
 		}
+
+		references := anyReference(definition.References)
 		if len(references) > 0 {
 			s := fmt.Sprintf("%s (%s)", references[0].ID, references[0].Type)
 			for _, ref := range references[1:] {
@@ -55,23 +49,15 @@ func ValidateVisualizationsUsedByValue(fsys fspath.FS) specerrors.ValidationErro
 			}
 
 			err = fmt.Errorf("references found in dashboard %s: %s", filePath, s)
-			errs = append(errs, specerrors.NewStructuredError(err, specerrors.CodeVisualizationByValue))
+			errs = append(errs, specerrors.NewStructuredError(err, specerrors.CodeVisualizationByValue).WithFile(filePath))
 		}
 	}
 
 	return errs
 }
 
-func anyReference(val interface{}) ([]reference, error) {
-	allReferences, err := toReferenceSlice(val)
-	if err != nil {
-		return []reference{}, fmt.Errorf("unable to convert references: %w", err)
-	}
-
-	if len(allReferences) == 0 {
-		return []reference{}, nil
-	}
-
+// anyReference returns the references to visualizations.
+func anyReference(allReferences []reference) []reference {
 	var references []reference
 	for _, reference := range allReferences {
 		switch reference.Type {
@@ -79,28 +65,5 @@ func anyReference(val interface{}) ([]reference, error) {
 			references = append(references, reference)
 		}
 	}
-	return references, nil
-
-}
-
-func toReferenceSlice(val interface{}) ([]reference, error) {
-	vals, ok := val.([]interface{})
-	if !ok {
-		return nil, errors.New("conversion error to array")
-	}
-	var refs []reference
-	for _, v := range vals {
-		r, ok := v.(map[string]interface{})
-		if !ok {
-			return nil, errors.New("conversion error to reference element")
-		}
-		ref := reference{
-			ID:   r["id"].(string),
-			Type: r["type"].(string),
-			Name: r["name"].(string),
-		}
-
-		refs = append(refs, ref)
-	}
-	return refs, nil
+	return references
 }
@@ -5,33 +5,25 @@
 package semantic
 
 import (
-	"log"
+	"context"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 // WarnOn returns a validation function that wraps another one. Errors returned by the
-// wrapped validation that have a filtering code are printed as warnings. Other errors
+// wrapped validation that have a filtering code are reported as warnings. Other errors
 // are directly returned.
-func WarnOn(warningsAsErrors bool, validation func(fsys fspath.FS) specerrors.ValidationErrors) func(fspath.FS) specerrors.ValidationErrors {
-	return func(fsys fspath.FS) specerrors.ValidationErrors {
-		errs := validation(fsys)
-		if warningsAsErrors {
-			return errs
-		}
-
-		k := 0
-		for i := range errs {
-			if err := errs[i]; err.Code() != specerrors.UnassignedCode {
-				log.Printf("Warning: %s", err.Error())
+func WarnOn(validation func(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors) func(context.Context, fspath.FS, *packages.Model) specerrors.ValidationErrors {
+	return func(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
+		errs := validation(ctx, fsys, model)
+		for i, err := range errs {
+			if err.Code() == specerrors.UnassignedCode {
 				continue
 			}
-
-			errs[k] = errs[i]
-			k++
+			errs[i] = specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithSeverity(specerrors.SeverityWarning)
 		}
-
-		return errs[:k]
+		return errs
 	}
 }
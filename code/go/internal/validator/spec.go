@@ -5,13 +5,14 @@
 package validator
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
-	"log"
-	"regexp"
+	"log/slog"
 	"slices"
-	"strings"
+	"sync"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/elastic/package-spec/v3/code/go/internal/loader"
 	"github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/internal/spectypes"
+	"github.com/elastic/package-spec/v3/code/go/internal/validator/common"
 	"github.com/elastic/package-spec/v3/code/go/internal/validator/semantic"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
@@ -33,16 +35,65 @@ type Spec struct {
 	specVersion semver.Version
 	// fs contains the filesystem of the spec.
 	fs fs.FS
+	// cache contains the specs already loaded from fs, specs are loaded on each validation when nil.
+	cache *loader.SpecCache
 	// mode is the validation mode (legacy, source, build).
 	mode Mode
 
-	// WarningsAsErrors causes validation warnings to be reported as errors when true.
-	WarningsAsErrors bool
+	// RuleTimeout limits the time each semantic rule can spend validating the package.
+	// No limit is applied when zero.
+	RuleTimeout time.Duration
+
+	// Concurrency is the maximum number of semantic rules run in parallel. Rules
+	// are run sequentially when it is lower than 2.
+	Concurrency int
+
+	// Rules contains additional semantic rules, run after the ones of the spec.
+	Rules []Rule
+
+	// Logger is used to log messages during the validation, they are discarded
+	// when nil. Rules get it from the context with common.LoggerFrom.
+	Logger *slog.Logger
+
+	// Stats collects statistics about the validation of the package when set.
+	Stats *Stats
+}
+
+type validationRule func(ctx context.Context, pkg fspath.FS, model *packages.Model) specerrors.ValidationErrors
+
+// Rule is a semantic validation rule, with the conditions to run it.
+type Rule struct {
+	// Name identifies the rule in the statistics and in timeout errors, the name
+	// of the Validate function is used if empty.
+	Name string
+	// Validate checks the package.
+	Validate func(ctx context.Context, pkg fspath.FS) specerrors.ValidationErrors
+	// ValidateModel checks the package with the model shared by all the rules. It
+	// is used instead of Validate if set.
+	ValidateModel func(ctx context.Context, pkg fspath.FS, model *packages.Model) specerrors.ValidationErrors
+	// Since is the first version of the spec the rule is run for, if set.
+	Since *semver.Version
+	// Until is the version of the spec from which the rule is not run anymore, if set.
+	Until *semver.Version
+	// Types are the package types the rule is run for, all types if nil.
+	Types []string
+	// Modes are the validation modes the rule is run in, all modes if nil.
+	Modes []Mode
+	// Code is assigned to the errors returned by the rule without code, if set.
+	Code string
+}
+
+// namedRule is a validation rule with the name used to report it.
+type namedRule struct {
+	name     string
+	validate validationRule
 }
 
-type validationRule func(pkg fspath.FS) specerrors.ValidationErrors
+type validationRules []namedRule
 
-type validationRules []validationRule
+// specCache contains the specs loaded from the embedded filesystem, shared by all validations
+// in the process.
+var specCache = loader.NewSpecCache(spec.FS())
 
 // GASpecCheckVersion represents minimum version to start checking for unreleased version of the spec
 var GASpecCheckVersion = semver.MustParse("3.0.1")
@@ -58,17 +109,11 @@ func NewSpec(version semver.Version, mode Mode) (*Spec, error) {
 		return nil, fmt.Errorf("invalid validation mode %q", mode)
 	}
 
-	// With more current versions this is reported as a filterable validation error for GA packages.
-	if version.LessThan(GASpecCheckVersion) {
-		if specVersion.Prerelease() != "" {
-			log.Printf("Warning: package using an unreleased version of the spec (%s)", specVersion)
-		}
-	}
-
 	s := Spec{
 		version:     version,
 		specVersion: *specVersion,
 		fs:          spec.FS(),
+		cache:       specCache,
 		mode:        mode,
 	}
 
@@ -77,224 +122,251 @@ func NewSpec(version semver.Version, mode Mode) (*Spec, error) {
 
 // ValidatePackage validates the given Package against the Spec, running both
 // syntactic and semantic rules. The mode embedded in the Spec controls which
-// semantic rules are active.
-func (s Spec) ValidatePackage(pkg packages.Package) specerrors.ValidationErrors {
+// semantic rules are active. Validation stops early if the context is done, in
+// that case the returned errors may be incomplete.
+func (s Spec) ValidatePackage(ctx context.Context, pkg packages.Package) specerrors.ValidationErrors {
 	var errs specerrors.ValidationErrors
 
-	rootSpec, err := loader.LoadSpec(s.fs, s.version, pkg.Type)
+	if s.Logger != nil {
+		ctx = common.ContextWithLogger(ctx, s.Logger)
+	}
+	logger := common.LoggerFrom(ctx)
+	logger.Debug("validating package", "path", pkg.Path(), "spec_version", s.specVersion.String(), "mode", string(s.mode))
+
+	if s.Stats != nil {
+		*s.Stats = Stats{}
+		defer func(start time.Time) {
+			s.Stats.Duration = time.Since(start)
+		}(time.Now())
+	}
+
+	rootSpec, err := s.loadSpec(pkg.Type)
 	if err != nil {
-		errs = append(errs, specerrors.NewStructuredErrorf("could not read root folder spec file: %w", err))
+		errs = append(errs, specerrors.NewStructuredError(fmt.Errorf("could not read root folder spec file: %w", err), specerrors.CodeUnreadablePackage))
 		return errs
 	}
 
-	if !s.version.LessThan(GASpecCheckVersion) && pkg.IsGA() {
-		if s.specVersion.Prerelease() != "" {
+	if s.specVersion.Prerelease() != "" {
+		switch {
+		case s.version.LessThan(GASpecCheckVersion):
+			// With older versions this is only a warning, for any package.
+			err := specerrors.NewStructuredError(
+				fmt.Errorf("file \"%s\": package using an unreleased version of the spec (%s)", pkg.Path("manifest.yml"), s.specVersion),
+				specerrors.CodeNonGASpecOnGAPackage).WithFile("manifest.yml").WithSeverity(specerrors.SeverityWarning)
+			errs = append(errs, err)
+		case pkg.IsGA():
 			err := specerrors.NewStructuredError(
 				fmt.Errorf("file \"%s\": package with GA version (%s) is using an unreleased version of the spec (%s)", pkg.Path("manifest.yml"), pkg.Version, s.specVersion),
-				specerrors.CodeNonGASpecOnGAPackage)
+				specerrors.CodeNonGASpecOnGAPackage).WithFile("manifest.yml")
 			errs = append(errs, err)
 		}
 	}
 
 	// Syntactic validations
-	validator := newValidator(rootSpec, &pkg, s.WarningsAsErrors, s.mode)
-	errs = append(errs, validator.Validate()...)
+	validator := newValidator(rootSpec, &pkg, s.mode)
+	validator.stats = s.Stats
+	errs = append(errs, validator.Validate(ctx)...)
 
 	// Semantic validations
-	errs = append(errs, s.rules(pkg.Type, rootSpec).validate(&pkg)...)
-
-	return processErrors(errs)
-}
-
-func substringInSlice(str string, list []string) bool {
-	for _, substr := range list {
-		if strings.Contains(str, substr) {
-			return true
-		}
+	model := pkg.Model()
+	if model == nil {
+		model = packages.NewModel(&pkg)
 	}
-	return false
-}
-
-func processErrors(errs specerrors.ValidationErrors) specerrors.ValidationErrors {
-	var processedErrs specerrors.ValidationErrors
-
-	// Rename unclear error messages
-	msgTransforms := []struct {
-		matcher *regexp.Regexp
-		new     string
-	}{
-		{
-			matcher: regexp.MustCompile(`Must not validate the schema \(not\)`),
-			new:     "Must not be present",
-		},
-		{
-			matcher: regexp.MustCompile("secret is required"),
-			new:     "variable identified as possible secret, secret parameter required to be set to true or false",
-		},
-		{
-			matcher: regexp.MustCompile(`(field processors.[0-9]+.rename): if is required`),
-			new:     "%s: rename \"message\" to \"event.original\" processor requires if: 'ctx.event?.original == null'",
-		},
-		{
-			matcher: regexp.MustCompile(`(field processors.[0-9]+): remove is required`),
-			new:     "%s: rename \"message\" to \"event.original\" processor requires remove \"message\" processor",
-		},
-		{
-			matcher: regexp.MustCompile(`(processors.[0-9]+.remove.field(.[0-9]+)?): processors.[0-9]+.remove.field(.[0-9]+)? does not match: "message"`),
-			new:     "%s: rename \"message\" to \"event.original\" processor requires remove \"message\" processor",
-		},
-		{
-			matcher: regexp.MustCompile(`(processors.[0-9]+.remove.if): processors.[0-9]+.remove.if does not match: "ctx\.event\?\.original != null"`),
-			new:     "%s: rename \"message\" to \"event.original\" processor requires remove \"message\" processor with if: 'ctx.event?.original != null'",
-		},
-		{
-			matcher: regexp.MustCompile(`(field processors.[0-9]+.remove): (ignore_missing|if) is required`),
-			new:     "%s: rename \"message\" to \"event.original\" processor requires remove \"message\" processor with if: 'ctx.event?.original != null'",
-		},
-	}
-
-	// Filter out redundant errors
-	redundant := []string{
-		"Must validate \"then\" as \"if\" was valid",
-		"Must validate \"else\" as \"if\" was not valid",
-		"Must validate all the schemas (allOf)",
-		"Must validate at least one schema (anyOf)",
-		"Must validate one and only one schema (oneOf)",
-		"At least one of the items must match",
+	rules := s.rules(pkg.Type, rootSpec)
+	logger.Debug("running semantic rules", "rules", len(rules), "concurrency", max(s.Concurrency, 1))
+	modelFilesRead := model.FilesRead()
+	errs = append(errs, rules.validate(ctx, &pkg, model, s.RuleTimeout, s.Concurrency)...)
+	if s.Stats != nil {
+		s.Stats.ModelFilesRead = model.FilesRead() - modelFilesRead
 	}
 
-	// Add error code to specific errors
-	addErrorCode := []struct {
-		matcher *regexp.Regexp
-		code    string
-	}{
-		{
-			matcher: regexp.MustCompile(`rename "message" to "event.original" processor`),
-			code:    specerrors.MessageRenameToEventOriginalValidation,
-		},
-	}
+	return errs
+}
 
-	for _, e := range errs {
-		for _, msg := range msgTransforms {
-			if match := msg.matcher.FindStringSubmatch(e.Error()); len(match) > 1 {
-				e = specerrors.NewStructuredError(
-					errors.New(strings.Replace(e.Error(), match[0], fmt.Sprintf(msg.new, match[1]), 1)),
-					specerrors.UnassignedCode)
-			} else if msg.matcher.MatchString(e.Error()) {
-				e = specerrors.NewStructuredError(
-					errors.New(strings.Replace(e.Error(), msg.matcher.FindString(e.Error()), msg.new, 1)),
-					specerrors.UnassignedCode)
-			}
-		}
-		for _, transform := range addErrorCode {
-			if transform.matcher.MatchString(e.Error()) {
-				e = specerrors.NewStructuredError(e, transform.code)
-			}
-		}
-		if substringInSlice(e.Error(), redundant) {
-			continue
-		}
-		processedErrs = append(processedErrs, e)
+func (s Spec) loadSpec(pkgType string) (spectypes.ItemSpec, error) {
+	if s.cache != nil {
+		return s.cache.LoadSpec(s.version, pkgType)
 	}
-
-	return processedErrs
+	return loader.LoadSpec(s.fs, s.version, pkgType)
 }
 
 func (s Spec) rules(pkgType string, rootSpec spectypes.ItemSpec) validationRules {
-	warnOn := func(validation func(fsys fspath.FS) specerrors.ValidationErrors) func(fspath.FS) specerrors.ValidationErrors {
-		return semantic.WarnOn(s.WarningsAsErrors, validation)
-	}
-	rulesDef := []struct {
-		fn    validationRule
-		since *semver.Version
-		until *semver.Version
-		types []string
-		modes []Mode
-	}{
-		{fn: semantic.ValidateVersionIntegrity},
-		{fn: semantic.ValidateChangelogLinks},
-		{fn: semantic.ValidatePrerelease},
-		{fn: warnOn(semantic.ValidateMinimumKibanaVersion), until: semver.MustParse("3.0.0")},
-		{fn: semantic.ValidateMinimumKibanaVersion, since: semver.MustParse("3.0.0")},
-		{fn: semantic.ValidateFieldGroups},
-		{fn: semantic.ValidateFieldsLimits(rootSpec.MaxFieldsPerDataStream()), types: []string{"integration", "input"}},
-		{fn: semantic.ValidateUniqueFields, since: semver.MustParse("2.0.0"), types: []string{"integration", "input"}},
-		{fn: semantic.ValidateDimensionFields, types: []string{"integration", "input"}},
-		{fn: semantic.ValidateDateFields, types: []string{"integration", "input"}},
-		{fn: semantic.ValidateRequiredFields, types: []string{"integration", "input"}},
-		{fn: semantic.ValidateExternalFieldsWithDevFolder, types: []string{"integration", "input"},
-			modes: []Mode{LegacyMode, SourceMode}},
-		{fn: warnOn(semantic.ValidateVisualizationsUsedByValue), types: []string{"integration", "content"}, until: semver.MustParse("3.0.0")},
-		{fn: semantic.ValidateVisualizationsUsedByValue, types: []string{"integration", "content"}, since: semver.MustParse("3.0.0")},
-		{fn: semantic.ValidateILMPolicyPresent, since: semver.MustParse("2.0.0"), types: []string{"integration"}},
-		{fn: semantic.ValidateProfilesNonGA, types: []string{"integration"}},
-		{fn: semantic.ValidateKibanaObjectIDs, types: []string{"integration", "content"}},
-		{fn: semantic.ValidateRoutingRulesAndDataset, types: []string{"integration"}, since: semver.MustParse("2.9.0")},
-		{fn: semantic.ValidateKibanaNoDanglingObjectIDs, since: semver.MustParse("3.0.0")},
-		{fn: semantic.ValidateKibanaFilterPresent, since: semver.MustParse("3.0.0")},
-		{fn: semantic.ValidateKibanaNoLegacyVisualizations, types: []string{"integration", "content"}, since: semver.MustParse("3.0.0")},
-		{fn: semantic.ValidateDimensionsPresent, types: []string{"integration"}, since: semver.MustParse("3.0.1")},
-		{fn: semantic.ValidateCapabilitiesRequired, since: semver.MustParse("2.10.0")}, // capabilities definition was added in spec version 2.10.0
-		{fn: semantic.ValidateRequiredVarGroups},
-		{fn: semantic.ValidateVarGroups, since: semver.MustParse("3.6.0")},
-		{fn: semantic.ValidateSections},
-		{fn: semantic.ValidateDocsStructure},
-		{fn: semantic.ValidateDeploymentModes, types: []string{"integration"}},
-		{fn: semantic.ValidateDurationVariables, since: semver.MustParse("3.5.0")},
-		{fn: semantic.ValidateInputPackagesPolicyTemplates, types: []string{"input"}},
-		{fn: semantic.ValidateInputDynamicSignalTypes, since: semver.MustParse("3.6.0")},
-		{fn: semantic.ValidateFleetReservedVars, types: []string{"integration", "input"}, since: semver.MustParse("3.6.1")},
-		{fn: semantic.ValidateMinimumAgentVersion},
-		{fn: semantic.ValidateIntegrationPolicyTemplates, types: []string{"integration"}},
-		{fn: semantic.ValidatePolicyTemplateDatastreamCategories, types: []string{"integration"}},
-		{fn: semantic.ValidateDatastreamPackageCategories, types: []string{"integration"}},
-		{fn: semantic.ValidatePipelineTags, types: []string{"integration"}, since: semver.MustParse("3.6.0")},
-		{fn: semantic.ValidateStaticHandlebarsFiles, types: []string{"integration", "input"}},
-		{fn: semantic.ValidateKibanaTagDuplicates},
-		{fn: semantic.ValidatePipelineOnFailure, types: []string{"integration"}, since: semver.MustParse("3.6.0")},
-		{fn: semantic.ValidateIntegrationInputsDeprecation, types: []string{"integration"}, since: semver.MustParse("3.6.0")},
-		{fn: semantic.ValidateIntegrationInputQualifier, types: []string{"integration"}, since: semver.MustParse("3.6.0"),
-			modes: []Mode{LegacyMode, BuildMode}},
-		{fn: semantic.ValidateDeprecatedReplacedBy, since: semver.MustParse("3.6.0")},
-		{fn: semantic.ValidatePackageReferences, types: []string{"integration"}, since: semver.MustParse("3.6.0")},
-		{fn: semantic.ValidateTestPackageRequirements, types: []string{"integration"}, since: semver.MustParse("3.6.0"),
-			modes: []Mode{LegacyMode, SourceMode}},
-		{fn: semantic.ValidateNoEmbeddedEcsInDynamicTemplates, types: []string{"integration"},
-			modes: []Mode{SourceMode}},
-		{fn: semantic.ValidateNoExternalFields, modes: []Mode{BuildMode}},
-		{fn: semantic.ValidateStreamInputBundled, modes: []Mode{BuildMode},
-			types: []string{"integration"}},
+	rulesDef := []Rule{
+		{Code: specerrors.CodeVersionIntegrity, Validate: semantic.ValidateVersionIntegrity},
+		{Code: specerrors.CodeChangelogLinks, Validate: semantic.ValidateChangelogLinks},
+		{Code: specerrors.CodePrerelease, Validate: semantic.ValidatePrerelease},
+		{Name: "semantic.ValidateMinimumKibanaVersion", Code: specerrors.CodeMinimumKibanaVersion, ValidateModel: semantic.WarnOn(semantic.ValidateMinimumKibanaVersion), Until: semver.MustParse("3.0.0")},
+		{Code: specerrors.CodeMinimumKibanaVersion, ValidateModel: semantic.ValidateMinimumKibanaVersion, Since: semver.MustParse("3.0.0")},
+		{Code: specerrors.CodeFieldGroups, ValidateModel: semantic.ValidateFieldGroups},
+		{Code: specerrors.CodeFieldsLimits, ValidateModel: semantic.ValidateFieldsLimits(rootSpec.MaxFieldsPerDataStream()), Types: []string{"integration", "input"}},
+		{Code: specerrors.CodeUniqueFields, ValidateModel: semantic.ValidateUniqueFields, Since: semver.MustParse("2.0.0"), Types: []string{"integration", "input"}},
+		{Code: specerrors.CodeDimensionFields, ValidateModel: semantic.ValidateDimensionFields, Types: []string{"integration", "input"}},
+		{Code: specerrors.CodeDateFields, ValidateModel: semantic.ValidateDateFields, Types: []string{"integration", "input"}},
+		{Code: specerrors.CodeRequiredFields, ValidateModel: semantic.ValidateRequiredFields, Types: []string{"integration", "input"}},
+		{Code: specerrors.CodeExternalFieldsWithDevFolder, ValidateModel: semantic.ValidateExternalFieldsWithDevFolder, Types: []string{"integration", "input"},
+			Modes: []Mode{LegacyMode, SourceMode}},
+		{Name: "semantic.ValidateVisualizationsUsedByValue", Code: specerrors.CodeVisualizationByValue, ValidateModel: semantic.WarnOn(semantic.ValidateVisualizationsUsedByValue), Types: []string{"integration", "content"}, Until: semver.MustParse("3.0.0")},
+		{Code: specerrors.CodeVisualizationByValue, ValidateModel: semantic.ValidateVisualizationsUsedByValue, Types: []string{"integration", "content"}, Since: semver.MustParse("3.0.0")},
+		{Code: specerrors.CodeILMPolicyPresent, Validate: semantic.ValidateILMPolicyPresent, Since: semver.MustParse("2.0.0"), Types: []string{"integration"}},
+		{Code: specerrors.CodeProfilesNonGA, Validate: semantic.ValidateProfilesNonGA, Types: []string{"integration"}},
+		{Code: specerrors.CodeKibanaObjectIDs, ValidateModel: semantic.ValidateKibanaObjectIDs, Types: []string{"integration", "content"}},
+		{Code: specerrors.CodeRoutingRulesAndDataset, Validate: semantic.ValidateRoutingRulesAndDataset, Types: []string{"integration"}, Since: semver.MustParse("2.9.0")},
+		{Code: specerrors.CodeKibanaDanglingObjectsIDs, ValidateModel: semantic.ValidateKibanaNoDanglingObjectIDs, Since: semver.MustParse("3.0.0")},
+		{Code: specerrors.CodeKibanaDashboardWithoutFilter, Validate: semantic.ValidateKibanaFilterPresent, Since: semver.MustParse("3.0.0")},
+		{Code: specerrors.CodeKibanaNoLegacyVisualizations, Validate: semantic.ValidateKibanaNoLegacyVisualizations, Types: []string{"integration", "content"}, Since: semver.MustParse("3.0.0")},
+		{Code: specerrors.CodeDimensionsPresent, ValidateModel: semantic.ValidateDimensionsPresent, Types: []string{"integration"}, Since: semver.MustParse("3.0.1")},
+		{Code: specerrors.CodeCapabilitiesRequired, Validate: semantic.ValidateCapabilitiesRequired, Since: semver.MustParse("2.10.0")}, // capabilities definition was added in spec version 2.10.0
+		{Code: specerrors.CodeRequiredVarGroups, ValidateModel: semantic.ValidateRequiredVarGroups},
+		{Code: specerrors.CodeVarGroups, Validate: semantic.ValidateVarGroups, Since: semver.MustParse("3.6.0")},
+		{Code: specerrors.CodeSections, ValidateModel: semantic.ValidateSections},
+		{Code: specerrors.CodeDocsStructure, Validate: semantic.ValidateDocsStructure},
+		{Code: specerrors.CodeDeploymentModes, Validate: semantic.ValidateDeploymentModes, Types: []string{"integration"}},
+		{Code: specerrors.CodeDurationVariables, ValidateModel: semantic.ValidateDurationVariables, Since: semver.MustParse("3.5.0")},
+		{Code: specerrors.CodeInputPackagesPolicyTemplates, Validate: semantic.ValidateInputPackagesPolicyTemplates, Types: []string{"input"}},
+		{Code: specerrors.CodeInputDynamicSignalTypes, Validate: semantic.ValidateInputDynamicSignalTypes, Since: semver.MustParse("3.6.0")},
+		{Code: specerrors.CodeFleetReservedVars, ValidateModel: semantic.ValidateFleetReservedVars, Types: []string{"integration", "input"}, Since: semver.MustParse("3.6.1")},
+		{Code: specerrors.CodeMinimumAgentVersion, Validate: semantic.ValidateMinimumAgentVersion},
+		{Code: specerrors.CodeIntegrationPolicyTemplates, Validate: semantic.ValidateIntegrationPolicyTemplates, Types: []string{"integration"}},
+		{Code: specerrors.CodePolicyTemplateDatastreamCategories, Validate: semantic.ValidatePolicyTemplateDatastreamCategories, Types: []string{"integration"}},
+		{Code: specerrors.CodeDatastreamPackageCategories, Validate: semantic.ValidateDatastreamPackageCategories, Types: []string{"integration"}},
+		{Code: specerrors.CodePipelineTagRequired, ValidateModel: semantic.ValidatePipelineTags, Types: []string{"integration"}, Since: semver.MustParse("3.6.0")},
+		{Code: specerrors.CodeStaticHandlebarsFiles, Validate: semantic.ValidateStaticHandlebarsFiles, Types: []string{"integration", "input"}},
+		{Code: specerrors.CodeKibanaTagDuplicates, Validate: semantic.ValidateKibanaTagDuplicates},
+		{Code: specerrors.CodePipelineOnFailureEventKind, ValidateModel: semantic.ValidatePipelineOnFailure, Types: []string{"integration"}, Since: semver.MustParse("3.6.0")},
+		{Code: specerrors.CodeIntegrationInputsDeprecation, Validate: semantic.ValidateIntegrationInputsDeprecation, Types: []string{"integration"}, Since: semver.MustParse("3.6.0")},
+		{Code: specerrors.CodeIntegrationInputQualifierRequired, Validate: semantic.ValidateIntegrationInputQualifier, Types: []string{"integration"}, Since: semver.MustParse("3.6.0"),
+			Modes: []Mode{LegacyMode, BuildMode}},
+		{Code: specerrors.CodeDeprecatedReplacedBy, Validate: semantic.ValidateDeprecatedReplacedBy, Since: semver.MustParse("3.6.0")},
+		{Code: specerrors.CodePackageReferences, ValidateModel: semantic.ValidatePackageReferences, Types: []string{"integration"}, Since: semver.MustParse("3.6.0")},
+		{Code: specerrors.CodeTestPackageRequirements, Validate: semantic.ValidateTestPackageRequirements, Types: []string{"integration"}, Since: semver.MustParse("3.6.0"),
+			Modes: []Mode{LegacyMode, SourceMode}},
+		{Code: specerrors.CodeNoEmbeddedEcsInDynamicTemplates, Validate: semantic.ValidateNoEmbeddedEcsInDynamicTemplates, Types: []string{"integration"},
+			Modes: []Mode{SourceMode}},
+		{Code: specerrors.CodeNoExternalFields, ValidateModel: semantic.ValidateNoExternalFields, Modes: []Mode{BuildMode}},
+		{Code: specerrors.CodeStreamInputBundled, Validate: semantic.ValidateStreamInputBundled, Modes: []Mode{BuildMode},
+			Types: []string{"integration"}},
 	}
 
+	rulesDef = append(rulesDef, s.Rules...)
+
 	var validationRules validationRules
 	for _, rule := range rulesDef {
-		if rule.since != nil && s.version.LessThan(rule.since) {
+		if rule.Since != nil && s.version.LessThan(rule.Since) {
 			continue
 		}
-		if rule.until != nil && !s.version.LessThan(rule.until) {
+		if rule.Until != nil && !s.version.LessThan(rule.Until) {
 			continue
 		}
 
-		if rule.types != nil && !slices.Contains(rule.types, pkgType) {
+		if rule.Types != nil && !slices.Contains(rule.Types, pkgType) {
 			continue
 		}
 
-		if rule.modes != nil && !slices.Contains(rule.modes, s.mode) {
+		if rule.Modes != nil && !slices.Contains(rule.Modes, s.mode) {
 			continue
 		}
 
-		validationRules = append(validationRules, rule.fn)
+		name := rule.name()
+		if s.Stats != nil {
+			s.Stats.Rules = append(s.Stats.Rules, RuleStats{Name: name, Code: rule.Code})
+		}
+		validationRules = append(validationRules, namedRule{name: name, validate: withCode(rule.Code, rule.validationRule())})
+	}
+	if s.Stats != nil {
+		// Wrap the rules once all the stats are allocated, so their pointers don't change.
+		for i := range validationRules {
+			validationRules[i].validate = withStats(&s.Stats.Rules[i], validationRules[i].validate)
+		}
 	}
 
 	return validationRules
 }
 
-func (vr validationRules) validate(fsys fspath.FS) specerrors.ValidationErrors {
+// name returns the name of the rule, or the name of its function if not set.
+func (r Rule) name() string {
+	switch {
+	case r.Name != "":
+		return r.Name
+	case r.ValidateModel != nil:
+		return RuleFuncName(r.ValidateModel)
+	default:
+		return RuleFuncName(r.Validate)
+	}
+}
+
+// validationRule returns the function that checks the package for the rule.
+func (r Rule) validationRule() validationRule {
+	if r.ValidateModel != nil {
+		return r.ValidateModel
+	}
+	validate := r.Validate
+	return func(ctx context.Context, fsys fspath.FS, _ *packages.Model) specerrors.ValidationErrors {
+		return validate(ctx, fsys)
+	}
+}
+
+// withCode assigns the code to the errors returned by the rule without code.
+func withCode(code string, rule validationRule) validationRule {
+	if code == specerrors.UnassignedCode {
+		return rule
+	}
+	return func(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
+		errs := rule(ctx, fsys, model)
+		for i, err := range errs {
+			if err.Code() == specerrors.UnassignedCode {
+				errs[i] = specerrors.NewStructuredError(err, code)
+			}
+		}
+		return errs
+	}
+}
+
+// validate runs the rules, up to concurrency of them in parallel. Errors are
+// returned in the order of the rules, regardless of the order they finish.
+func (vr validationRules) validate(ctx context.Context, fsys fspath.FS, model *packages.Model, timeout time.Duration, concurrency int) specerrors.ValidationErrors {
+	results := make([]specerrors.ValidationErrors, len(vr))
+	sem := make(chan struct{}, max(concurrency, 1))
+	var wg sync.WaitGroup
+	for i, rule := range vr {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			results[i] = runRule(ctx, rule, fsys, model, timeout)
+		})
+	}
+	wg.Wait()
+
 	var errs specerrors.ValidationErrors
-	for _, validationRule := range vr {
-		err := validationRule(fsys)
+	for _, err := range results {
 		errs.Append(err)
 	}
 
 	return errs
 }
+
+// runRule runs a validation rule with a context that expires after the given
+// timeout, if any. Rules are expected to honor the context in long-running
+// operations, such as network requests. Rules that reach the timeout are reported
+// with a validation error, as their results can be incomplete. Rules interrupted
+// because the parent context is done are not reported.
+func runRule(parent context.Context, rule namedRule, fsys fspath.FS, model *packages.Model, timeout time.Duration) specerrors.ValidationErrors {
+	ctx := parent
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parent, timeout)
+		defer cancel()
+	}
+	errs := rule.validate(ctx, fsys, model)
+	if timeout > 0 && parent.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		errs = append(errs, specerrors.NewStructuredError(
+			fmt.Errorf("semantic rule %s reached the timeout of %s, its results can be incomplete", rule.name, timeout),
+			specerrors.CodeRuleTimeout))
+	}
+	return errs
+}
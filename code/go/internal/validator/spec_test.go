@@ -5,7 +5,10 @@
 package validator
 
 import (
+	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/stretchr/testify/assert"
@@ -13,6 +16,7 @@ import (
 
 	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
 	"github.com/elastic/package-spec/v3/code/go/internal/packages"
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
 func TestNewSpec(t *testing.T) {
@@ -49,7 +53,7 @@ func TestNoBetaFeatures_Package_GA(t *testing.T) {
 	pkg, err := packages.NewPackage("testdata/packages/features_ga")
 	require.NoError(t, err)
 
-	err = s.ValidatePackage(*pkg)
+	err = s.ValidatePackage(t.Context(), *pkg)
 	require.Empty(t, err)
 }
 
@@ -64,9 +68,25 @@ func TestBetaFeatures_Package_GA(t *testing.T) {
 	pkg, err := packages.NewPackage("testdata/packages/features_beta")
 	require.NoError(t, err)
 
-	errs := s.ValidatePackage(*pkg)
+	errs := s.ValidatePackage(t.Context(), *pkg)
 	require.Len(t, errs, 1)
-	require.Equal(t, "spec for [testdata/packages/features_beta/beta] defines beta features which can't be enabled for packages with a stable semantic version", errs[0].Error())
+	require.Equal(t, "spec for [testdata/packages/features_beta/beta] defines beta features which can't be enabled for packages with a stable semantic version (PSR00002)", errs[0].Error())
+}
+
+func TestUnsupportedReleaseLevel(t *testing.T) {
+	s := Spec{
+		version:     *semver.MustParse("1.0.0"),
+		specVersion: *semver.MustParse("1.0.0"),
+		fs:          fspath.DirFS("testdata/fakespec"),
+		mode:        LegacyMode,
+	}
+	pkg, err := packages.NewPackage("testdata/packages/features_experimental")
+	require.NoError(t, err)
+
+	errs := s.ValidatePackage(t.Context(), *pkg)
+	require.Len(t, errs, 1)
+	assert.Equal(t, specerrors.CodeInvalidSpecRelease, errs[0].Code())
+	assert.Equal(t, `spec for [testdata/packages/features_experimental/experimental] has unsupported release level "experimental", supported values: beta, ga (PSR00013)`, errs[0].Error())
 }
 
 func TestFolderSpecInvalid(t *testing.T) {
@@ -93,8 +113,8 @@ func TestFolderSpecInvalid(t *testing.T) {
 			pkgPath: "testdata/packages/folder_spec_patches",
 			valid:   false,
 			expectedErrors: []string{
-				"item [other.yml] is not allowed in folder [testdata/packages/folder_spec_patches/patches]",
-				"expecting to find [data_stream] folder in folder [testdata/packages/folder_spec_patches/patches]",
+				"item [other.yml] is not allowed in folder [testdata/packages/folder_spec_patches/patches] (PSR00005)",
+				"expecting to find [data_stream] folder in folder [testdata/packages/folder_spec_patches/patches] (PSR00006)",
 			},
 		},
 		{
@@ -104,7 +124,7 @@ func TestFolderSpecInvalid(t *testing.T) {
 			pkgPath: "testdata/packages/folder_spec_patches",
 			valid:   false,
 			expectedErrors: []string{
-				"item [other.yml] is not allowed in folder [testdata/packages/folder_spec_patches/patches]",
+				"item [other.yml] is not allowed in folder [testdata/packages/folder_spec_patches/patches] (PSR00005)",
 			},
 		},
 		{
@@ -114,8 +134,8 @@ func TestFolderSpecInvalid(t *testing.T) {
 			pkgPath: "testdata/packages/folder_spec_patches_chain",
 			valid:   false,
 			expectedErrors: []string{
-				"item [other.yml] is not allowed in folder [testdata/packages/folder_spec_patches_chain/patches]",
-				"expecting to find [other.yml] file in folder [testdata/packages/folder_spec_patches_chain/patches/data_stream]",
+				"item [other.yml] is not allowed in folder [testdata/packages/folder_spec_patches_chain/patches] (PSR00005)",
+				"expecting to find [other.yml] file in folder [testdata/packages/folder_spec_patches_chain/patches/data_stream] (PSR00006)",
 			},
 		},
 		{
@@ -125,7 +145,7 @@ func TestFolderSpecInvalid(t *testing.T) {
 			pkgPath: "testdata/packages/folder_spec_patches_chain",
 			valid:   false,
 			expectedErrors: []string{
-				"item [other.yml] is not allowed in folder [testdata/packages/folder_spec_patches_chain/patches]",
+				"item [other.yml] is not allowed in folder [testdata/packages/folder_spec_patches_chain/patches] (PSR00005)",
 			},
 		},
 	}
@@ -141,7 +161,7 @@ func TestFolderSpecInvalid(t *testing.T) {
 			pkg, err := packages.NewPackage(c.pkgPath)
 			require.NoError(t, err)
 
-			errs := s.ValidatePackage(*pkg)
+			errs := s.ValidatePackage(t.Context(), *pkg)
 			if c.valid {
 				require.Empty(t, errs)
 				return
@@ -155,3 +175,89 @@ func TestFolderSpecInvalid(t *testing.T) {
 	}
 
 }
+
+func TestValidationRulesContext(t *testing.T) {
+	var deadlines []bool
+	rule := func(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
+		_, hasDeadline := ctx.Deadline()
+		deadlines = append(deadlines, hasDeadline)
+		return nil
+	}
+	rules := validationRules{{name: "first", validate: rule}, {name: "second", validate: rule}}
+
+	t.Run("without timeout", func(t *testing.T) {
+		deadlines = nil
+		errs := rules.validate(t.Context(), fspath.DirFS("testdata/packages/features_ga"), nil, 0, 1)
+		assert.Empty(t, errs)
+		assert.Equal(t, []bool{false, false}, deadlines)
+	})
+
+	t.Run("with timeout", func(t *testing.T) {
+		deadlines = nil
+		errs := rules.validate(t.Context(), fspath.DirFS("testdata/packages/features_ga"), nil, time.Minute, 1)
+		assert.Empty(t, errs)
+		assert.Equal(t, []bool{true, true}, deadlines)
+	})
+
+	t.Run("timeout reached", func(t *testing.T) {
+		slowRule := func(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
+			<-ctx.Done()
+			return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("partial result")}
+		}
+		errs := validationRules{{name: "slow", validate: slowRule}}.validate(t.Context(), fspath.DirFS("testdata/packages/features_ga"), nil, time.Millisecond, 1)
+		require.Len(t, errs, 2)
+		assert.Equal(t, "partial result", errs[0].Error())
+		assert.Equal(t, specerrors.CodeRuleTimeout, errs[1].Code())
+		assert.Equal(t, "semantic rule slow reached the timeout of 1ms, its results can be incomplete (PSR00012)", errs[1].Error())
+	})
+
+	t.Run("parent deadline reached", func(t *testing.T) {
+		var called bool
+		slowRule := func(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
+			called = true
+			<-ctx.Done()
+			return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("partial result")}
+		}
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+		errs := validationRules{{name: "slow", validate: slowRule}}.validate(ctx, fspath.DirFS("testdata/packages/features_ga"), nil, time.Minute, 1)
+		require.True(t, called)
+		for _, err := range errs {
+			assert.NotEqual(t, specerrors.CodeRuleTimeout, err.Code())
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		deadlines = nil
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		errs := rules.validate(ctx, fspath.DirFS("testdata/packages/features_ga"), nil, 0, 1)
+		assert.Empty(t, errs)
+		assert.Empty(t, deadlines)
+	})
+}
+
+func TestValidationRulesConcurrency(t *testing.T) {
+	var rules validationRules
+	var expected []string
+	for i := range 10 {
+		message := fmt.Sprintf("error from rule %d", i)
+		expected = append(expected, message)
+		rules = append(rules, namedRule{name: fmt.Sprintf("rule%d", i), validate: func(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
+			// Rules defined first take longer to finish.
+			time.Sleep(time.Duration(10-i) * time.Millisecond)
+			return specerrors.ValidationErrors{specerrors.NewStructuredErrorf("%s", message)}
+		}})
+	}
+
+	for _, concurrency := range []int{0, 1, 4, 20} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			errs := rules.validate(t.Context(), fspath.DirFS("testdata/packages/features_ga"), nil, 0, concurrency)
+			var messages []string
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			assert.Equal(t, expected, messages)
+		})
+	}
+}
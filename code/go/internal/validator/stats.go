@@ -0,0 +1,120 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package validator
+
+import (
+	"context"
+	"io/fs"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
+)
+
+// Stats contains statistics about the validation of a package.
+type Stats struct {
+	// Duration is the total time spent validating the package.
+	Duration time.Duration
+
+	// Folders contains the statistics of the validation of each folder against
+	// its spec, in the order the folders are walked.
+	Folders []FolderStats
+
+	// Rules contains the statistics of each semantic rule run, in the order of
+	// the rules.
+	Rules []RuleStats
+
+	// ModelFilesRead is the number of files read by the model shared by the
+	// semantic rules. Each file is read once, by the first rule that requests its
+	// contents, and it is not counted in the statistics of the rules.
+	ModelFilesRead int
+}
+
+// FolderStats contains statistics about the validation of a folder against its
+// spec. They don't include the validation of its subfolders.
+type FolderStats struct {
+	// Path is the path of the folder in the package, "." for the root folder.
+	Path string
+
+	// Duration is the time spent validating the folder.
+	Duration time.Duration
+
+	// FilesRead is the number of files in the folder whose content was validated.
+	FilesRead int
+
+	// Findings is the number of errors found in the folder.
+	Findings int
+}
+
+// RuleStats contains statistics about a semantic rule.
+type RuleStats struct {
+	// Name identifies the rule.
+	Name string
+
+	// Code is the code assigned to the errors of the rule, if any.
+	Code string
+
+	// Duration is the time spent running the rule.
+	Duration time.Duration
+
+	// FilesRead is the number of files opened by the rule. Files read through
+	// the model shared by the rules are counted in Stats.ModelFilesRead.
+	FilesRead int
+
+	// Findings is the number of errors found by the rule.
+	Findings int
+}
+
+// withStats records the statistics of the rule in stats.
+func withStats(stats *RuleStats, rule validationRule) validationRule {
+	return func(ctx context.Context, fsys fspath.FS, model *packages.Model) specerrors.ValidationErrors {
+		start := time.Now()
+		counter := &countingFS{FS: fsys}
+		errs := rule(ctx, counter, model)
+		stats.Duration = time.Since(start)
+		stats.FilesRead = int(counter.files.Load())
+		stats.Findings = len(errs)
+		return errs
+	}
+}
+
+// countingFS counts the files opened in a filesystem, directories are not counted.
+type countingFS struct {
+	fspath.FS
+
+	files atomic.Int64
+}
+
+// Open opens the named file.
+func (c *countingFS) Open(name string) (fs.File, error) {
+	f, err := c.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := f.Stat(); err == nil && !info.IsDir() {
+		c.files.Add(1)
+	}
+	return f, nil
+}
+
+var funcLiteralSuffix = regexp.MustCompile(`(\.func\d+)+$`)
+
+// RuleFuncName returns the name used to identify a rule with the given validation
+// function in the statistics, the name of the function with its package name.
+func RuleFuncName(validate any) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(validate).Pointer())
+	if fn == nil {
+		return ""
+	}
+	name := fn.Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	return funcLiteralSuffix.ReplaceAllString(name, "")
+}
@@ -0,0 +1,159 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package yamlschema
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// jsonSchemaDraft is the version of JSON Schema used by the spec files.
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// BundleSchema returns the schema of the given spec file as a standalone JSON
+// Schema document for the given version of the spec. The files referenced with
+// `$ref` are included in its definitions, and custom error messages are converted
+// to the `errorMessage` strings understood by editors.
+func BundleSchema(fsys fs.FS, schemaPath string, version semver.Version) (map[string]any, error) {
+	b := bundler{
+		fsys:     fsys,
+		version:  version,
+		root:     schemaPath,
+		included: make(map[string]string),
+	}
+	return b.bundle()
+}
+
+type bundler struct {
+	fsys    fs.FS
+	version semver.Version
+	root    string
+
+	// included contains the definition names of the referenced files.
+	included map[string]string
+	// pending contains the referenced files not included yet.
+	pending []string
+}
+
+func (b *bundler) bundle() (map[string]any, error) {
+	schema, err := loadSchemaFile(b.fsys, b.root, b.version)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.rewrite(schema, b.root); err != nil {
+		return nil, fmt.Errorf("failed to bundle schema %s: %w", b.root, err)
+	}
+
+	definitions, _ := schema["definitions"].(map[string]any)
+	for len(b.pending) > 0 {
+		var schemaPath string
+		schemaPath, b.pending = b.pending[0], b.pending[1:]
+
+		included, err := loadSchemaFile(b.fsys, schemaPath, b.version)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.rewrite(included, schemaPath); err != nil {
+			return nil, fmt.Errorf("failed to bundle schema %s: %w", schemaPath, err)
+		}
+		delete(included, "$schema")
+
+		if definitions == nil {
+			definitions = make(map[string]any)
+			schema["definitions"] = definitions
+		}
+		name := b.included[schemaPath]
+		if _, found := definitions[name]; found {
+			return nil, fmt.Errorf("failed to bundle schema %s: definition %q already exists", schemaPath, name)
+		}
+		definitions[name] = included
+	}
+
+	if _, found := schema["$schema"]; !found {
+		schema["$schema"] = jsonSchemaDraft
+	}
+	return schema, nil
+}
+
+// rewrite updates the references in the schema of the given file to point to the
+// bundled definitions, and converts its custom error messages.
+func (b *bundler) rewrite(schema any, schemaPath string) error {
+	switch schema := schema.(type) {
+	case []any:
+		for _, item := range schema {
+			if err := b.rewrite(item, schemaPath); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		for key, value := range schema {
+			switch {
+			case key == "$ref":
+				ref, ok := value.(string)
+				if !ok {
+					return fmt.Errorf("invalid $ref: %v", value)
+				}
+				schema[key] = b.reference(ref, schemaPath)
+			case key == errorMessageKeyword:
+				msg, err := parseErrorMessage(value)
+				if err != nil {
+					return fmt.Errorf("invalid errorMessage: %w", err)
+				}
+				schema[key] = fmt.Sprintf("%s (%s)", msg.Message, msg.Code)
+			case slices.Contains(dataKeywords, key):
+				continue
+			case slices.Contains(schemaMapKeywords, key):
+				named, ok := value.(map[string]any)
+				if !ok {
+					continue
+				}
+				for _, subschema := range named {
+					if err := b.rewrite(subschema, schemaPath); err != nil {
+						return err
+					}
+				}
+			default:
+				if err := b.rewrite(value, schemaPath); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// reference returns the reference to use in the bundle for a reference found in
+// the given file. Referenced files are queued to be included in the bundle.
+func (b *bundler) reference(ref string, schemaPath string) string {
+	file, fragment, _ := strings.Cut(ref, "#")
+	target := schemaPath
+	if file != "" {
+		target = path.Join(path.Dir(schemaPath), file)
+	}
+	if target == b.root {
+		return "#" + fragment
+	}
+
+	name, found := b.included[target]
+	if !found {
+		name = definitionName(target)
+		b.included[target] = name
+		b.pending = append(b.pending, target)
+	}
+	return "#/definitions/" + name + fragment
+}
+
+// definitionName returns the name of the definition of a bundled file, a valid
+// JSON pointer token derived from its path.
+func definitionName(schemaPath string) string {
+	name := strings.TrimSuffix(schemaPath, ".spec.yml")
+	name = strings.TrimSuffix(name, ".yml")
+	return strings.ReplaceAll(name, "/", ".")
+}
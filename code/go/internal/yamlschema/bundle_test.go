@@ -0,0 +1,67 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package yamlschema
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleSchema(t *testing.T) {
+	fsys := fstest.MapFS{
+		"foo/manifest.spec.yml": &fstest.MapFile{Data: []byte(`
+spec:
+  type: object
+  properties:
+    name:
+      $ref: "../common.spec.yml#/definitions/name"
+    owner:
+      $ref: "#/definitions/owner"
+    version:
+      type: string
+      errorMessage:
+        message: version must be a string
+        code: JSE00001
+  definitions:
+    owner:
+      type: string
+versions:
+  - before: 2.0.0
+    patch:
+      - op: remove
+        path: "/properties/version"
+`)},
+		"common.spec.yml": &fstest.MapFile{Data: []byte(`
+spec:
+  definitions:
+    name:
+      $ref: "#/definitions/identifier"
+    identifier:
+      type: string
+      pattern: '^[a-z]+$'
+`)},
+	}
+
+	schema, err := BundleSchema(fsys, "foo/manifest.spec.yml", *semver.MustParse("2.0.0"))
+	require.NoError(t, err)
+
+	assert.Equal(t, jsonSchemaDraft, schema["$schema"])
+	properties := schema["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"$ref": "#/definitions/common/definitions/name"}, properties["name"])
+	assert.Equal(t, map[string]any{"$ref": "#/definitions/owner"}, properties["owner"])
+	assert.Equal(t, "version must be a string (JSE00001)", properties["version"].(map[string]any)["errorMessage"])
+
+	definitions := schema["definitions"].(map[string]any)
+	common := definitions["common"].(map[string]any)["definitions"].(map[string]any)
+	assert.Equal(t, map[string]any{"$ref": "#/definitions/common/definitions/identifier"}, common["name"])
+
+	schema, err = BundleSchema(fsys, "foo/manifest.spec.yml", *semver.MustParse("1.0.0"))
+	require.NoError(t, err)
+	assert.NotContains(t, schema["properties"], "version")
+}
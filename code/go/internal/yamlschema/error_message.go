@@ -0,0 +1,162 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package yamlschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
+)
+
+// errorMessageKeyword is the keyword of the extension used in spec files to
+// define the message and code of the errors found when validating a subschema.
+// Its value can be the message, or an object with the message and the code.
+const errorMessageKeyword = "errorMessage"
+
+// errorMessageMarker is the property of the constant used to report custom error
+// messages. Schemas with custom messages are converted to a condition that fails
+// with a constant containing the message when the original schema is not valid.
+const errorMessageMarker = "x-package-spec-error-message"
+
+var (
+	// schemaMapKeywords are the keywords whose values are maps of schemas
+	// by name, so their keys are not keywords.
+	schemaMapKeywords = []string{"properties", "patternProperties", "definitions", "$defs", "dependencies"}
+
+	// dataKeywords are the keywords whose values are not schemas.
+	dataKeywords = []string{"const", "enum", "default", "examples"}
+
+	// annotationKeywords are the keywords kept in schemas with custom messages,
+	// so definitions can still be referenced and annotations are not lost.
+	annotationKeywords = []string{"$id", "$schema", "definitions", "$defs", "title", "description", "examples", "default", "$comment"}
+)
+
+// errorMessage is the definition of a custom error message.
+type errorMessage struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
+
+// resolveErrorMessages converts the subschemas with custom error messages to
+// conditions that report these messages when the subschemas are not valid.
+func resolveErrorMessages(schema any) (any, error) {
+	switch schema := schema.(type) {
+	case []any:
+		for i, item := range schema {
+			resolved, err := resolveErrorMessages(item)
+			if err != nil {
+				return nil, err
+			}
+			schema[i] = resolved
+		}
+	case map[string]any:
+		for key, value := range schema {
+			if key == errorMessageKeyword || slices.Contains(dataKeywords, key) {
+				continue
+			}
+			if named, ok := value.(map[string]any); ok && slices.Contains(schemaMapKeywords, key) {
+				for name, subschema := range named {
+					resolved, err := resolveErrorMessages(subschema)
+					if err != nil {
+						return nil, err
+					}
+					named[name] = resolved
+				}
+				continue
+			}
+			resolved, err := resolveErrorMessages(value)
+			if err != nil {
+				return nil, err
+			}
+			schema[key] = resolved
+		}
+
+		if raw, found := schema[errorMessageKeyword]; found {
+			msg, err := parseErrorMessage(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", errorMessageKeyword, err)
+			}
+			return withErrorMessage(schema, msg), nil
+		}
+	}
+	return schema, nil
+}
+
+func parseErrorMessage(raw any) (errorMessage, error) {
+	var msg errorMessage
+	switch raw := raw.(type) {
+	case string:
+		msg.Message = raw
+	case map[string]any:
+		d, err := json.Marshal(raw)
+		if err != nil {
+			return msg, err
+		}
+		if err := json.Unmarshal(d, &msg); err != nil {
+			return msg, err
+		}
+	default:
+		return msg, errors.New("a message or an object with message and code expected")
+	}
+
+	if msg.Message == "" {
+		return msg, errors.New("message cannot be empty")
+	}
+	if msg.Code == "" {
+		msg.Code = specerrors.CodeSchemaInvalid
+	}
+	if _, found := specerrors.LookupCode(msg.Code); !found {
+		return msg, fmt.Errorf("unknown code %q", msg.Code)
+	}
+	return msg, nil
+}
+
+// withErrorMessage returns a schema that reports the given message when the
+// schema is not valid, instead of the errors of the schema itself.
+func withErrorMessage(schema map[string]any, msg errorMessage) map[string]any {
+	condition := make(map[string]any)
+	result := make(map[string]any)
+	for key, value := range schema {
+		switch {
+		case key == errorMessageKeyword:
+		case slices.Contains(annotationKeywords, key):
+			result[key] = value
+		default:
+			condition[key] = value
+		}
+	}
+	result["if"] = condition
+	result["else"] = map[string]any{
+		"const": map[string]any{
+			errorMessageMarker: msg.Message,
+			"code":             msg.Code,
+		},
+	}
+	return result
+}
+
+// customErrorMessage returns the custom message and code reported by the
+// constants created by withErrorMessage, if the error is one of them.
+func customErrorMessage(e *specerrors.SchemaError) (errorMessage, bool) {
+	if e.Keyword != "const" {
+		return errorMessage{}, false
+	}
+	allowed, ok := e.Details["allowed"].(string)
+	if !ok {
+		return errorMessage{}, false
+	}
+	var marker map[string]string
+	if err := json.Unmarshal([]byte(allowed), &marker); err != nil {
+		return errorMessage{}, false
+	}
+	message, found := marker[errorMessageMarker]
+	if !found {
+		return errorMessage{}, false
+	}
+	return errorMessage{Message: message, Code: marker["code"]}, true
+}
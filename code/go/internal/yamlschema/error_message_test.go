@@ -0,0 +1,108 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package yamlschema
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/package-spec/v3/code/go/internal/spectypes"
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
+)
+
+type testLimits struct {
+	spectypes.LimitsSpec
+}
+
+func (testLimits) MaxRelativePathSize() spectypes.FileSize { return 0 }
+
+const errorMessageSpec = `
+spec:
+  type: object
+  properties:
+    name:
+      type: string
+      pattern: '^[a-z]+$'
+      errorMessage: name must contain only lowercase letters
+    service:
+      type: object
+      properties:
+        errorMessage:
+          type: string
+      not:
+        required:
+          - hostname
+      errorMessage:
+        message: hostname is not allowed in services
+        code: JSE00006
+    title:
+      type: string
+`
+
+func TestErrorMessage(t *testing.T) {
+	fsys := fstest.MapFS{
+		"manifest.spec.yml": {Data: []byte(errorMessageSpec)},
+		"valid.yml":         {Data: []byte("name: foo\nservice:\n  errorMessage: foo\n")},
+		"invalid.yml":       {Data: []byte("name: Foo\nservice:\n  hostname: foo\ntitle: 1\n")},
+	}
+	options := spectypes.FileSchemaLoadOptions{
+		ContentType: &spectypes.ContentType{MediaType: "application/x-yaml"},
+		Limits:      testLimits{},
+		SpecVersion: *semver.MustParse("3.0.0"),
+	}
+	schema, err := NewFileSchemaLoader().Load(fsys, "manifest.spec.yml", options)
+	require.NoError(t, err)
+
+	assert.Empty(t, schema.Validate(fsys, "valid.yml"))
+
+	errs := schema.Validate(fsys, "invalid.yml")
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	assert.ElementsMatch(t, []string{
+		"field name: name must contain only lowercase letters (JSE00009)",
+		"field service: hostname is not allowed in services (JSE00006)",
+		"field title: Invalid type. Expected: string, given: integer (JSE00004)",
+	}, messages)
+
+	schemaPaths := make(map[string]string)
+	for _, err := range errs {
+		var schemaErr *specerrors.SchemaError
+		require.ErrorAs(t, err, &schemaErr)
+		if schemaErr.Field != "title" {
+			assert.Equal(t, errorMessageKeyword, schemaErr.Keyword)
+			assert.Empty(t, schemaErr.Details)
+		}
+		schemaPaths[schemaErr.Field] = schemaErr.SchemaPath
+	}
+	assert.Equal(t, map[string]string{
+		"name":    "manifest.spec.yml#/properties/name/errorMessage",
+		"service": "manifest.spec.yml#/properties/service/errorMessage",
+		"title":   "manifest.spec.yml#/properties/title/type",
+	}, schemaPaths)
+}
+
+func TestErrorMessageInvalid(t *testing.T) {
+	cases := map[string]string{
+		"unknown code":  "errorMessage: {message: foo, code: FOO00001}",
+		"empty message": "errorMessage: ''",
+		"invalid type":  "errorMessage: [foo]",
+	}
+
+	for title, definition := range cases {
+		t.Run(title, func(t *testing.T) {
+			fsys := fstest.MapFS{
+				"manifest.spec.yml": {Data: []byte("spec:\n  type: object\n  " + definition + "\n")},
+			}
+			_, err := NewFileSchemaLoader().Load(fsys, "manifest.spec.yml", spectypes.FileSchemaLoadOptions{})
+			assert.ErrorContains(t, err, "invalid errorMessage")
+		})
+	}
+}
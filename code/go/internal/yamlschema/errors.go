@@ -0,0 +1,183 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package yamlschema
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/elastic/gojsonschema"
+
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
+)
+
+// redundantSchemaErrorKeywords are the keywords of errors that are always reported
+// along with other errors explaining the cause, so they are not reported.
+var redundantSchemaErrorKeywords = []string{
+	"condition_then",
+	"condition_else",
+	"number_all_of",
+	"number_any_of",
+	"number_one_of",
+	"contains",
+}
+
+// schemaErrorTransform replaces the description of schema errors that are not
+// clear enough, and optionally assigns them a more specific code.
+type schemaErrorTransform struct {
+	keyword     string
+	field       *regexp.Regexp
+	details     map[string][]string
+	description string
+	code        string
+}
+
+func (t schemaErrorTransform) matches(e *specerrors.SchemaError) bool {
+	if t.keyword != e.Keyword {
+		return false
+	}
+	if t.field != nil && !t.field.MatchString(e.Field) {
+		return false
+	}
+	for name, values := range t.details {
+		value, found := e.Details[name]
+		if !found || !slices.Contains(values, fmt.Sprint(value)) {
+			return false
+		}
+	}
+	return true
+}
+
+const (
+	renameMessageRequiresIf           = `rename "message" to "event.original" processor requires if: 'ctx.event?.original == null'`
+	renameMessageRequiresRemove       = `rename "message" to "event.original" processor requires remove "message" processor`
+	renameMessageRequiresRemoveWithIf = `rename "message" to "event.original" processor requires remove "message" processor with if: 'ctx.event?.original != null'`
+)
+
+var schemaErrorTransforms = []schemaErrorTransform{
+	{
+		keyword:     "number_not",
+		description: "Must not be present",
+	},
+	{
+		keyword:     "required",
+		details:     map[string][]string{"property": {"secret"}},
+		description: "variable identified as possible secret, secret parameter required to be set to true or false",
+	},
+	{
+		keyword:     "format",
+		details:     map[string][]string{"format": {relativePathFormat}},
+		description: "relative path is invalid, target doesn't exist or it exceeds the file size limit",
+	},
+	{
+		keyword:     "format",
+		details:     map[string][]string{"format": {dataStreamNameFormat}},
+		description: "data stream doesn't exist",
+	},
+	{
+		keyword:     "required",
+		field:       regexp.MustCompile(`^processors\.[0-9]+\.rename$`),
+		details:     map[string][]string{"property": {"if"}},
+		description: renameMessageRequiresIf,
+		code:        specerrors.MessageRenameToEventOriginalValidation,
+	},
+	{
+		keyword:     "required",
+		field:       regexp.MustCompile(`^processors\.[0-9]+$`),
+		details:     map[string][]string{"property": {"remove"}},
+		description: renameMessageRequiresRemove,
+		code:        specerrors.MessageRenameToEventOriginalValidation,
+	},
+	{
+		keyword:     "const",
+		field:       regexp.MustCompile(`^processors\.[0-9]+\.remove\.field(\.[0-9]+)?$`),
+		details:     map[string][]string{"allowed": {`"message"`}},
+		description: renameMessageRequiresRemove,
+		code:        specerrors.MessageRenameToEventOriginalValidation,
+	},
+	{
+		keyword:     "const",
+		field:       regexp.MustCompile(`^processors\.[0-9]+\.remove\.if$`),
+		details:     map[string][]string{"allowed": {`"ctx.event?.original != null"`}},
+		description: renameMessageRequiresRemoveWithIf,
+		code:        specerrors.MessageRenameToEventOriginalValidation,
+	},
+	{
+		keyword:     "required",
+		field:       regexp.MustCompile(`^processors\.[0-9]+\.remove$`),
+		details:     map[string][]string{"property": {"ignore_missing", "if"}},
+		description: renameMessageRequiresRemoveWithIf,
+		code:        specerrors.MessageRenameToEventOriginalValidation,
+	},
+}
+
+// newSchemaError converts the error reported by the schema validator to a
+// schema error, with the custom error message defined in the spec, if any, or
+// applying the first matching transform otherwise. It returns false if
+// the error is redundant and shouldn't be reported.
+func newSchemaError(re gojsonschema.ResultError) (*specerrors.SchemaError, string, bool) {
+	if slices.Contains(redundantSchemaErrorKeywords, re.Type()) {
+		return nil, "", false
+	}
+
+	details := make(map[string]any, len(re.Details()))
+	for name, value := range re.Details() {
+		// The field and its context are already part of the error.
+		if name == "field" || name == "context" {
+			continue
+		}
+		details[name] = value
+	}
+	schemaErr := &specerrors.SchemaError{
+		Keyword:     re.Type(),
+		Field:       re.Field(),
+		Details:     details,
+		Description: re.Description(),
+	}
+
+	if msg, ok := customErrorMessage(schemaErr); ok {
+		schemaErr.Keyword = errorMessageKeyword
+		schemaErr.Description = msg.Message
+		delete(schemaErr.Details, "allowed")
+		return schemaErr, msg.Code, true
+	}
+
+	code := schemaErrorCode(re.Type())
+	for _, transform := range schemaErrorTransforms {
+		if !transform.matches(schemaErr) {
+			continue
+		}
+		schemaErr.Description = transform.description
+		if transform.code != "" {
+			code = transform.code
+		}
+		break
+	}
+	return schemaErr, code, true
+}
+
+// schemaErrorCode returns the validation code for the class of the schema error.
+func schemaErrorCode(keyword string) string {
+	switch keyword {
+	case "required":
+		return specerrors.CodeSchemaRequired
+	case "additional_property_not_allowed", "array_no_additional_items":
+		return specerrors.CodeSchemaAdditionalProperty
+	case "invalid_type":
+		return specerrors.CodeSchemaInvalidType
+	case "enum", "const":
+		return specerrors.CodeSchemaValueNotAllowed
+	case "number_not", "false":
+		return specerrors.CodeSchemaMustNotBePresent
+	case "pattern", "format", "invalid_property_pattern", "invalid_property_name":
+		return specerrors.CodeSchemaInvalidFormat
+	case "string_gte", "string_lte", "number_gte", "number_gt", "number_lte", "number_lt", "multiple_of",
+		"array_min_items", "array_max_items", "array_min_properties", "array_max_properties":
+		return specerrors.CodeSchemaOutOfRange
+	default:
+		return specerrors.CodeSchemaInvalid
+	}
+}
@@ -56,33 +56,53 @@ func (r relativePathChecker) IsFormat(input interface{}) bool {
 	return true
 }
 
-// loadRelativePathFormatChecker loads the relative-path format checker into the
-// json-schema validation library.
-func loadRelativePathFormatChecker(fsys fs.FS, currentPath string, sizeLimit spectypes.FileSize) {
-	gojsonschema.FormatCheckers.Add(relativePathFormat, relativePathChecker{
-		fsys:        fsys,
-		currentPath: currentPath,
-		sizeLimit:   sizeLimit,
-	})
+// formatCheckers contains the format checkers that depend on the package being
+// validated, indexed by format name.
+type formatCheckers map[string]gojsonschema.FormatChecker
+
+// newFormatCheckers returns the format checkers for a file in currentPath.
+func newFormatCheckers(fsys fs.FS, currentPath string, sizeLimit spectypes.FileSize) formatCheckers {
+	return formatCheckers{
+		relativePathFormat: relativePathChecker{
+			fsys:        fsys,
+			currentPath: currentPath,
+			sizeLimit:   sizeLimit,
+		},
+		dataStreamNameFormat: relativePathChecker{
+			fsys:        fsys,
+			currentPath: path.Join(currentPath, "data_stream"),
+		},
+	}
 }
 
-// unloadRelativePathFormatChecker unloads the relative-path format checker from the
-// json-schema validation library.
-func unloadRelativePathFormatChecker() {
-	gojsonschema.FormatCheckers.Remove(relativePathFormat)
+// reportable returns false if the given schema validation error is a format error of
+// one of the checkers and the value matches the format, true otherwise.
+func (c formatCheckers) reportable(re gojsonschema.ResultError) bool {
+	if _, ok := re.(*gojsonschema.DoesNotMatchFormatError); !ok {
+		return true
+	}
+	format, _ := re.Details()["format"].(string)
+	checker, found := c[format]
+	if !found {
+		return true
+	}
+	return !checker.IsFormat(re.Value())
 }
 
-// loadDataStreamNameFormatChecker loads the data-stream-name format checker into the
-// json-schema validation library.
-func loadDataStreamNameFormatChecker(fsys fs.FS, currentPath string) {
-	gojsonschema.FormatCheckers.Add(dataStreamNameFormat, relativePathChecker{
-		fsys:        fsys,
-		currentPath: path.Join(currentPath, "data_stream"),
-	})
+// deferredFormatChecker is registered in the json-schema validation library for the formats
+// that depend on the package being validated. It reports all values as invalid, so they are
+// reported as errors and checked after validation with the checkers of each validation.
+// This avoids modifying the global registry of checkers of the library on each validation.
+// As a consequence, these formats cannot be used to choose between alternatives, as in
+// oneOf, anyOf or not.
+type deferredFormatChecker struct{}
+
+// IsFormat method always returns false, so the value is checked after validation.
+func (deferredFormatChecker) IsFormat(input interface{}) bool {
+	return false
 }
 
-// unloadDataStreamNameFormatChecker unloads the data-stream-name format checker from the
-// json-schema validation library.
-func unloadDataStreamNameFormatChecker() {
-	gojsonschema.FormatCheckers.Remove(dataStreamNameFormat)
+func init() {
+	gojsonschema.FormatCheckers.Add(relativePathFormat, deferredFormatChecker{})
+	gojsonschema.FormatCheckers.Add(dataStreamNameFormat, deferredFormatChecker{})
 }
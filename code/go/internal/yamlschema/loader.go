@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"io/fs"
 	"path"
-	"sync"
 
 	"github.com/Masterminds/semver/v3"
 
@@ -35,47 +34,67 @@ func (*FileSchemaLoader) Load(fs fs.FS, schemaPath string, options spectypes.Fil
 	if err != nil {
 		return nil, fmt.Errorf("failed to load schema for %q: %v", schemaPath, err)
 	}
-	return &FileSchema{schema, options}, nil
+	return &FileSchema{
+		schema:  schema,
+		options: options,
+		locator: newSchemaLocator(fs, schemaPath, options.SpecVersion),
+	}, nil
 }
 
 type FileSchema struct {
 	schema  *gojsonschema.Schema
 	options spectypes.FileSchemaLoadOptions
+	locator *schemaLocator
 }
 
-var formatCheckersMutex sync.Mutex
-
 func (s *FileSchema) Validate(fsys fs.FS, filePath string) specerrors.ValidationErrors {
 	data, err := loadItemSchema(fsys, filePath, s.options.ContentType, s.options.SpecVersion)
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.CodeInvalidFileFormat).WithFile(filePath)}
 	}
 
-	formatCheckersMutex.Lock()
-	defer func() {
-		unloadRelativePathFormatChecker()
-		unloadDataStreamNameFormatChecker()
-		formatCheckersMutex.Unlock()
-	}()
-
-	loadRelativePathFormatChecker(fsys, path.Dir(filePath), s.options.Limits.MaxRelativePathSize())
-	loadDataStreamNameFormatChecker(fsys, path.Dir(filePath))
 	result, err := s.schema.Validate(gojsonschema.NewBytesLoader(data))
 	if err != nil {
-		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.UnassignedCode)}
+		return specerrors.ValidationErrors{specerrors.NewStructuredError(err, specerrors.CodeUnreadablePackage).WithFile(filePath)}
+	}
+
+	if result.Valid() {
+		return nil // item content is valid according to the loaded schema
 	}
 
-	if !result.Valid() {
-		var errs specerrors.ValidationErrors
-		for _, re := range result.Errors() {
-			errs = append(errs,
-				specerrors.NewStructuredErrorf("field %s: %s", re.Field(), adjustErrorDescription(re.Description())),
-			)
+	checkers := newFormatCheckers(fsys, path.Dir(filePath), s.options.Limits.MaxRelativePathSize())
+	var resultErrors []gojsonschema.ResultError
+	for _, re := range result.Errors() {
+		if checkers.reportable(re) {
+			resultErrors = append(resultErrors, re)
 		}
-		return errs
+	}
+	if len(resultErrors) == 0 {
+		return nil // values with formats checked after validation are valid
+	}
+
+	var positions *documentPositions
+	if raw, err := fs.ReadFile(fsys, filePath); err == nil {
+		positions = newDocumentPositions(raw)
+	} else {
+		positions = &documentPositions{}
 	}
 
-	return nil // item content is valid according to the loaded schema
+	var errs specerrors.ValidationErrors
+	for _, re := range resultErrors {
+		schemaErr, code, reportable := newSchemaError(re)
+		if !reportable {
+			continue
+		}
+		schemaErr.SchemaPath = s.locator.locate(re.Context(), schemaErr)
+		line, column := positions.lookup(re.Context())
+		errs = append(errs,
+			specerrors.NewStructuredError(schemaErr, code).
+				WithFile(filePath).
+				WithPosition(line, column),
+		)
+	}
+	return errs
 }
 
 func loadItemSchema(fsys fs.FS, path string, contentType *spectypes.ContentType, specVersion semver.Version) ([]byte, error) {
@@ -134,12 +153,3 @@ func expandItemKey(c interface{}) interface{} {
 	}
 	return c // c is something else, e.g. string, int, etc.
 }
-
-func adjustErrorDescription(description string) string {
-	if description == "Does not match format '"+relativePathFormat+"'" {
-		return "relative path is invalid, target doesn't exist or it exceeds the file size limit"
-	} else if description == "Does not match format '"+dataStreamNameFormat+"'" {
-		return "data stream doesn't exist"
-	}
-	return description
-}
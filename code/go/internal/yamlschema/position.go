@@ -0,0 +1,76 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package yamlschema
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/elastic/gojsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// contextSeparator is used to split json-schema contexts into their segments, it
+// cannot be a dot because dots can be part of the keys.
+const contextSeparator = "\x00"
+
+// documentPositions resolves json-schema contexts to positions in the source
+// document. JSON documents are also valid YAML, so both are supported.
+type documentPositions struct {
+	root *yaml.Node
+}
+
+func newDocumentPositions(data []byte) *documentPositions {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return &documentPositions{}
+	}
+	return &documentPositions{root: &root}
+}
+
+// lookup returns the line and column of the deepest node found for the given
+// context, or zeroes if the document couldn't be parsed.
+func (p *documentPositions) lookup(context *gojsonschema.JsonContext) (line int, column int) {
+	if p.root == nil || context == nil {
+		return 0, 0
+	}
+
+	node := p.root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	segments := strings.Split(context.String(contextSeparator), contextSeparator)
+	// First segment is always the root.
+	for _, segment := range segments[1:] {
+		next := childNode(node, segment)
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return node.Line, node.Column
+}
+
+func childNode(node *yaml.Node, segment string) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == segment {
+				return node.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		idx, err := strconv.Atoi(segment)
+		if err != nil || idx < 0 || idx >= len(node.Content) {
+			return nil
+		}
+		return node.Content[idx]
+	}
+	return nil
+}
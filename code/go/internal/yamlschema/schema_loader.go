@@ -49,7 +49,16 @@ func (l *yamlReferenceLoader) LoadJSON() (any, error) {
 	}
 	resourcePath := strings.TrimPrefix(parsed.Path, "/")
 
-	itemSchemaData, err := fs.ReadFile(l.fs, resourcePath)
+	resolved, err := loadSchemaFile(l.fs, resourcePath, l.version)
+	if err != nil {
+		return nil, err
+	}
+	return resolveErrorMessages(resolved)
+}
+
+// loadSchemaFile reads the schema in the given spec file, resolved for the given version.
+func loadSchemaFile(fsys fs.FS, resourcePath string, version semver.Version) (map[string]any, error) {
+	itemSchemaData, err := fs.ReadFile(fsys, resourcePath)
 	if err != nil {
 		return nil, fmt.Errorf("reading schema file failed: %w", err)
 	}
@@ -60,10 +69,10 @@ func (l *yamlReferenceLoader) LoadJSON() (any, error) {
 	var schema itemSchemaSpec
 	err = yaml.Unmarshal(itemSchemaData, &schema)
 	if err != nil {
-		return nil, fmt.Errorf("schema unmarshalling failed (path: %s): %w", l.source, err)
+		return nil, fmt.Errorf("schema unmarshalling failed (path: %s): %w", resourcePath, err)
 	}
 	if len(schema.Spec) == 0 {
-		return nil, fmt.Errorf("no spec found in schema file (path: %s)", l.source)
+		return nil, fmt.Errorf("no spec found in schema file (path: %s)", resourcePath)
 	}
 
 	// fixJSONNumbers ensures that the numbers in the resulting spec are of type `json.Number`, that is
@@ -73,10 +82,10 @@ func (l *yamlReferenceLoader) LoadJSON() (any, error) {
 	// look for a YAML parser that can be customized to use `json.Number`.
 	schema.Spec, err = fixJSONNumbers(schema.Spec)
 	if err != nil {
-		return nil, fmt.Errorf("fixing numbers in parsed schema failed (path %s): %w", l.source, err)
+		return nil, fmt.Errorf("fixing numbers in parsed schema failed (path %s): %w", resourcePath, err)
 	}
 
-	return schema.resolve(l.version)
+	return schema.resolve(version)
 }
 
 // fixJSONNumbers converts number types to `json.Number` by converting the struct to JSON and decoding it again.
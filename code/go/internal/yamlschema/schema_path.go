@@ -0,0 +1,321 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package yamlschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/elastic/gojsonschema"
+
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
+)
+
+// schemaKeywords maps the types of the errors reported by the schema validator
+// to the keywords of the schema that fail. An empty keyword refers to the
+// subschema itself, as for the false schemas.
+var schemaKeywords = map[string]string{
+	"required":                        "required",
+	"additional_property_not_allowed": "additionalProperties",
+	"array_no_additional_items":       "additionalItems",
+	"invalid_type":                    "type",
+	"enum":                            "enum",
+	"const":                           "const",
+	"number_not":                      "not",
+	"false":                           "",
+	"pattern":                         "pattern",
+	"format":                          "format",
+	"invalid_property_pattern":        "patternProperties",
+	"invalid_property_name":           "propertyNames",
+	"string_gte":                      "minLength",
+	"string_lte":                      "maxLength",
+	"number_gte":                      "minimum",
+	"number_gt":                       "exclusiveMinimum",
+	"number_lte":                      "maximum",
+	"number_lt":                       "exclusiveMaximum",
+	"multiple_of":                     "multipleOf",
+	"array_min_items":                 "minItems",
+	"array_max_items":                 "maxItems",
+	"array_min_properties":            "minProperties",
+	"array_max_properties":            "maxProperties",
+	"unique":                          "uniqueItems",
+	"contains":                        "contains",
+	"number_any_of":                   "anyOf",
+	"number_one_of":                   "oneOf",
+	"number_all_of":                   "allOf",
+	"condition_then":                  "then",
+	"condition_else":                  "else",
+	"missing_dependency":              "dependencies",
+	errorMessageKeyword:               errorMessageKeyword,
+}
+
+// schemaLocator finds the location in the spec files of the keywords that fail
+// when validating documents. The schema validator doesn't report the schema of
+// the errors, so their location is derived from the path of the invalid value
+// and the keyword, walking the spec files from the root schema. Spec files are
+// loaded the first time they are needed.
+type schemaLocator struct {
+	fsys    fs.FS
+	root    string
+	version semver.Version
+
+	mutex sync.Mutex
+	files map[string]map[string]any
+}
+
+func newSchemaLocator(fsys fs.FS, root string, version semver.Version) *schemaLocator {
+	return &schemaLocator{
+		fsys:    fsys,
+		root:    root,
+		version: version,
+		files:   make(map[string]map[string]any),
+	}
+}
+
+// schemaNode is a subschema in a spec file, located by a JSON pointer.
+type schemaNode struct {
+	file    string
+	pointer string
+	schema  any
+}
+
+func (n schemaNode) String() string {
+	return n.file + "#" + n.pointer
+}
+
+func (n schemaNode) child(schema any, keys ...string) schemaNode {
+	pointer := n.pointer
+	for _, key := range keys {
+		pointer += "/" + escapePointer(key)
+	}
+	return schemaNode{file: n.file, pointer: pointer, schema: schema}
+}
+
+// locate returns the location of the keyword of the schema error, as a spec file
+// and a JSON pointer in its schema. It returns an empty string if the location
+// cannot be determined.
+func (l *schemaLocator) locate(context *gojsonschema.JsonContext, e *specerrors.SchemaError) string {
+	keyword, found := schemaKeywords[e.Keyword]
+	if !found || context == nil {
+		return ""
+	}
+	schema, err := l.file(l.root)
+	if err != nil {
+		return ""
+	}
+
+	// First segment is always the root.
+	segments := strings.Split(context.String(contextSeparator), contextSeparator)[1:]
+	node, found := l.find(schemaNode{file: l.root, schema: schema}, segments, keyword, e, make(map[string]bool))
+	if !found {
+		return ""
+	}
+	if keyword == "" {
+		return node.String()
+	}
+	return node.child(nil, keyword).String()
+}
+
+// find looks for the subschema with the keyword that validates the value in the
+// path given by segments. References and subschemas combined with allOf, anyOf,
+// oneOf or conditions are followed in order, and the first match is returned.
+func (l *schemaLocator) find(node schemaNode, segments []string, keyword string, e *specerrors.SchemaError, visited map[string]bool) (schemaNode, bool) {
+	// Segments are always suffixes of the same path, so their length identifies them.
+	key := node.String() + contextSeparator + strconv.Itoa(len(segments))
+	if visited[key] {
+		return schemaNode{}, false
+	}
+	visited[key] = true
+
+	schema, ok := node.schema.(map[string]any)
+	if !ok {
+		found := len(segments) == 0 && keyword == "" && node.schema == false
+		return node, found
+	}
+	if len(segments) == 0 && matchesKeyword(schema, keyword, e) {
+		return node, true
+	}
+
+	var candidates []schemaNode
+	if ref, ok := schema["$ref"].(string); ok {
+		if target, ok := l.resolve(node, ref); ok {
+			candidates = append(candidates, target)
+		}
+	}
+	for _, combinator := range []string{"allOf", "anyOf", "oneOf"} {
+		subschemas, _ := schema[combinator].([]any)
+		for i, subschema := range subschemas {
+			candidates = append(candidates, node.child(subschema, combinator, strconv.Itoa(i)))
+		}
+	}
+	for _, condition := range []string{"then", "else"} {
+		if subschema, found := schema[condition]; found {
+			candidates = append(candidates, node.child(subschema, condition))
+		}
+	}
+	for _, candidate := range candidates {
+		if found, ok := l.find(candidate, segments, keyword, e, visited); ok {
+			return found, true
+		}
+	}
+
+	if len(segments) == 0 {
+		return schemaNode{}, false
+	}
+	for _, child := range childSchemas(node, schema, segments[0]) {
+		if found, ok := l.find(child, segments[1:], keyword, e, visited); ok {
+			return found, true
+		}
+	}
+	return schemaNode{}, false
+}
+
+// matchesKeyword returns true if the schema contains the keyword that reports the
+// given error.
+func matchesKeyword(schema map[string]any, keyword string, e *specerrors.SchemaError) bool {
+	if keyword == "" {
+		return false
+	}
+	value, found := schema[keyword]
+	if !found {
+		return false
+	}
+	switch keyword {
+	case "required":
+		required, _ := value.([]any)
+		return slices.Contains(required, e.Details["property"])
+	case "additionalProperties", "additionalItems":
+		return value == false
+	case "const":
+		allowed, err := json.Marshal(value)
+		return err == nil && string(allowed) == e.Details["allowed"]
+	}
+	return true
+}
+
+// childSchemas returns the subschemas that validate the child of a value with the
+// given name, or index for arrays, including the subschema of contains.
+func childSchemas(node schemaNode, schema map[string]any, name string) []schemaNode {
+	var children []schemaNode
+	described := false
+	if properties, ok := schema["properties"].(map[string]any); ok {
+		if subschema, found := properties[name]; found {
+			children = append(children, node.child(subschema, "properties", name))
+			described = true
+		}
+	}
+	if patterns, ok := schema["patternProperties"].(map[string]any); ok {
+		for _, pattern := range sortedKeys(patterns) {
+			re, err := regexp.Compile(pattern)
+			if err != nil || !re.MatchString(name) {
+				continue
+			}
+			children = append(children, node.child(patterns[pattern], "patternProperties", pattern))
+			described = true
+		}
+	}
+	if additional, ok := schema["additionalProperties"].(map[string]any); ok && !described {
+		children = append(children, node.child(additional, "additionalProperties"))
+	}
+
+	index, err := strconv.Atoi(name)
+	if err != nil {
+		return children
+	}
+	switch items := schema["items"].(type) {
+	case map[string]any:
+		children = append(children, node.child(items, "items"))
+	case []any:
+		if index < len(items) {
+			children = append(children, node.child(items[index], "items", name))
+		} else if additional, ok := schema["additionalItems"].(map[string]any); ok {
+			children = append(children, node.child(additional, "additionalItems"))
+		}
+	}
+	if contains, ok := schema["contains"].(map[string]any); ok {
+		children = append(children, node.child(contains, "contains"))
+	}
+	return children
+}
+
+// resolve returns the subschema referenced by ref from the given node.
+func (l *schemaLocator) resolve(node schemaNode, ref string) (schemaNode, bool) {
+	file, pointer, _ := strings.Cut(ref, "#")
+	if file == "" {
+		file = node.file
+	} else {
+		file = path.Join(path.Dir(node.file), file)
+	}
+	schema, err := l.file(file)
+	if err != nil {
+		return schemaNode{}, false
+	}
+
+	var current any = schema
+	pointer = strings.TrimSuffix(pointer, "/")
+	if pointer != "" {
+		for _, segment := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+			segment = unescapePointer(segment)
+			switch value := current.(type) {
+			case map[string]any:
+				current = value[segment]
+			case []any:
+				i, err := strconv.Atoi(segment)
+				if err != nil || i < 0 || i >= len(value) {
+					return schemaNode{}, false
+				}
+				current = value[i]
+			default:
+				return schemaNode{}, false
+			}
+		}
+	}
+	if current == nil {
+		return schemaNode{}, false
+	}
+	return schemaNode{file: file, pointer: pointer, schema: current}, true
+}
+
+// file returns the schema in the given spec file, resolved for the version.
+func (l *schemaLocator) file(name string) (map[string]any, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if schema, found := l.files[name]; found {
+		if schema == nil {
+			return nil, fmt.Errorf("schema file %q could not be loaded", name)
+		}
+		return schema, nil
+	}
+	schema, err := loadSchemaFile(l.fsys, name, l.version)
+	l.files[name] = schema
+	return schema, err
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func escapePointer(segment string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(segment)
+}
+
+func unescapePointer(segment string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+}
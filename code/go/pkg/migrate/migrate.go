@@ -0,0 +1,210 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Package migrate upgrades packages to newer versions of the spec. Migrations
+// are transformations of packages registered for the version of the spec that
+// requires them, they are applied in order to the packages that are migrated to
+// that version or later.
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v3"
+
+	packagespec "github.com/elastic/package-spec/v3"
+)
+
+// Migration is a transformation of packages required to use a version of the spec.
+type Migration struct {
+	// Name identifies the migration in the results.
+	Name string
+	// Description explains what the migration changes.
+	Description string
+	// Version is the version of the spec that requires the migration. It is
+	// applied to packages with a lower format_version, when they are migrated to
+	// this version or later.
+	Version *semver.Version
+	// Types are the package types the migration applies to, all types if nil.
+	Types []string
+	// Unversioned marks migrations of changes that the spec requires in all its
+	// versions. They are applied to all the migrated packages, regardless of
+	// their format_version and the target version, Version is only used to
+	// order them.
+	Unversioned bool
+	// Migrate changes the package. Changes that cannot be done automatically
+	// must be reported with Package.Report, returned errors abort the migration
+	// of the package.
+	Migrate func(pkg *Package) error
+}
+
+// Migrations returns the migrations included in this module, in the order they
+// are applied.
+func Migrations() []Migration {
+	return slices.Clone(builtinMigrations)
+}
+
+// Change is a change done in a package by a migration.
+type Change struct {
+	// Migration is the name of the migration that did the change.
+	Migration string `json:"migration"`
+	// File is the package-relative path of the file changed.
+	File string `json:"file"`
+	// Description is a human-readable summary of the change.
+	Description string `json:"description"`
+}
+
+// Issue is something a migration could not migrate, that needs to be done manually.
+type Issue struct {
+	// Migration is the name of the migration that found the issue.
+	Migration string `json:"migration"`
+	// File is the package-relative path of the file with the issue, if any.
+	File string `json:"file,omitempty"`
+	// Code is the code of the validation error, for issues found when
+	// validating the migrated package.
+	Code string `json:"code,omitempty"`
+	// Message describes what needs to be done.
+	Message string `json:"message"`
+}
+
+// Result is the result of the migration of a package.
+type Result struct {
+	// From is the format_version of the package before the migration.
+	From *semver.Version `json:"from"`
+	// To is the format_version of the package after the migration.
+	To *semver.Version `json:"to"`
+	// Applied contains the names of the migrations applied, in order.
+	Applied []string `json:"applied"`
+	// Changes contains the changes done in the package.
+	Changes []Change `json:"changes"`
+	// Issues contains what could not be migrated.
+	Issues []Issue `json:"issues"`
+}
+
+// Option configures a Migrator.
+type Option func(*Migrator)
+
+// WithMigrations adds custom migrations. They are applied along with the
+// migrations of this module, ordered by version. Migrations for the same version
+// are applied in the order they are added, after the ones of this module.
+func WithMigrations(migrations ...Migration) Option {
+	return func(m *Migrator) { m.migrations = append(m.migrations, migrations...) }
+}
+
+// WithDryRun controls whether the migrated files are written. When enabled is
+// true, the results are returned but the package is not changed.
+func WithDryRun(enabled bool) Option {
+	return func(m *Migrator) { m.dryRun = enabled }
+}
+
+// Migrator migrates packages to newer versions of the spec.
+type Migrator struct {
+	migrations []Migration
+	dryRun     bool
+}
+
+// New creates a Migrator with the given options.
+func New(opts ...Option) (*Migrator, error) {
+	m := &Migrator{
+		migrations: Migrations(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	for _, migration := range m.migrations {
+		if migration.Name == "" || migration.Version == nil || migration.Migrate == nil {
+			return nil, fmt.Errorf("invalid migration %q: name, version and migrate function are required", migration.Name)
+		}
+	}
+	slices.SortStableFunc(m.migrations, func(a, b Migration) int {
+		return a.Version.Compare(b.Version)
+	})
+	return m, nil
+}
+
+// Migrate migrates the package in the given directory to the given version of
+// the spec. The migrations required between the format_version of the package and
+// the target version are applied in order, and format_version is updated. The
+// migrated package is then validated against the target version, the fixes
+// available for the rules enforced by newer versions are applied, and the
+// remaining errors are reported as issues. Files are only written if all the
+// migrations succeed, and even if some issues are reported.
+func (m *Migrator) Migrate(dir string, target semver.Version) (*Result, error) {
+	if _, err := packagespec.CheckVersion(target); err != nil {
+		return nil, fmt.Errorf("could not migrate to version [%s]: %w", target.String(), err)
+	}
+
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("no package found at path [%v]: %w", dir, err)
+	}
+	defer root.Close()
+
+	pkg, manifest, err := newPackage(root, target)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{From: pkg.FormatVersion, To: &target}
+	pkg.result = result
+	if pkg.FormatVersion.GreaterThan(&target) {
+		return nil, fmt.Errorf("package format_version [%s] is newer than [%s]", pkg.FormatVersion, target.String())
+	}
+	if pkg.FormatVersion.Equal(&target) {
+		return result, nil
+	}
+
+	for _, migration := range m.migrations {
+		if !migration.Unversioned && (!migration.Version.GreaterThan(pkg.FormatVersion) || migration.Version.GreaterThan(&target)) {
+			continue
+		}
+		if migration.Types != nil && !slices.Contains(migration.Types, pkg.Type) {
+			continue
+		}
+		pkg.migration = migration.Name
+		if err := migration.Migrate(pkg); err != nil {
+			return nil, fmt.Errorf("migration %q failed: %w", migration.Name, err)
+		}
+		result.Applied = append(result.Applied, migration.Name)
+	}
+
+	pkg.migration = "format_version"
+	if err := pkg.setFormatVersion(manifest); err != nil {
+		return nil, err
+	}
+	if err := pkg.validate(dir); err != nil {
+		return nil, err
+	}
+
+	if m.dryRun {
+		return result, nil
+	}
+	if err := pkg.write(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// manifestFile is the path of the manifest of packages.
+const manifestFile = "manifest.yml"
+
+// setFormatVersion updates the format_version in the manifest of the package.
+func (p *Package) setFormatVersion(manifest *yaml.Node) error {
+	content, err := p.ReadFile(manifestFile)
+	if err != nil {
+		return err
+	}
+	node := mappingValue(manifest, "format_version")
+	if node == nil {
+		return errors.New("format_version not found in manifest")
+	}
+	edit, ok := scalarEdit(content, node, p.TargetVersion.String())
+	if !ok {
+		return errors.New("could not locate format_version in manifest")
+	}
+	return p.Edit(manifestFile, fmt.Sprintf("format_version updated from %s to %s", p.FormatVersion, p.TargetVersion), edit)
+}
@@ -0,0 +1,257 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package migrate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPackagesPath = filepath.Join("..", "..", "..", "..", "test", "packages")
+
+func copyTestPackage(t *testing.T, name string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.CopyFS(dir, os.DirFS(filepath.Join(testPackagesPath, name))))
+	return dir
+}
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestMigrate(t *testing.T) {
+	dir := copyTestPackage(t, "good_v2")
+
+	m, err := New()
+	require.NoError(t, err)
+	result, err := m.Migrate(dir, *semver.MustParse("3.0.0"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.12.0", result.From.String())
+	assert.Equal(t, "3.0.0", result.To.String())
+	assert.Equal(t, []string{"expand-dotted-keys", "profiles-data-stream-type"}, result.Applied)
+	assert.Contains(t, result.Changes, Change{
+		Migration:   "expand-dotted-keys",
+		File:        "manifest.yml",
+		Description: "expanded dotted keys conditions.kibana.version, conditions.elastic.subscription, conditions.elastic.capabilities, elasticsearch.privileges.cluster, the file is reformatted",
+	})
+	assert.Contains(t, result.Changes, Change{
+		Migration:   "format_version",
+		File:        "manifest.yml",
+		Description: "format_version updated from 2.12.0 to 3.0.0",
+	})
+	assert.Equal(t, []Issue{{
+		Migration: "expand-dotted-keys",
+		File:      "data_stream/foo/manifest.yml",
+		Message:   `dotted key "elasticsearch.index_template.mappings" is not expanded since spec 3.0.0, and expanding it doesn't fix the file`,
+	}}, migrationIssues(result, "expand-dotted-keys"))
+	// Errors found when validating the migrated package are reported too.
+	assert.Contains(t, codes(migrationIssues(result, "validation")), "JSE00003")
+
+	manifest, err := os.ReadFile(filepath.Join(dir, "manifest.yml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(manifest), "format_version: 3.0.0\n")
+	assert.Contains(t, string(manifest), "conditions:\n  kibana:\n    version: '^8.10.0'\n  elastic:\n    subscription: 'basic'\n")
+
+	// Migrating again to the same version doesn't change anything.
+	result, err = m.Migrate(dir, *semver.MustParse("3.0.0"))
+	require.NoError(t, err)
+	assert.Empty(t, result.Applied)
+	assert.Empty(t, result.Changes)
+
+	_, err = m.Migrate(dir, *semver.MustParse("2.12.0"))
+	assert.ErrorContains(t, err, "package format_version [3.0.0] is newer than [2.12.0]")
+	_, err = m.Migrate(dir, *semver.MustParse("9999.0.0"))
+	assert.ErrorContains(t, err, "could not migrate to version [9999.0.0]")
+}
+
+func TestMigrateDryRun(t *testing.T) {
+	dir := copyTestPackage(t, "good_v2")
+	original, err := os.ReadFile(filepath.Join(dir, "manifest.yml"))
+	require.NoError(t, err)
+
+	m, err := New(WithDryRun(true))
+	require.NoError(t, err)
+	result, err := m.Migrate(dir, *semver.MustParse("3.0.0"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.Changes)
+
+	manifest, err := os.ReadFile(filepath.Join(dir, "manifest.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(manifest))
+}
+
+func TestMigrateProfilingType(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"manifest.yml":                       "format_version: \"3.6.0\"\nname: profiler\ntype: integration\n",
+		"data_stream/cpu/manifest.yml":       "title: CPU\n# Technical preview.\ntype: 'profiling'\n",
+		"data_stream/memory/manifest.yml":    "title: Memory\ntype: profiling # Technical preview.\n",
+		"data_stream/processes/manifest.yml": "title: Processes\ntype: metrics\n",
+	})
+
+	m, err := New()
+	require.NoError(t, err)
+	result, err := m.Migrate(dir, *semver.MustParse("3.6.4"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"profiles-data-stream-type"}, result.Applied)
+	assert.Empty(t, migrationIssues(result, "profiles-data-stream-type"))
+	assert.Len(t, result.Changes, 3)
+
+	expected := map[string]string{
+		"manifest.yml":                       "format_version: \"3.6.4\"\nname: profiler\ntype: integration\n",
+		"data_stream/cpu/manifest.yml":       "title: CPU\n# Technical preview.\ntype: 'profiles'\n",
+		"data_stream/memory/manifest.yml":    "title: Memory\ntype: profiles # Technical preview.\n",
+		"data_stream/processes/manifest.yml": "title: Processes\ntype: metrics\n",
+	}
+	for name, content := range expected {
+		d, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		require.NoError(t, err)
+		assert.Equal(t, content, string(d), name)
+	}
+}
+
+func TestMigrateProfilingTypeNewerVersion(t *testing.T) {
+	// The profiling type is not accepted by any version of the spec, so it is
+	// also replaced in packages with a format_version newer than 3.6.4.
+	dir := writeFiles(t, map[string]string{
+		"manifest.yml":                 "format_version: 3.6.4\nname: profiler\ntype: integration\n",
+		"data_stream/cpu/manifest.yml": "title: CPU\ntype: profiling\n",
+	})
+
+	m, err := New()
+	require.NoError(t, err)
+	result, err := m.Migrate(dir, *semver.MustParse("3.7.0"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"profiles-data-stream-type"}, result.Applied)
+
+	d, err := os.ReadFile(filepath.Join(dir, "data_stream", "cpu", "manifest.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "title: CPU\ntype: profiles\n", string(d))
+}
+
+func TestMigrateFixes(t *testing.T) {
+	dir := copyTestPackage(t, "bad_pipeline_tags")
+	manifestPath := filepath.Join(dir, "manifest.yml")
+	manifest, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	manifest = bytes.Replace(manifest, []byte("format_version: 3.6.0"), []byte("format_version: 3.5.0"), 1)
+	require.NoError(t, os.WriteFile(manifestPath, manifest, 0o644))
+
+	m, err := New()
+	require.NoError(t, err)
+	result, err := m.Migrate(dir, *semver.MustParse("3.6.0"))
+	require.NoError(t, err)
+
+	const pipeline = "data_stream/example/elasticsearch/ingest_pipeline/default.yml"
+	var fixed []Change
+	for _, change := range result.Changes {
+		if change.Migration == "validation" {
+			fixed = append(fixed, change)
+		}
+	}
+	require.Len(t, fixed, 1)
+	assert.Equal(t, pipeline, fixed[0].File)
+
+	// Errors without fix are reported as issues.
+	issues := migrationIssues(result, "validation")
+	require.Len(t, issues, 1)
+	assert.Equal(t, pipeline, issues[0].File)
+	assert.Equal(t, "SVR00006", issues[0].Code)
+	assert.Contains(t, issues[0].Message, "duplicate tag value")
+}
+
+func TestMigrateInvalidPackage(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"manifest.yml": "format_version: 3.5.0\nname: invalid\ntype: input\n",
+	})
+
+	m, err := New()
+	require.NoError(t, err)
+	result, err := m.Migrate(dir, *semver.MustParse("3.6.0"))
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, "validation", result.Issues[0].Migration)
+	assert.Contains(t, result.Issues[0].Message, "could not validate the migrated package")
+}
+
+func migrationIssues(result *Result, migration string) []Issue {
+	var issues []Issue
+	for _, issue := range result.Issues {
+		if issue.Migration == migration {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func codes(issues []Issue) []string {
+	var codes []string
+	for _, issue := range issues {
+		codes = append(codes, issue.Code)
+	}
+	return codes
+}
+
+func TestWithMigrations(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"manifest.yml": "format_version: 3.5.0\nname: custom\ntype: input\n",
+	})
+
+	var applied []string
+	custom := func(name string, version string) Migration {
+		return Migration{
+			Name:    name,
+			Version: semver.MustParse(version),
+			Migrate: func(pkg *Package) error {
+				applied = append(applied, name)
+				assert.Equal(t, "custom", pkg.Name)
+				assert.Equal(t, "input", pkg.Type)
+				return nil
+			},
+		}
+	}
+	m, err := New(WithMigrations(
+		custom("later", "3.6.0"),
+		custom("older", "3.5.0"),
+		custom("newer", "3.7.0"),
+		custom("first", "3.5.1"),
+		Migration{
+			Name:    "notes",
+			Version: semver.MustParse("3.5.1"),
+			Migrate: func(pkg *Package) error {
+				pkg.Report("", "package needs manual review")
+				return pkg.WriteFile("docs/NOTES.md", []byte("# Notes\n"), "notes added")
+			},
+		},
+	))
+	require.NoError(t, err)
+
+	result, err := m.Migrate(dir, *semver.MustParse("3.6.0"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "later"}, applied)
+	assert.Equal(t, []string{"first", "notes", "later"}, result.Applied)
+	assert.Equal(t, []Issue{{Migration: "notes", Message: "package needs manual review"}}, migrationIssues(result, "notes"))
+
+	notes, err := os.ReadFile(filepath.Join(dir, "docs", "NOTES.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Notes\n", string(notes))
+
+	_, err = New(WithMigrations(Migration{Name: "invalid"}))
+	assert.ErrorContains(t, err, `invalid migration "invalid"`)
+}
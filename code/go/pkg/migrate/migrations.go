@@ -0,0 +1,213 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v3"
+
+	packagespec "github.com/elastic/package-spec/v3"
+	"github.com/elastic/package-spec/v3/code/go/internal/loader"
+	"github.com/elastic/package-spec/v3/code/go/internal/spectypes"
+)
+
+// builtinMigrations are the migrations included in this module, ordered by version.
+var builtinMigrations = []Migration{
+	{
+		Name:        "expand-dotted-keys",
+		Description: "Expand the dotted keys of YAML files, that are not expanded since spec 3.0.0, when their schema requires it.",
+		Version:     semver.MustParse("3.0.0"),
+		Migrate:     expandDottedKeys,
+	},
+	{
+		Name:        "profiles-data-stream-type",
+		Description: "Replace the `profiling` data stream type with `profiles`.",
+		Version:     semver.MustParse("3.6.4"),
+		Types:       []string{"integration"},
+		// The `profiling` type is not accepted by any version of the spec.
+		Unversioned: true,
+		Migrate:     replaceProfilingType,
+	},
+}
+
+// expandDottedKeys expands the dotted keys of the YAML files of the package whose
+// schema is not valid for the target version. Each key is only expanded if this
+// reduces the errors found in the file, as some files, as index settings, can
+// contain keys with dots.
+func expandDottedKeys(pkg *Package) error {
+	rootSpec, err := loader.LoadSpec(packagespec.FS(), *pkg.TargetVersion, pkg.Type)
+	if err != nil {
+		return fmt.Errorf("could not load spec for version [%s]: %w", pkg.TargetVersion, err)
+	}
+
+	files, err := pkg.Files()
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if ext := path.Ext(file); ext != ".yml" && ext != ".yaml" {
+			continue
+		}
+		itemSpec, err := findItemSpec(rootSpec, file, pkg.Name)
+		if err != nil {
+			return err
+		}
+		if itemSpec == nil || itemSpec.ContentMediaType() == nil || itemSpec.ContentMediaType().MediaType != "application/x-yaml" {
+			continue
+		}
+		if err := expandFileDottedKeys(pkg, itemSpec, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func expandFileDottedKeys(pkg *Package, itemSpec spectypes.ItemSpec, file string) error {
+	content, err := pkg.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil || len(dottedKeys(&document, "")) == 0 {
+		// Invalid files are reported by the validator.
+		return nil
+	}
+	errs := itemSpec.ValidateSchema(pkg, file)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	var expanded []string
+	rejected := make(map[string]bool)
+	for {
+		var document yaml.Node
+		if err := yaml.Unmarshal(content, &document); err != nil {
+			return fmt.Errorf("failed to parse file %q: %w", file, err)
+		}
+		var key *dottedKey
+		for _, candidate := range dottedKeys(&document, "") {
+			if !rejected[candidate.path] {
+				key = &candidate
+				break
+			}
+		}
+		if key == nil {
+			break
+		}
+		if !key.expand() {
+			rejected[key.path] = true
+			continue
+		}
+		candidate, err := encodeYAML(&document)
+		if err != nil {
+			return fmt.Errorf("failed to encode file %q: %w", file, err)
+		}
+		candidateErrs := itemSpec.ValidateSchema(&candidateFS{Package: pkg, name: file, content: candidate}, file)
+		if len(candidateErrs) >= len(errs) {
+			rejected[key.path] = true
+			continue
+		}
+		content, errs = candidate, candidateErrs
+		expanded = append(expanded, key.path)
+	}
+
+	if len(expanded) > 0 {
+		description := "expanded dotted keys " + strings.Join(expanded, ", ") + ", the file is reformatted"
+		if err := pkg.WriteFile(file, content, description); err != nil {
+			return err
+		}
+	}
+	if len(errs) > 0 {
+		for _, path := range slices.Sorted(maps.Keys(rejected)) {
+			pkg.Report(file, "dotted key %q is not expanded since spec 3.0.0, and expanding it doesn't fix the file", path)
+		}
+	}
+	return nil
+}
+
+// findItemSpec returns the spec of the item in the given package-relative path, or
+// nil if the spec doesn't define it.
+func findItemSpec(rootSpec spectypes.ItemSpec, filePath string, pkgName string) (spectypes.ItemSpec, error) {
+	itemSpec := rootSpec
+	for _, name := range strings.Split(filePath, "/") {
+		var found spectypes.ItemSpec
+		for _, content := range itemSpec.Contents() {
+			isMatch, err := spectypes.MatchName(content, name, pkgName)
+			if err != nil {
+				return nil, err
+			}
+			if isMatch {
+				found = content
+				break
+			}
+		}
+		if found == nil {
+			return nil, nil
+		}
+		itemSpec = found
+	}
+	return itemSpec, nil
+}
+
+// candidateFS is the filesystem of a package with a candidate content for a file.
+type candidateFS struct {
+	*Package
+	name    string
+	content []byte
+}
+
+func (c *candidateFS) Open(name string) (fs.File, error) {
+	if name == c.name {
+		return newMemFile(name, c.content), nil
+	}
+	return c.Package.Open(name)
+}
+
+func (c *candidateFS) ReadFile(name string) ([]byte, error) {
+	if name == c.name {
+		return c.content, nil
+	}
+	return c.Package.ReadFile(name)
+}
+
+// replaceProfilingType replaces the `profiling` data stream type, renamed to
+// `profiles` in spec 3.6.4. The old type was removed from all the versions of the
+// spec, so it is also replaced in packages with newer format versions.
+func replaceProfilingType(pkg *Package) error {
+	manifests, err := pkg.Glob("data_stream/*/manifest.yml")
+	if err != nil {
+		return err
+	}
+	for _, manifest := range manifests {
+		content, err := pkg.ReadFile(manifest)
+		if err != nil {
+			return err
+		}
+		var document yaml.Node
+		if err := yaml.Unmarshal(content, &document); err != nil || len(document.Content) == 0 {
+			continue
+		}
+		node := mappingValue(document.Content[0], "type")
+		if node == nil || node.Value != "profiling" {
+			continue
+		}
+		edit, ok := scalarEdit(content, node, "profiles")
+		if !ok {
+			pkg.Report(manifest, "data stream type profiling must be replaced with profiles")
+			continue
+		}
+		if err := pkg.Edit(manifest, "data stream type profiling replaced with profiles", edit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package migrate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
+)
+
+// Package is a package being migrated. Its files can be read as a filesystem,
+// that includes the changes done by previous migrations. Changes are kept in
+// memory, and written once all the migrations are applied.
+type Package struct {
+	// Name is the name of the package.
+	Name string
+	// Type is the type of the package, as "integration", "input" or "content".
+	Type string
+	// FormatVersion is the format_version of the package before the migration.
+	FormatVersion *semver.Version
+	// TargetVersion is the version of the spec the package is migrated to.
+	TargetVersion *semver.Version
+
+	root *os.Root
+
+	// files contains the contents of the files changed by the migrations.
+	files   map[string][]byte
+	changed []string
+
+	migration string
+	result    *Result
+}
+
+// Ensure Package implements these interfaces.
+var (
+	_ fs.FS         = new(Package)
+	_ fs.ReadFileFS = new(Package)
+)
+
+func newPackage(root *os.Root, target semver.Version) (*Package, *yaml.Node, error) {
+	content, err := root.ReadFile(manifestFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read package manifest: %w", err)
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse package manifest: %w", err)
+	}
+	if len(document.Content) == 0 {
+		return nil, nil, errors.New("empty package manifest")
+	}
+	manifest := document.Content[0]
+
+	var formatVersion *semver.Version
+	if node := mappingValue(manifest, "format_version"); node != nil {
+		formatVersion, err = semver.NewVersion(node.Value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid format_version %q in package manifest: %w", node.Value, err)
+		}
+	}
+	if formatVersion == nil {
+		return nil, nil, errors.New("format_version not found in package manifest")
+	}
+
+	pkg := &Package{
+		FormatVersion: formatVersion,
+		TargetVersion: &target,
+		root:          root,
+		files:         make(map[string][]byte),
+	}
+	if node := mappingValue(manifest, "name"); node != nil {
+		pkg.Name = node.Value
+	}
+	if node := mappingValue(manifest, "type"); node != nil {
+		pkg.Type = node.Value
+	}
+	return pkg, manifest, nil
+}
+
+// Open opens the named file of the package, with the changes done by previous
+// migrations.
+func (p *Package) Open(name string) (fs.File, error) {
+	if content, found := p.files[name]; found {
+		return newMemFile(name, content), nil
+	}
+	return p.root.FS().Open(name)
+}
+
+// ReadFile reads the named file of the package, with the changes done by previous
+// migrations.
+func (p *Package) ReadFile(name string) ([]byte, error) {
+	if content, found := p.files[name]; found {
+		return bytes.Clone(content), nil
+	}
+	return fs.ReadFile(p.root.FS(), name)
+}
+
+// Glob returns the package-relative paths of the files matching pattern, with the
+// syntax of path.Match. Files created by previous migrations are not included.
+func (p *Package) Glob(pattern string) ([]string, error) {
+	return fs.Glob(p.root.FS(), pattern)
+}
+
+// Files returns the package-relative paths of all the files in the package.
+// Files created by previous migrations are not included.
+func (p *Package) Files() ([]string, error) {
+	var files []string
+	err := fs.WalkDir(p.root.FS(), ".", func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, filePath)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// WriteFile sets the content of the named file of the package, and records the
+// change with the given description.
+func (p *Package) WriteFile(name string, content []byte, description string) error {
+	if !fs.ValidPath(name) || name == "." {
+		return fmt.Errorf("invalid file path %q", name)
+	}
+	if _, found := p.files[name]; !found {
+		p.changed = append(p.changed, name)
+	}
+	p.files[name] = bytes.Clone(content)
+	p.result.Changes = append(p.result.Changes, Change{
+		Migration:   p.migration,
+		File:        name,
+		Description: description,
+	})
+	return nil
+}
+
+// Edit applies the given edits to the named file of the package, and records the
+// change with the given description.
+func (p *Package) Edit(name string, description string, edits ...specerrors.Edit) error {
+	content, err := p.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	content, err = specerrors.ApplyEdits(content, edits...)
+	if err != nil {
+		return fmt.Errorf("failed to edit file %q: %w", name, err)
+	}
+	return p.WriteFile(name, content, description)
+}
+
+// Report records something that could not be migrated in the given file, or in
+// the package if file is empty.
+func (p *Package) Report(file string, format string, args ...any) {
+	p.result.Issues = append(p.result.Issues, Issue{
+		Migration: p.migration,
+		File:      file,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// write writes the files changed by the migrations.
+func (p *Package) write() error {
+	for _, name := range p.changed {
+		perm := fs.FileMode(0o644)
+		if info, err := p.root.Stat(name); err == nil {
+			perm = info.Mode().Perm()
+		} else if err := p.root.MkdirAll(path.Dir(name), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for file %q: %w", name, err)
+		}
+		if err := p.root.WriteFile(name, p.files[name], perm); err != nil {
+			return fmt.Errorf("failed to write file %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// memFile is a file changed by the migrations, whose content is in memory.
+type memFile struct {
+	*bytes.Reader
+	info memFileInfo
+}
+
+func newMemFile(name string, content []byte) *memFile {
+	return &memFile{
+		Reader: bytes.NewReader(content),
+		info:   memFileInfo{name: path.Base(name), size: int64(len(content))},
+	}
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFile) Close() error               { return nil }
+
+type memFileInfo struct {
+	name string
+	size int64
+}
+
+func (i memFileInfo) Name() string       { return i.name }
+func (i memFileInfo) Size() int64        { return i.size }
+func (i memFileInfo) Mode() fs.FileMode  { return 0o644 }
+func (i memFileInfo) ModTime() time.Time { return time.Time{} }
+func (i memFileInfo) IsDir() bool        { return false }
+func (i memFileInfo) Sys() any           { return nil }
@@ -0,0 +1,127 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package migrate
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
+	"github.com/elastic/package-spec/v3/code/go/pkg/validator"
+)
+
+// validationMigration is the name used in the results for the changes and issues
+// found when validating the migrated package.
+const validationMigration = "validation"
+
+// fixableCodes are the codes of the validation errors whose fixes are applied to
+// migrated packages. They are reported by rules enforced since newer versions of
+// the spec.
+var fixableCodes = []string{
+	specerrors.CodePipelineTagRequired,
+	specerrors.CodePipelineOnFailureEventKind,
+	specerrors.CodePipelineOnFailureMessage,
+}
+
+// maxFixRounds is the maximum number of times a package is validated to apply
+// fixes. Fixes that overlap with others are applied in the next round.
+const maxFixRounds = 5
+
+// validate validates the migrated package against the target version, applies
+// the fixes available for the errors found, and reports the remaining errors as
+// issues. Packages that cannot be validated are also reported as issues.
+func (p *Package) validate(location string) error {
+	v, err := validator.New(validator.LegacyMode, validator.WithMinimumSeverity(specerrors.SeverityError))
+	if err != nil {
+		return err
+	}
+	p.migration = validationMigration
+	for round := 1; ; round++ {
+		errs, err := validatePackage(v, location, p)
+		if err != nil {
+			p.Report("", "could not validate the migrated package: %v", err)
+			return nil
+		}
+		applied := false
+		if round < maxFixRounds {
+			applied, err = p.applyFixes(errs)
+			if err != nil {
+				return err
+			}
+		}
+		if !applied {
+			for _, err := range errs {
+				p.reportFinding(err)
+			}
+			return nil
+		}
+	}
+}
+
+func validatePackage(v *validator.Validator, location string, pkg *Package) (specerrors.ValidationErrors, error) {
+	err := v.ValidateFromFS(location, pkg)
+	if err == nil {
+		return nil, nil
+	}
+	var errs specerrors.ValidationErrors
+	if !errors.As(err, &errs) {
+		return nil, err
+	}
+	return errs, nil
+}
+
+// applyFixes applies the fixes of the given errors whose code is in fixableCodes.
+// Fixes that overlap with previous ones are skipped. It returns true if any fix
+// was applied.
+func (p *Package) applyFixes(errs specerrors.ValidationErrors) (bool, error) {
+	applied := false
+	contents := make(map[string][]byte)
+	edits := make(map[string][]specerrors.Edit)
+	for _, err := range errs {
+		if !slices.Contains(fixableCodes, err.Code()) {
+			continue
+		}
+		fixErr, ok := err.(specerrors.ValidationFixError)
+		if !ok || fixErr.Fix() == nil {
+			continue
+		}
+		fix := fixErr.Fix()
+
+		original, found := contents[fix.File]
+		if !found {
+			content, err := p.ReadFile(fix.File)
+			if err != nil {
+				return false, err
+			}
+			original = content
+			contents[fix.File] = content
+		}
+		accepted := append(slices.Clone(edits[fix.File]), fix.Edits...)
+		content, err := specerrors.ApplyEdits(original, accepted...)
+		if err != nil {
+			// Overlapping fixes are found again when validating the package.
+			continue
+		}
+		if err := p.WriteFile(fix.File, content, fix.Description); err != nil {
+			return false, err
+		}
+		edits[fix.File] = accepted
+		applied = true
+	}
+	return applied, nil
+}
+
+// reportFinding reports a validation error of the migrated package as an issue.
+func (p *Package) reportFinding(err specerrors.ValidationError) {
+	issue := Issue{
+		Migration: p.migration,
+		Code:      err.Code(),
+		Message:   err.Error(),
+	}
+	if pathErr, ok := err.(specerrors.ValidationPathError); ok {
+		issue.File = pathErr.File()
+	}
+	p.result.Issues = append(p.result.Issues, issue)
+}
@@ -0,0 +1,202 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
+)
+
+// mappingValue returns the value of the given key in a mapping node, or nil if
+// the node is not a mapping or it doesn't contain the key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalarEdit returns an edit that replaces the value of a scalar node in content,
+// keeping its quoting style. It returns false if the node cannot be located.
+func scalarEdit(content []byte, node *yaml.Node, value string) (specerrors.Edit, bool) {
+	if node.Kind != yaml.ScalarNode {
+		return specerrors.Edit{}, false
+	}
+	start, ok := offset(content, node.Line, node.Column)
+	if !ok {
+		return specerrors.Edit{}, false
+	}
+
+	var quote string
+	switch node.Style {
+	case 0, yaml.TaggedStyle:
+	case yaml.DoubleQuotedStyle:
+		quote = `"`
+	case yaml.SingleQuotedStyle:
+		quote = "'"
+	default:
+		return specerrors.Edit{}, false
+	}
+	if strings.Contains(value, quote) && quote != "" {
+		return specerrors.Edit{}, false
+	}
+
+	original := quote + node.Value + quote
+	if !bytes.HasPrefix(content[start:], []byte(original)) {
+		return specerrors.Edit{}, false
+	}
+	return specerrors.Edit{Start: start, End: start + len(original), Text: quote + value + quote}, true
+}
+
+// offset returns the byte offset of the given 1-based line and column in content.
+// Columns are counted in characters.
+func offset(content []byte, line, column int) (int, bool) {
+	pos := 0
+	for current := 1; current < line; current++ {
+		i := bytes.IndexByte(content[pos:], '\n')
+		if i < 0 {
+			return 0, false
+		}
+		pos += i + 1
+	}
+	for current := 1; current < column; current++ {
+		if pos >= len(content) || content[pos] == '\n' {
+			return 0, false
+		}
+		_, size := utf8.DecodeRune(content[pos:])
+		pos += size
+	}
+	return pos, true
+}
+
+// dottedKey is a key of a mapping that contains dots.
+type dottedKey struct {
+	// path identifies the key in the document, as "conditions.kibana.version".
+	path    string
+	mapping *yaml.Node
+	index   int
+}
+
+// dottedKeys returns the keys with dots in the mappings of the document, in
+// document order.
+func dottedKeys(node *yaml.Node, path string) []dottedKey {
+	var keys []dottedKey
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			keys = append(keys, dottedKeys(child, path)...)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			keys = append(keys, dottedKeys(child, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			keyPath := key.Value
+			if path != "" {
+				keyPath = path + "." + key.Value
+			}
+			if key.Kind == yaml.ScalarNode && strings.Contains(key.Value, ".") {
+				keys = append(keys, dottedKey{path: keyPath, mapping: node, index: i})
+			}
+			keys = append(keys, dottedKeys(node.Content[i+1], keyPath)...)
+		}
+	}
+	return keys
+}
+
+// expand replaces the dotted key with nested mappings, merged with the existing
+// ones. It returns false if the key cannot be expanded because some of its parts
+// conflict with other keys.
+func (k dottedKey) expand() bool {
+	key, value := k.mapping.Content[k.index], k.mapping.Content[k.index+1]
+	k.mapping.Content = append(k.mapping.Content[:k.index:k.index], k.mapping.Content[k.index+2:]...)
+	return insertKey(k.mapping, k.index, key, key.Value, value)
+}
+
+// insertKey inserts the value with the given dotted key in a mapping, at the given
+// position if a new key is added to the mapping. The key node is reused for the
+// last part of the key.
+func insertKey(mapping *yaml.Node, position int, keyNode *yaml.Node, key string, value *yaml.Node) bool {
+	position = min(position, len(mapping.Content))
+	head, rest, dotted := strings.Cut(key, ".")
+	existing := -1
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == head {
+			existing = i
+			break
+		}
+	}
+
+	if !dotted {
+		if existing < 0 {
+			keyNode.Value = key
+			mapping.Content = insertPair(mapping.Content, position, keyNode, value)
+			return true
+		}
+		current := mapping.Content[existing+1]
+		if current.Kind != yaml.MappingNode || value.Kind != yaml.MappingNode {
+			return false
+		}
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			if !insertKey(current, len(current.Content), value.Content[i], value.Content[i].Value, value.Content[i+1]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if existing < 0 {
+		headNode := &yaml.Node{
+			Kind:        yaml.ScalarNode,
+			Tag:         "!!str",
+			Value:       head,
+			HeadComment: keyNode.HeadComment,
+			LineComment: keyNode.LineComment,
+		}
+		keyNode.HeadComment, keyNode.LineComment = "", ""
+		child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		mapping.Content = insertPair(mapping.Content, position, headNode, child)
+		existing = position
+	}
+	child := mapping.Content[existing+1]
+	if child.Kind != yaml.MappingNode {
+		return false
+	}
+	return insertKey(child, len(child.Content), keyNode, rest, value)
+}
+
+func insertPair(content []*yaml.Node, position int, key, value *yaml.Node) []*yaml.Node {
+	result := make([]*yaml.Node, 0, len(content)+2)
+	result = append(result, content[:position]...)
+	result = append(result, key, value)
+	return append(result, content[position:]...)
+}
+
+// encodeYAML encodes a document with the indentation usually found in packages.
+func encodeYAML(document *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(document); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
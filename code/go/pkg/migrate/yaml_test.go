@@ -0,0 +1,99 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
+)
+
+func TestScalarEdit(t *testing.T) {
+	cases := []struct {
+		content  string
+		expected string
+		ok       bool
+	}{
+		{"a: 1\nkey: value\n", "a: 1\nkey: new\n", true},
+		{"key: \"value\"\n", "key: \"new\"\n", true},
+		{"key: 'value' # comment\n", "key: 'new' # comment\n", true},
+		{"ñandú: value\nkey: value\n", "ñandú: value\nkey: new\n", true},
+		{"key: |\n  value\n", "", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.content, func(t *testing.T) {
+			var document yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(c.content), &document))
+			node := mappingValue(document.Content[0], "key")
+			require.NotNil(t, node)
+
+			edit, ok := scalarEdit([]byte(c.content), node, "new")
+			require.Equal(t, c.ok, ok)
+			if !ok {
+				return
+			}
+			result, err := specerrors.ApplyEdits([]byte(c.content), edit)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, string(result))
+		})
+	}
+}
+
+func TestDottedKeyExpand(t *testing.T) {
+	cases := []struct {
+		title    string
+		content  string
+		expected string
+		ok       bool
+	}{
+		{
+			title:    "new key",
+			content:  "first: 1\na.b.c: 2\nlast: 3\n",
+			expected: "first: 1\na:\n  b:\n    c: 2\nlast: 3\n",
+			ok:       true,
+		},
+		{
+			title:    "merged with existing key",
+			content:  "# Comment.\na.c: 2\na:\n  b: 1\n",
+			expected: "a:\n  b: 1\n  # Comment.\n  c: 2\n",
+			ok:       true,
+		},
+		{
+			title:    "merged mappings",
+			content:  "a.b:\n  d: 2\na:\n  b:\n    c: 1\n",
+			expected: "a:\n  b:\n    c: 1\n    d: 2\n",
+			ok:       true,
+		},
+		{
+			title:   "conflict",
+			content: "a.b: 2\na: 1\n",
+			ok:      false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			var document yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(c.content), &document))
+			keys := dottedKeys(&document, "")
+			require.Len(t, keys, 1)
+
+			ok := keys[0].expand()
+			require.Equal(t, c.ok, ok)
+			if !ok {
+				return
+			}
+			result, err := encodeYAML(&document)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, string(result))
+			assert.Empty(t, dottedKeys(&document, ""))
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Package packages loads Elastic packages into typed models. These models are the
+// same ones used by the validator, so they follow the spec of this module.
+//
+// Packages are parsed according to the spec version they declare in their
+// format_version, that must be a version implemented by this module. Syntax only
+// allowed by some versions is only read for them, as the dotted keys in the
+// conditions of the manifest, that are not read since spec 3.0.0. Models don't
+// validate the package, packages must be validated to know if they follow the
+// spec version they declare.
+package packages
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/Masterminds/semver/v3"
+
+	packagespec "github.com/elastic/package-spec/v3"
+	"github.com/elastic/package-spec/v3/code/go/internal/linkedfiles"
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
+)
+
+// Package is a package loaded from a directory, a zip file or a filesystem. Its
+// contents are read and parsed the first time they are requested. It is safe for
+// concurrent use, values returned must be treated as read-only.
+type Package struct {
+	// Name is the name of the package.
+	Name string
+	// Type is the type of the package, as "integration", "input" or "content".
+	Type string
+	// Version is the version of the package.
+	Version *semver.Version
+	// SpecVersion is the version of the spec used by the package, as defined in its format_version.
+	SpecVersion *semver.Version
+
+	pkg    *packages.Package
+	closer io.Closer
+}
+
+// LoadFromPath loads the package at path on disk. Linked files are resolved.
+func LoadFromPath(path string) (*Package, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("no package found at path [%v]: %w", path, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("no package folder found at path [%v]", path)
+	}
+
+	return LoadFromFS(path, linkedfiles.NewFS(path, os.DirFS(path)))
+}
+
+// LoadFromZip loads the package stored in a zip file. The zip file must contain a
+// single directory with the package. Files are read from the zip file when they are
+// requested, so it is kept open until the package is closed with Close.
+func LoadFromZip(zipPath string) (*Package, error) {
+	r, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zip file (%s): %w", zipPath, err)
+	}
+	pkg, err := loadFromZipReader(zipPath, r)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	pkg.closer = r
+	return pkg, nil
+}
+
+func loadFromZipReader(zipPath string, r *zip.ReadCloser) (*Package, error) {
+	dirs, err := fs.ReadDir(r, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read root directory in zip file (%s): %w", zipPath, err)
+	}
+	if len(dirs) != 1 {
+		return nil, fmt.Errorf("a single directory is expected in zip file, %d found", len(dirs))
+	}
+
+	subDir, err := fs.Sub(r, dirs[0].Name())
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadFromFS(zipPath, subDir)
+}
+
+// LoadFromFS loads the package accessible through fsys at location. Location is
+// only used to build paths meaningful for the user. Linked files are read as
+// they are found in fsys. Packages with a format_version not implemented by this
+// module cannot be loaded.
+func LoadFromFS(location string, fsys fs.FS) (*Package, error) {
+	pkg, err := packages.NewPackageFromFS(location, fsys)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := packagespec.CheckVersion(*pkg.SpecVersion); err != nil {
+		return nil, fmt.Errorf("could not load package with format_version [%s]: %w", pkg.SpecVersion, err)
+	}
+
+	return &Package{
+		Name:        pkg.Name,
+		Type:        pkg.Type,
+		Version:     pkg.Version,
+		SpecVersion: pkg.SpecVersion,
+		pkg:         pkg,
+	}, nil
+}
+
+// Close releases the zip file of packages loaded with LoadFromZip, their files
+// cannot be read after closing them. Other packages don't hold any resource, and
+// closing them has no effect.
+func (p *Package) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+	return p.closer.Close()
+}
+
+// Open opens a file of the package. Package files cannot be modified.
+func (p *Package) Open(name string) (fs.File, error) {
+	return p.pkg.Open(name)
+}
+
+// Path returns the path of a file of the package, meaningful for the user.
+func (p *Package) Path(names ...string) string {
+	return p.pkg.Path(names...)
+}
+
+// Manifest returns the manifest of the package.
+func (p *Package) Manifest() (*Manifest, error) {
+	return p.pkg.Model().Manifest()
+}
+
+// DataStreams returns the data streams of the package, sorted by name.
+func (p *Package) DataStreams() ([]*DataStream, error) {
+	return p.pkg.Model().DataStreams()
+}
+
+// FieldsFiles returns the fields files of the package, in data streams, transforms
+// or in the package root.
+func (p *Package) FieldsFiles() ([]*FieldsFile, error) {
+	return p.pkg.Model().FieldsFiles()
+}
+
+// IngestPipelines returns the ingest pipelines of the package, in the package root
+// and in data streams.
+func (p *Package) IngestPipelines() ([]*IngestPipeline, error) {
+	return p.pkg.Model().IngestPipelines()
+}
+
+// KibanaObjects returns the Kibana saved objects of the package.
+func (p *Package) KibanaObjects() ([]*KibanaObject, error) {
+	return p.pkg.Model().KibanaObjects()
+}
+
+// IsParseError returns true if the error was returned when parsing a file of the package.
+func IsParseError(err error) bool {
+	return packages.IsParseError(err)
+}
@@ -0,0 +1,125 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package packages
+
+import (
+	"archive/zip"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPackagePath(name string) string {
+	return filepath.Join("..", "..", "..", "..", "test", "packages", name)
+}
+
+func TestLoadFromPath(t *testing.T) {
+	pkg, err := LoadFromPath(testPackagePath("good_v3"))
+	require.NoError(t, err)
+	assert.Equal(t, "good_v3", pkg.Name)
+	assert.Equal(t, "integration", pkg.Type)
+	assert.Equal(t, "3.6.0", pkg.SpecVersion.String())
+
+	manifest, err := pkg.Manifest()
+	require.NoError(t, err)
+	assert.Equal(t, pkg.Name, manifest.Name)
+
+	dataStreams, err := pkg.DataStreams()
+	require.NoError(t, err)
+	assert.NotEmpty(t, dataStreams)
+
+	// Linked fields files are resolved.
+	fieldsFiles, err := pkg.FieldsFiles()
+	require.NoError(t, err)
+	var linked *FieldsFile
+	for _, f := range fieldsFiles {
+		if f.Path == "data_stream/foo/fields/base-fields.yml.link" {
+			linked = f
+		}
+	}
+	require.NotNil(t, linked)
+	fields, err := linked.Fields()
+	require.NoError(t, err)
+	assert.NotEmpty(t, fields)
+
+	_, err = LoadFromPath(testPackagePath("non_existent"))
+	assert.ErrorContains(t, err, "no package found at path")
+}
+
+func TestLoadFromZip(t *testing.T) {
+	zipPath := writePackageZip(t, testPackagePath("good_input"), "good_input")
+
+	pkg, err := LoadFromZip(zipPath)
+	require.NoError(t, err)
+	defer pkg.Close()
+	assert.Equal(t, "good_input", pkg.Name)
+	assert.Equal(t, "input", pkg.Type)
+	assert.Equal(t, zipPath+"/manifest.yml", pkg.Path("manifest.yml"))
+
+	manifest, err := pkg.Manifest()
+	require.NoError(t, err)
+	require.Len(t, manifest.PolicyTemplates, 1)
+	assert.NotEmpty(t, manifest.PolicyTemplates[0].Input)
+
+	// Files are read from the zip file until the package is closed.
+	require.NoError(t, pkg.Close())
+	_, err = fs.ReadFile(pkg, "manifest.yml")
+	assert.Error(t, err)
+}
+
+func TestLoadFromFS(t *testing.T) {
+	path := testPackagePath("good_input")
+	pkg, err := LoadFromFS(path, os.DirFS(path))
+	require.NoError(t, err)
+
+	fieldsFiles, err := pkg.FieldsFiles()
+	require.NoError(t, err)
+	assert.Len(t, fieldsFiles, 2)
+}
+
+func TestLoadFromFS_unsupportedVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"manifest.yml": &fstest.MapFile{Data: []byte("format_version: 99.0.0\nname: foo\ntype: integration\nversion: 1.0.0\n")},
+	}
+	_, err := LoadFromFS("foo", fsys)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `could not load package with format_version [99.0.0]: spec version "99.0.0" not found`)
+}
+
+func writePackageZip(t *testing.T, pkgDir, rootName string) string {
+	t.Helper()
+
+	zipPath := filepath.Join(t.TempDir(), rootName+".zip")
+	f, err := os.Create(zipPath)
+	require.NoError(t, err)
+
+	zw := zip.NewWriter(f)
+	pkgFS := os.DirFS(pkgDir)
+	err = fs.WalkDir(pkgFS, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		w, err := zw.Create(path.Join(rootName, name))
+		if err != nil {
+			return err
+		}
+		content, err := fs.ReadFile(pkgFS, name)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(content)
+		return err
+	})
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, f.Close())
+	return zipPath
+}
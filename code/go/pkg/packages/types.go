@@ -0,0 +1,65 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package packages
+
+import (
+	"github.com/elastic/package-spec/v3/code/go/internal/packages"
+)
+
+type (
+	// Manifest is the manifest of a package, as defined in its manifest.yml file.
+	Manifest = packages.Manifest
+	// Conditions are the requirements to install a package.
+	Conditions = packages.Conditions
+	// Owner is the owner of a package.
+	Owner = packages.Owner
+	// Image is an icon or a screenshot of a package.
+	Image = packages.Image
+	// Requires contains the packages required by a package.
+	Requires = packages.Requires
+	// PackageRequirement is a reference to a required package.
+	PackageRequirement = packages.PackageRequirement
+	// PolicyTemplate is a policy template of a package.
+	PolicyTemplate = packages.PolicyTemplate
+	// PolicyTemplateInput is an input of a policy template of an integration package.
+	PolicyTemplateInput = packages.PolicyTemplateInput
+	// Var is a variable that can be configured in a package policy.
+	Var = packages.Var
+	// Section groups variables in the configuration forms.
+	Section = packages.Section
+	// Position is a position in a file.
+	Position = packages.Position
+
+	// DataStream is a data stream of an integration package.
+	DataStream = packages.DataStream
+	// DataStreamManifest is the manifest of a data stream.
+	DataStreamManifest = packages.DataStreamManifest
+	// Stream is a stream of a data stream.
+	Stream = packages.Stream
+
+	// FieldsFile is a file with field definitions.
+	FieldsFile = packages.FieldsFile
+	// Field is a field definition.
+	Field = packages.Field
+	// RuntimeField is the runtime setting of a field.
+	RuntimeField = packages.RuntimeField
+
+	// IngestPipeline is an ingest pipeline definition.
+	IngestPipeline = packages.IngestPipeline
+	// IngestPipelineDefinition is the content of an ingest pipeline.
+	IngestPipelineDefinition = packages.IngestPipelineDefinition
+	// Processor is a processor of an ingest pipeline.
+	Processor = packages.Processor
+
+	// KibanaObject is a file with a Kibana saved object.
+	KibanaObject = packages.KibanaObject
+	// KibanaObjectDefinition is the content of a Kibana saved object.
+	KibanaObjectDefinition = packages.KibanaObjectDefinition
+	// KibanaReference is a reference from a Kibana saved object to another one.
+	KibanaReference = packages.KibanaReference
+
+	// ParseError is returned when a file of the package can be read, but not parsed.
+	ParseError = packages.ParseError
+)
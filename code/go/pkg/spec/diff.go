@@ -0,0 +1,491 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package spec
+
+import (
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	packagespec "github.com/elastic/package-spec/v3"
+	"github.com/elastic/package-spec/v3/code/go/internal/spectypes"
+	"github.com/elastic/package-spec/v3/code/go/internal/yamlschema"
+)
+
+// ChangeKind is the kind of a difference between two versions of the spec.
+type ChangeKind string
+
+// Kinds of changes between versions of the spec.
+const (
+	// ChangeAdded is used for files, folders and properties added.
+	ChangeAdded ChangeKind = "added"
+
+	// ChangeRemoved is used for files, folders and properties removed.
+	ChangeRemoved ChangeKind = "removed"
+
+	// ChangeRequired is used for files, folders and properties that become required.
+	ChangeRequired ChangeKind = "required"
+
+	// ChangeOptional is used for files, folders and properties that are not
+	// required anymore.
+	ChangeOptional ChangeKind = "optional"
+
+	// ChangeEnum is used for changes in the allowed values of properties.
+	ChangeEnum ChangeKind = "enum"
+
+	// ChangeLimit is used for changes in the limits of files, folders and properties.
+	ChangeLimit ChangeKind = "limit"
+
+	// ChangeRelease is used for changes in the release level of files and folders.
+	ChangeRelease ChangeKind = "release"
+)
+
+// Change is a structural difference between two versions of the spec.
+type Change struct {
+	// Kind is the kind of change.
+	Kind ChangeKind `json:"kind"`
+
+	// Path is a glob pattern for the paths of the files or folders affected by
+	// the change, relative to the root of the package.
+	Path string `json:"path"`
+
+	// Property is the property of the files affected by the change, as
+	// "policy_templates[].inputs[].type". It is empty for changes of the files
+	// or folders themselves.
+	Property string `json:"property,omitempty"`
+
+	// Message describes the change.
+	Message string `json:"message"`
+}
+
+// String returns a description of the change with its location.
+func (c Change) String() string {
+	location := c.Path
+	if c.Property != "" {
+		location += ": " + c.Property
+	}
+	return fmt.Sprintf("%s: %s (%s)", location, c.Message, c.Kind)
+}
+
+// Diff returns the structural differences between the specifications of the
+// packages of the given type in two versions of the spec, with their patches
+// applied. It reports the files and folders added or removed, and the changes in
+// their requirement, release level and limits. For files with schema, it also
+// reports the properties added or removed, and the changes in the properties
+// required, in their allowed values and in their limits.
+func Diff(pkgType string, from, to semver.Version) ([]Change, error) {
+	fromSpec, err := Load(from, pkgType)
+	if err != nil {
+		return nil, err
+	}
+	toSpec, err := Load(to, pkgType)
+	if err != nil {
+		return nil, err
+	}
+
+	d := differ{
+		from:    fromSpec,
+		to:      toSpec,
+		schemas: make(map[schemaKey]map[string]any),
+		seen:    make(map[Change]bool),
+	}
+	if err := d.diff(); err != nil {
+		return nil, err
+	}
+	return d.changes, nil
+}
+
+type differ struct {
+	from, to *Spec
+
+	// schemas contains the bundled schemas already loaded.
+	schemas map[schemaKey]map[string]any
+
+	changes []Change
+	seen    map[Change]bool
+}
+
+type schemaKey struct {
+	path    string
+	version string
+}
+
+func (d *differ) add(change Change) {
+	if d.seen[change] {
+		return
+	}
+	d.seen[change] = true
+	d.changes = append(d.changes, change)
+}
+
+func (d *differ) diff() error {
+	fromItems := itemsByKey(d.from)
+
+	err := d.to.Walk(func(item *Item) error {
+		key := itemKey(item)
+		previous, found := fromItems[key]
+		if !found {
+			d.add(Change{Kind: ChangeAdded, Path: item.Path(), Message: item.Type() + " added"})
+			return fs.SkipDir
+		}
+		return d.diffItem(previous, item)
+	})
+	if err != nil {
+		return err
+	}
+
+	toItems := itemsByKey(d.to)
+	return d.from.Walk(func(item *Item) error {
+		if _, found := toItems[itemKey(item)]; !found {
+			d.add(Change{Kind: ChangeRemoved, Path: item.Path(), Message: item.Type() + " removed"})
+			return fs.SkipDir
+		}
+		return nil
+	})
+}
+
+func (d *differ) diffItem(from, to *Item) error {
+	itemPath := to.Path()
+	switch {
+	case !from.Required() && to.Required():
+		d.add(Change{Kind: ChangeRequired, Path: itemPath, Message: to.Type() + " is now required"})
+	case from.Required() && !to.Required():
+		d.add(Change{Kind: ChangeOptional, Path: itemPath, Message: to.Type() + " is not required anymore"})
+	}
+	if from.Release() != to.Release() {
+		d.add(Change{Kind: ChangeRelease, Path: itemPath, Message: fmt.Sprintf("release changed from %s to %s", from.Release(), to.Release())})
+	}
+
+	fromLimits, toLimits := from.Limits(), to.Limits()
+	for _, limit := range []struct {
+		name     string
+		from, to string
+	}{
+		{"totalContentsLimit", countLimit(fromLimits.TotalContents), countLimit(toLimits.TotalContents)},
+		{"totalSizeLimit", sizeLimit(fromLimits.TotalSize), sizeLimit(toLimits.TotalSize)},
+		{"sizeLimit", sizeLimit(fromLimits.FileSize), sizeLimit(toLimits.FileSize)},
+		{"configurationSizeLimit", sizeLimit(fromLimits.ConfigurationSize), sizeLimit(toLimits.ConfigurationSize)},
+		{"relativePathSizeLimit", sizeLimit(fromLimits.RelativePathSize), sizeLimit(toLimits.RelativePathSize)},
+		{"fieldsPerDataStreamLimit", countLimit(fromLimits.FieldsPerDataStream), countLimit(toLimits.FieldsPerDataStream)},
+	} {
+		if limit.from != limit.to {
+			d.add(Change{Kind: ChangeLimit, Path: itemPath, Message: fmt.Sprintf("%s changed from %s to %s", limit.name, limit.from, limit.to)})
+		}
+	}
+
+	if from.SchemaPath() == "" || to.SchemaPath() == "" {
+		return nil
+	}
+	fromSchema, err := d.schema(from.SchemaPath(), d.from.Version())
+	if err != nil {
+		return err
+	}
+	toSchema, err := d.schema(to.SchemaPath(), d.to.Version())
+	if err != nil {
+		return err
+	}
+	sd := schemaDiffer{
+		differ:   d,
+		path:     itemPath,
+		fromRoot: fromSchema,
+		toRoot:   toSchema,
+		visiting: make(map[[2]string]bool),
+	}
+	sd.compare("", fromSchema, toSchema, false)
+	return nil
+}
+
+func (d *differ) schema(schemaPath string, version semver.Version) (map[string]any, error) {
+	key := schemaKey{path: schemaPath, version: version.String()}
+	if schema, found := d.schemas[key]; found {
+		return schema, nil
+	}
+	schema, err := yamlschema.BundleSchema(packagespec.FS(), schemaPath, version)
+	if err != nil {
+		return nil, fmt.Errorf("could not load schema %q for version [%s]: %w", schemaPath, version.String(), err)
+	}
+	d.schemas[key] = schema
+	return schema, nil
+}
+
+// itemsByKey returns the items of a spec indexed by their keys.
+func itemsByKey(spec *Spec) map[string]*Item {
+	items := make(map[string]*Item)
+	spec.Walk(func(item *Item) error {
+		items[itemKey(item)] = item
+		return nil
+	})
+	return items
+}
+
+// itemKey returns a key that identifies an item in any version of the spec, made
+// of the names or patterns of the item and its parents.
+func itemKey(item *Item) string {
+	if item.Parent() == nil {
+		return ""
+	}
+	name := item.Name()
+	if name == "" {
+		name = item.Pattern()
+	}
+	return itemKey(item.Parent()) + "/" + name
+}
+
+func countLimit(limit int) string {
+	if limit == 0 {
+		return "none"
+	}
+	return strconv.Itoa(limit)
+}
+
+func sizeLimit(limit uint64) string {
+	if limit == 0 {
+		return "none"
+	}
+	return spectypes.FileSize(limit).String()
+}
+
+// schemaLimitKeywords are the keywords of JSON Schema that limit values.
+var schemaLimitKeywords = []string{
+	"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum",
+	"minLength", "maxLength", "minItems", "maxItems",
+	"minProperties", "maxProperties",
+}
+
+// schemaDiffer compares the bundled schemas of a file in two versions of the spec.
+type schemaDiffer struct {
+	*differ
+
+	path             string
+	fromRoot, toRoot map[string]any
+
+	// visiting contains the pairs of references being compared, to stop on
+	// recursive schemas.
+	visiting map[[2]string]bool
+}
+
+// compare compares the schemas of a property. Conditional schemas are the ones
+// that only apply in some cases, as the subschemas of if-then-else or anyOf.
+func (d *schemaDiffer) compare(property string, from, to map[string]any, conditional bool) {
+	fromRef, _ := from["$ref"].(string)
+	toRef, _ := to["$ref"].(string)
+	if fromRef != "" || toRef != "" {
+		pair := [2]string{fromRef, toRef}
+		if d.visiting[pair] {
+			return
+		}
+		d.visiting[pair] = true
+		defer delete(d.visiting, pair)
+
+		from = resolveRef(d.fromRoot, from)
+		to = resolveRef(d.toRoot, to)
+	}
+
+	d.compareRequired(property, from, to, conditional)
+	d.compareEnum(property, from, to)
+	for _, keyword := range schemaLimitKeywords {
+		fromLimit, toLimit := limitValue(from[keyword]), limitValue(to[keyword])
+		if fromLimit != toLimit {
+			d.add(Change{Kind: ChangeLimit, Path: d.path, Property: property, Message: fmt.Sprintf("%s changed from %s to %s", keyword, fromLimit, toLimit)})
+		}
+	}
+
+	fromProperties, _ := from["properties"].(map[string]any)
+	toProperties, _ := to["properties"].(map[string]any)
+	for _, name := range sortedKeys(fromProperties, toProperties) {
+		fromProperty, inFrom := fromProperties[name].(map[string]any)
+		toProperty, inTo := toProperties[name].(map[string]any)
+		switch {
+		case inFrom && inTo:
+			d.compare(joinProperty(property, name), fromProperty, toProperty, conditional)
+		case inTo:
+			d.add(Change{Kind: ChangeAdded, Path: d.path, Property: joinProperty(property, name), Message: "property added"})
+		case inFrom:
+			d.add(Change{Kind: ChangeRemoved, Path: d.path, Property: joinProperty(property, name), Message: "property removed"})
+		}
+	}
+
+	fromPatterns, _ := from["patternProperties"].(map[string]any)
+	toPatterns, _ := to["patternProperties"].(map[string]any)
+	for _, pattern := range sortedKeys(fromPatterns, toPatterns) {
+		fromProperty, inFrom := fromPatterns[pattern].(map[string]any)
+		toProperty, inTo := toPatterns[pattern].(map[string]any)
+		if inFrom && inTo {
+			d.compare(joinProperty(property, "*"), fromProperty, toProperty, conditional)
+		}
+	}
+
+	if fromItems, toItems, ok := subschemas(from, to, "items"); ok {
+		d.compare(property+"[]", fromItems, toItems, conditional)
+	}
+	if fromAdditional, toAdditional, ok := subschemas(from, to, "additionalProperties"); ok {
+		d.compare(joinProperty(property, "*"), fromAdditional, toAdditional, conditional)
+	}
+	for _, keyword := range []string{"then", "else"} {
+		// Conditions added or removed can change the required properties.
+		fromCondition, _ := from[keyword].(map[string]any)
+		toCondition, _ := to[keyword].(map[string]any)
+		if fromCondition != nil || toCondition != nil {
+			d.compare(property, fromCondition, toCondition, true)
+		}
+	}
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		fromList, _ := from[keyword].([]any)
+		toList, _ := to[keyword].([]any)
+		for i := 0; i < len(fromList) && i < len(toList); i++ {
+			fromSchema, fromOk := fromList[i].(map[string]any)
+			toSchema, toOk := toList[i].(map[string]any)
+			if fromOk && toOk {
+				d.compare(property, fromSchema, toSchema, conditional || keyword != "allOf")
+			}
+		}
+	}
+}
+
+func (d *schemaDiffer) compareRequired(property string, from, to map[string]any, conditional bool) {
+	fromRequired := stringSet(from["required"])
+	toRequired := stringSet(to["required"])
+	suffix := ""
+	if conditional {
+		suffix = " in some cases"
+	}
+	for _, name := range sortedKeys(fromRequired, toRequired) {
+		_, inFrom := fromRequired[name]
+		_, inTo := toRequired[name]
+		switch {
+		case inTo && !inFrom:
+			d.add(Change{Kind: ChangeRequired, Path: d.path, Property: joinProperty(property, name), Message: "property is now required" + suffix})
+		case inFrom && !inTo:
+			d.add(Change{Kind: ChangeOptional, Path: d.path, Property: joinProperty(property, name), Message: "property is not required anymore" + suffix})
+		}
+	}
+}
+
+func (d *schemaDiffer) compareEnum(property string, from, to map[string]any) {
+	fromEnum, inFrom := from["enum"].([]any)
+	toEnum, inTo := to["enum"].([]any)
+	switch {
+	case !inFrom && !inTo:
+		return
+	case !inFrom:
+		d.add(Change{Kind: ChangeEnum, Path: d.path, Property: property, Message: "values restricted to " + formatValues(toEnum)})
+		return
+	case !inTo:
+		d.add(Change{Kind: ChangeEnum, Path: d.path, Property: property, Message: "values not restricted anymore"})
+		return
+	}
+
+	var added, removed []any
+	for _, value := range toEnum {
+		if !containsValue(fromEnum, value) {
+			added = append(added, value)
+		}
+	}
+	for _, value := range fromEnum {
+		if !containsValue(toEnum, value) {
+			removed = append(removed, value)
+		}
+	}
+	var messages []string
+	if len(added) > 0 {
+		messages = append(messages, "values added: "+formatValues(added))
+	}
+	if len(removed) > 0 {
+		messages = append(messages, "values removed: "+formatValues(removed))
+	}
+	if len(messages) > 0 {
+		d.add(Change{Kind: ChangeEnum, Path: d.path, Property: property, Message: strings.Join(messages, ", ")})
+	}
+}
+
+// resolveRef returns the schema referenced by a schema with a local reference, or
+// the schema itself if it has no reference or it cannot be resolved.
+func resolveRef(root map[string]any, schema map[string]any) map[string]any {
+	ref, _ := schema["$ref"].(string)
+	pointer, found := strings.CutPrefix(ref, "#")
+	if !found {
+		return schema
+	}
+
+	var current any = root
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		object, ok := current.(map[string]any)
+		if !ok {
+			return schema
+		}
+		current = object[token]
+	}
+	resolved, ok := current.(map[string]any)
+	if !ok {
+		return schema
+	}
+	return resolved
+}
+
+func subschemas(from, to map[string]any, keyword string) (map[string]any, map[string]any, bool) {
+	fromSchema, fromOk := from[keyword].(map[string]any)
+	toSchema, toOk := to[keyword].(map[string]any)
+	return fromSchema, toSchema, fromOk && toOk
+}
+
+func joinProperty(property, name string) string {
+	if property == "" {
+		return name
+	}
+	return property + "." + name
+}
+
+func limitValue(value any) string {
+	if value == nil {
+		return "none"
+	}
+	return fmt.Sprint(value)
+}
+
+func stringSet(value any) map[string]any {
+	list, _ := value.([]any)
+	set := make(map[string]any, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			set[s] = nil
+		}
+	}
+	return set
+}
+
+func sortedKeys(maps ...map[string]any) []string {
+	var keys []string
+	for _, m := range maps {
+		for key := range m {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func containsValue(values []any, value any) bool {
+	return slices.ContainsFunc(values, func(v any) bool {
+		return fmt.Sprint(v) == fmt.Sprint(value)
+	})
+}
+
+func formatValues(values []any) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = fmt.Sprintf("%q", fmt.Sprint(value))
+	}
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
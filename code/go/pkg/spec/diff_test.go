@@ -0,0 +1,117 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDiff(t *testing.T) {
+	changes, err := Diff("integration", *semver.MustParse("3.0.0"), *semver.MustParse("3.6.0"))
+	require.NoError(t, err)
+	assert.Contains(t, changes, Change{Kind: ChangeAdded, Path: "kibana/slo_template", Message: "folder added"})
+	assert.Contains(t, changes, Change{Kind: ChangeAdded, Path: "manifest.yml", Property: "requires", Message: "property added"})
+	assert.Contains(t, changes, Change{Kind: ChangeEnum, Path: "changelog.yml", Property: "[].changes[].type", Message: `values added: ["deprecation"]`})
+	assert.Contains(t, changes, Change{Kind: ChangeOptional, Path: "data_stream/*/manifest.yml", Property: "streams[].input", Message: "property is not required anymore"})
+
+	changes, err = Diff("integration", *semver.MustParse("3.6.0"), *semver.MustParse("3.0.0"))
+	require.NoError(t, err)
+	assert.Contains(t, changes, Change{Kind: ChangeRemoved, Path: "kibana/slo_template", Message: "folder removed"})
+	assert.Contains(t, changes, Change{Kind: ChangeRequired, Path: "data_stream/*/manifest.yml", Property: "streams[].input", Message: "property is now required"})
+
+	changes, err = Diff("input", *semver.MustParse("3.6.0"), *semver.MustParse("3.6.0"))
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	_, err = Diff("integration", *semver.MustParse("3.0.0"), *semver.MustParse("9999.0.0"))
+	assert.ErrorContains(t, err, "could not load specification for version [9999.0.0]")
+}
+
+func TestSchemaDiffer(t *testing.T) {
+	from := parseSchema(t, `
+definitions:
+  field:
+    type: object
+    properties:
+      name:
+        type: string
+        maxLength: 10
+      fields:
+        type: array
+        items:
+          $ref: "#/definitions/field"
+properties:
+  type:
+    enum: [logs, metrics]
+  fields:
+    type: array
+    items:
+      $ref: "#/definitions/field"
+  owner:
+    type: string
+required: [type]
+`)
+	to := parseSchema(t, `
+definitions:
+  field:
+    type: object
+    properties:
+      name:
+        type: string
+        maxLength: 20
+      fields:
+        type: array
+        items:
+          $ref: "#/definitions/field"
+    required: [name]
+properties:
+  type:
+    enum: [logs, metrics, traces]
+  fields:
+    type: array
+    items:
+      $ref: "#/definitions/field"
+  elasticsearch:
+    type: object
+if:
+  properties:
+    type:
+      const: traces
+then:
+  required: [elasticsearch]
+required: [type]
+`)
+
+	d := differ{seen: make(map[Change]bool)}
+	sd := schemaDiffer{
+		differ:   &d,
+		path:     "manifest.yml",
+		fromRoot: from,
+		toRoot:   to,
+		visiting: make(map[[2]string]bool),
+	}
+	sd.compare("", from, to, false)
+
+	assert.Equal(t, []Change{
+		{Kind: ChangeAdded, Path: "manifest.yml", Property: "elasticsearch", Message: "property added"},
+		{Kind: ChangeRequired, Path: "manifest.yml", Property: "fields[].name", Message: "property is now required"},
+		{Kind: ChangeLimit, Path: "manifest.yml", Property: "fields[].name", Message: "maxLength changed from 10 to 20"},
+		{Kind: ChangeRemoved, Path: "manifest.yml", Property: "owner", Message: "property removed"},
+		{Kind: ChangeEnum, Path: "manifest.yml", Property: "type", Message: `values added: ["traces"]`},
+		{Kind: ChangeRequired, Path: "manifest.yml", Property: "elasticsearch", Message: "property is now required in some cases"},
+	}, d.changes)
+}
+
+func parseSchema(t *testing.T, doc string) map[string]any {
+	t.Helper()
+	var schema map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(doc), &schema))
+	return schema
+}
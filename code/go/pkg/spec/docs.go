@@ -0,0 +1,791 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/Masterminds/semver/v3"
+
+	packagespec "github.com/elastic/package-spec/v3"
+	"github.com/elastic/package-spec/v3/code/go/internal/yamlschema"
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
+)
+
+// DocsFormat is a format of the documentation generated from the spec.
+type DocsFormat string
+
+// Formats of the documentation generated from the spec.
+const (
+	DocsFormatMarkdown DocsFormat = "markdown"
+	DocsFormatHTML     DocsFormat = "html"
+)
+
+// Extension returns the extension of the documentation files in the format.
+func (f DocsFormat) Extension() string {
+	if f == DocsFormatHTML {
+		return ".html"
+	}
+	return ".md"
+}
+
+func (f DocsFormat) check() error {
+	if f != DocsFormatMarkdown && f != DocsFormatHTML {
+		return fmt.Errorf("unknown documentation format %q", f)
+	}
+	return nil
+}
+
+const (
+	// validationCodesDoc is the name of the catalogue of validation codes,
+	// without extension.
+	validationCodesDoc = "validations"
+
+	// referenceDocsDir is the directory of the reference pages of each type of
+	// package.
+	referenceDocsDir = "reference"
+
+	// generatedDocNotice is included at the beginning of generated pages.
+	generatedDocNotice = "<!-- This file is generated from the package spec with `package-spec docs`, do not edit it. -->\n"
+
+	releasesURL = "https://github.com/elastic/package-spec/releases/tag/v"
+)
+
+// PackageTypes returns the types of packages defined by the spec.
+func PackageTypes() []string {
+	return []string{"integration", "input", "content"}
+}
+
+// Docs returns the reference documentation generated from the given version of
+// the spec, indexed by the paths of its pages relative to the documentation
+// directory. It includes a page for each type of package, as
+// "reference/integration.md", and the catalogue of validation codes, as
+// "validations.md".
+func Docs(version semver.Version, format DocsFormat) (map[string][]byte, error) {
+	if err := format.check(); err != nil {
+		return nil, err
+	}
+	docs := make(map[string][]byte)
+	for _, pkgType := range PackageTypes() {
+		d, err := ReferenceDoc(version, pkgType, format)
+		if err != nil {
+			return nil, err
+		}
+		docs[path.Join(referenceDocsDir, pkgType+format.Extension())] = d
+	}
+	d, err := ValidationCodesDoc(format)
+	if err != nil {
+		return nil, err
+	}
+	docs[validationCodesDoc+format.Extension()] = d
+	return docs, nil
+}
+
+// StaleDocs compares the documentation in the given directory with the one
+// generated from the given version of the spec, and returns the paths of the
+// pages that are missing or outdated.
+func StaleDocs(dir string, version semver.Version, format DocsFormat) ([]string, error) {
+	docs, err := Docs(version, format)
+	if err != nil {
+		return nil, err
+	}
+	var stale []string
+	for _, name := range slices.Sorted(maps.Keys(docs)) {
+		current, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if errors.Is(err, fs.ErrNotExist) {
+			stale = append(stale, name)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(current, docs[name]) {
+			stale = append(stale, name)
+		}
+	}
+	return stale, nil
+}
+
+// ReferenceDoc returns the reference page of the files and folders of packages of
+// the given type, as defined by the given version of the spec. It includes the
+// properties of the files with a schema, with their descriptions, allowed values,
+// defaults and examples.
+func ReferenceDoc(version semver.Version, pkgType string, format DocsFormat) ([]byte, error) {
+	if err := format.check(); err != nil {
+		return nil, err
+	}
+	page, err := newReferencePage(version, pkgType)
+	if err != nil {
+		return nil, err
+	}
+	if format == DocsFormatHTML {
+		return renderHTML("reference", page)
+	}
+	return page.markdown(), nil
+}
+
+// ValidationCodesDoc returns the catalogue of validation codes, with the
+// description of each code, grouped by their prefixes.
+func ValidationCodesDoc(format DocsFormat) ([]byte, error) {
+	if err := format.check(); err != nil {
+		return nil, err
+	}
+	page := newValidationCodesPage()
+	if format == DocsFormatHTML {
+		return renderHTML("validations", page)
+	}
+	return page.markdown(), nil
+}
+
+type referencePage struct {
+	Title   string
+	Type    string
+	Version string
+	Limits  []string
+	Items   []referenceItem
+	Formats []*referenceFormat
+}
+
+type referenceItem struct {
+	Path        string
+	Type        string
+	Required    bool
+	Release     string
+	Description string
+	Format      *referenceFormat
+}
+
+// referenceFormat is the format of the files that follow a schema.
+type referenceFormat struct {
+	Heading     string
+	Anchor      string
+	SpecPath    string
+	Files       []string
+	Description string
+	Properties  []*referenceProperty
+}
+
+type referenceProperty struct {
+	Name        string
+	Type        string
+	Required    bool
+	Description string
+}
+
+func newReferencePage(version semver.Version, pkgType string) (*referencePage, error) {
+	spec, err := Load(version, pkgType)
+	if err != nil {
+		return nil, err
+	}
+	page := referencePage{
+		Title:   strings.ToUpper(pkgType[:1]) + pkgType[1:] + " packages",
+		Type:    pkgType,
+		Version: version.String(),
+		Limits:  limitNotes(spec.Root()),
+	}
+
+	anchors := make(map[string]int)
+	formats := make(map[string]*referenceFormat)
+	err = spec.Walk(func(item *Item) error {
+		if item.Parent() == nil {
+			return nil
+		}
+		entry := referenceItem{
+			Path:        item.Path(),
+			Type:        item.Type(),
+			Required:    item.Required(),
+			Release:     item.Release(),
+			Description: itemDescription(item),
+		}
+		if item.IsDir() {
+			entry.Path += "/"
+		}
+		if specPath := item.SchemaPath(); specPath != "" && !item.IsDir() {
+			format, found := formats[specPath]
+			if !found {
+				format, err = newReferenceFormat(specPath, item.Path(), version)
+				if err != nil {
+					return err
+				}
+				format.Anchor = uniqueAnchor(anchors, format.Heading)
+				formats[specPath] = format
+				page.Formats = append(page.Formats, format)
+			}
+			if !slices.Contains(format.Files, item.Path()) {
+				format.Files = append(format.Files, item.Path())
+			}
+			entry.Format = format
+		}
+		page.Items = append(page.Items, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// itemDescription returns the description of an item, followed by notes about
+// how it can be used.
+func itemDescription(item *Item) string {
+	notes := []string{sentence(item.Description())}
+	switch item.ValidationMode() {
+	case ValidationModeSource:
+		notes = append(notes, "Only allowed in source packages.")
+	case ValidationModeBuild:
+		notes = append(notes, "Only allowed in built packages.")
+	}
+	if item.DevelopmentFolder() && !item.Parent().DevelopmentFolder() {
+		notes = append(notes, "Development folder, not included in built packages.")
+	}
+	if item.IsDir() && item.AdditionalContents() {
+		notes = append(notes, "It can contain files and folders not defined in the spec.")
+	}
+	if item.AllowLink() {
+		notes = append(notes, "It can be a link file, with the `.link` suffix.")
+	}
+	if mediaType := item.ContentMediaType(); mediaType != "" {
+		notes = append(notes, "Media type: `"+mediaType+"`.")
+	}
+	notes = append(notes, limitNotes(item)...)
+	return joinNotes(notes)
+}
+
+// limitNotes describes the limits of an item that are different to the limits
+// of its parent. Files are only described by their size limit.
+func limitNotes(item *Item) []string {
+	limits := item.Limits()
+	var parent Limits
+	if item.Parent() != nil {
+		parent = item.Parent().Limits()
+	}
+	if !item.IsDir() {
+		if limits.FileSize > 0 && limits.FileSize != parent.FileSize {
+			return []string{"Size limit: " + sizeLimit(limits.FileSize) + "."}
+		}
+		return nil
+	}
+
+	var notes []string
+	if limits.TotalContents > 0 && limits.TotalContents != parent.TotalContents {
+		notes = append(notes, "Maximum number of files and folders: "+countLimit(limits.TotalContents)+".")
+	}
+	if limits.TotalSize > 0 && limits.TotalSize != parent.TotalSize {
+		notes = append(notes, "Total size limit: "+sizeLimit(limits.TotalSize)+".")
+	}
+	if limits.FileSize > 0 && limits.FileSize != parent.FileSize {
+		notes = append(notes, "Size limit of each file: "+sizeLimit(limits.FileSize)+".")
+	}
+	if limits.ConfigurationSize > 0 && limits.ConfigurationSize != parent.ConfigurationSize {
+		notes = append(notes, "Size limit of configuration files: "+sizeLimit(limits.ConfigurationSize)+".")
+	}
+	if limits.RelativePathSize > 0 && limits.RelativePathSize != parent.RelativePathSize {
+		notes = append(notes, "Size limit of files referenced with relative paths: "+sizeLimit(limits.RelativePathSize)+".")
+	}
+	if limits.FieldsPerDataStream > 0 && limits.FieldsPerDataStream != parent.FieldsPerDataStream {
+		notes = append(notes, "Maximum number of fields per data stream: "+countLimit(limits.FieldsPerDataStream)+".")
+	}
+	return notes
+}
+
+func newReferenceFormat(specPath string, heading string, version semver.Version) (*referenceFormat, error) {
+	schema, err := yamlschema.BundleSchema(packagespec.FS(), specPath, version)
+	if err != nil {
+		return nil, fmt.Errorf("could not load schema %q for version [%s]: %w", specPath, version.String(), err)
+	}
+	description, _ := schema["description"].(string)
+	format := referenceFormat{
+		Heading:     heading,
+		SpecPath:    specPath,
+		Description: sentence(description),
+	}
+	c := propertyCollector{
+		root:     schema,
+		index:    make(map[string]*referenceProperty),
+		visiting: make(map[string]bool),
+	}
+	c.collect("", schema, false)
+	format.Properties = c.properties
+	return &format, nil
+}
+
+// propertyCollector collects the properties defined by a bundled schema.
+type propertyCollector struct {
+	root       map[string]any
+	properties []*referenceProperty
+	index      map[string]*referenceProperty
+
+	// visiting contains the references being collected, to stop on recursive
+	// schemas.
+	visiting map[string]bool
+}
+
+// collect collects the properties of a schema. Properties of conditional
+// schemas, as the subschemas of if-then-else or anyOf, are never required.
+func (c *propertyCollector) collect(property string, schema map[string]any, conditional bool) {
+	if ref, _ := schema["$ref"].(string); ref != "" {
+		if c.visiting[ref] {
+			return
+		}
+		c.visiting[ref] = true
+		defer delete(c.visiting, ref)
+		schema = resolveRef(c.root, schema)
+	}
+
+	required := stringSet(schema["required"])
+	properties, _ := schema["properties"].(map[string]any)
+	for _, name := range sortedKeys(properties) {
+		subschema, ok := properties[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		_, isRequired := required[name]
+		c.add(joinProperty(property, name), subschema, isRequired && !conditional)
+	}
+	patterns, _ := schema["patternProperties"].(map[string]any)
+	for _, pattern := range sortedKeys(patterns) {
+		if subschema, ok := patterns[pattern].(map[string]any); ok {
+			c.add(joinProperty(property, "*"), subschema, false)
+		}
+	}
+	if additional, ok := schema["additionalProperties"].(map[string]any); ok {
+		c.add(joinProperty(property, "*"), additional, false)
+	}
+	if items, ok := schema["items"].(map[string]any); ok {
+		resolved := resolveRef(c.root, items)
+		if _, hasProperties := resolved["properties"]; !hasProperties && (items["description"] != nil || resolved["enum"] != nil) {
+			c.add(property+"[]", items, false)
+		} else {
+			c.collect(property+"[]", items, conditional)
+		}
+	}
+	for _, keyword := range []string{"then", "else"} {
+		if subschema, ok := schema[keyword].(map[string]any); ok {
+			c.collect(property, subschema, true)
+		}
+	}
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		list, _ := schema[keyword].([]any)
+		for _, item := range list {
+			if subschema, ok := item.(map[string]any); ok {
+				c.collect(property, subschema, conditional || keyword != "allOf")
+			}
+		}
+	}
+}
+
+// add adds a property, or completes it if it was already added from another
+// subschema, and collects its own properties.
+func (c *propertyCollector) add(name string, schema map[string]any, required bool) {
+	resolved := resolveRef(c.root, schema)
+	description, _ := schema["description"].(string)
+	if description == "" {
+		description, _ = resolved["description"].(string)
+	}
+
+	p, found := c.index[name]
+	if !found {
+		p = &referenceProperty{Name: name}
+		c.index[name] = p
+		c.properties = append(c.properties, p)
+	}
+	p.Required = p.Required || required
+	if p.Type == "" {
+		p.Type = schemaType(c.root, resolved)
+	}
+	if p.Description == "" {
+		p.Description = schemaDescription(description, resolved)
+	}
+	c.collect(name, schema, false)
+}
+
+// schemaType returns the type of the values of a schema.
+func schemaType(root map[string]any, schema map[string]any) string {
+	var types []string
+	switch t := schema["type"].(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, item := range t {
+			types = append(types, fmt.Sprint(item))
+		}
+	}
+	if slices.Equal(types, []string{"array"}) {
+		if items, ok := schema["items"].(map[string]any); ok {
+			if itemsType := schemaType(root, resolveRef(root, items)); itemsType != "" {
+				return "array of " + itemsType
+			}
+		}
+	}
+	return strings.Join(types, " or ")
+}
+
+// schemaDescription returns the description of a property, followed by its
+// allowed values, default and examples.
+func schemaDescription(description string, schema map[string]any) string {
+	notes := []string{sentence(description)}
+	if value, found := schema["const"]; found {
+		notes = append(notes, "Value: "+docValue(value)+".")
+	}
+	if values, ok := schema["enum"].([]any); ok {
+		notes = append(notes, "Allowed values: "+docValues(values)+".")
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		notes = append(notes, "Pattern: "+docValue(pattern)+".")
+	}
+	if value, found := schema["default"]; found {
+		notes = append(notes, "Default: "+docValue(value)+".")
+	}
+	if values, ok := schema["examples"].([]any); ok && len(values) > 0 {
+		notes = append(notes, "Examples: "+docValues(values)+".")
+	}
+	return joinNotes(notes)
+}
+
+func docValues(values []any) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = docValue(value)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// docValue formats a value as inline code.
+func docValue(value any) string {
+	s, ok := value.(string)
+	if !ok {
+		d, err := json.Marshal(value)
+		if err != nil {
+			s = fmt.Sprint(value)
+		} else {
+			s = string(d)
+		}
+	}
+	s = strings.Join(strings.Fields(s), " ")
+	if strings.Contains(s, "`") {
+		return "`` " + s + " ``"
+	}
+	return "`" + s + "`"
+}
+
+// sentence returns a text in a single line, finished with a period.
+func sentence(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" || strings.ContainsAny(text[len(text)-1:], ".?!:") {
+		return text
+	}
+	return text + "."
+}
+
+func joinNotes(notes []string) string {
+	return strings.Join(slices.DeleteFunc(notes, func(note string) bool { return note == "" }), " ")
+}
+
+// uniqueAnchor returns the anchor of a heading, as generated by GitHub, with a
+// suffix if another heading has the same anchor.
+func uniqueAnchor(anchors map[string]int, heading string) string {
+	var anchor strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r == ' ':
+			anchor.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			anchor.WriteRune(r)
+		}
+	}
+	name := anchor.String()
+	count := anchors[name]
+	anchors[name] = count + 1
+	if count > 0 {
+		return fmt.Sprintf("%s-%d", name, count)
+	}
+	return name
+}
+
+func (p *referencePage) markdown() []byte {
+	var b strings.Builder
+	b.WriteString(generatedDocNotice)
+	fmt.Fprintf(&b, "\n# %s\n\n", p.Title)
+	fmt.Fprintf(&b, "Files and folders of %s packages, as defined by version %s of the package specification.\n", p.Type, p.Version)
+	if len(p.Limits) > 0 {
+		b.WriteString("\n## Limits\n\n")
+		for _, limit := range p.Limits {
+			fmt.Fprintf(&b, "- %s\n", limit)
+		}
+	}
+
+	b.WriteString("\n## Files and folders\n\n")
+	b.WriteString("| Path | Type | Required | Release | Description |\n")
+	b.WriteString("|------|------|----------|---------|-------------|\n")
+	for _, item := range p.Items {
+		description := item.Description
+		if item.Format != nil {
+			description = joinNotes([]string{description, fmt.Sprintf("Format: [%s](#%s).", docValue(item.Format.Heading), item.Format.Anchor)})
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", markdownCell(docValue(item.Path)), item.Type, yesNo(item.Required), item.Release, markdownCell(description))
+	}
+
+	if len(p.Formats) > 0 {
+		b.WriteString("\n## File formats\n")
+	}
+	for _, format := range p.Formats {
+		fmt.Fprintf(&b, "\n### %s\n\n", docValue(format.Heading))
+		fmt.Fprintf(&b, "Defined in `%s`, for the files %s.\n", format.SpecPath, docValues(stringsToAny(format.Files)))
+		if format.Description != "" {
+			fmt.Fprintf(&b, "\n%s\n", format.Description)
+		}
+		if len(format.Properties) == 0 {
+			continue
+		}
+		b.WriteString("\n| Property | Type | Required | Description |\n")
+		b.WriteString("|----------|------|----------|-------------|\n")
+		for _, property := range format.Properties {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", markdownCell(docValue(property.Name)), property.Type, yesNo(property.Required), markdownCell(property.Description))
+		}
+	}
+	return []byte(b.String())
+}
+
+type validationCodesPage struct {
+	Groups []validationCodeGroup
+}
+
+type validationCodeGroup struct {
+	Prefix string
+	Name   string
+	Codes  []specerrors.CodeInfo
+}
+
+// validationCodeGroups contains the names of the groups of validation codes, by
+// their prefixes.
+var validationCodeGroups = map[string]string{
+	"JSE": "JSON Schema Errors",
+	"PSR": "Package Spec Rule",
+	"SVR": "Semantic Validation Rules",
+}
+
+func newValidationCodesPage() *validationCodesPage {
+	codes := specerrors.Codes()
+	slices.SortFunc(codes, func(a, b specerrors.CodeInfo) int { return strings.Compare(a.Code, b.Code) })
+
+	var page validationCodesPage
+	for _, info := range codes {
+		prefix := strings.TrimRightFunc(info.Code, unicode.IsDigit)
+		if len(page.Groups) == 0 || page.Groups[len(page.Groups)-1].Prefix != prefix {
+			name, found := validationCodeGroups[prefix]
+			if !found {
+				name = prefix
+			}
+			page.Groups = append(page.Groups, validationCodeGroup{Prefix: prefix, Name: name})
+		}
+		group := &page.Groups[len(page.Groups)-1]
+		group.Codes = append(group.Codes, info)
+	}
+	return &page
+}
+
+func (p *validationCodesPage) markdown() []byte {
+	var rows [][2]string
+	for _, group := range p.Groups {
+		rows = append(rows, [2]string{
+			fmt.Sprintf("**[%s][%s]**", group.Prefix, group.Codes[0].Code),
+			fmt.Sprintf("**%s**", group.Name),
+		})
+		for _, info := range group.Codes {
+			rows = append(rows, [2]string{"[" + info.Code + "]", info.Title})
+		}
+	}
+	header := [2]string{"Validation", "Short description"}
+	widths := [2]int{len(header[0]), len(header[1])}
+	for _, row := range rows {
+		widths[0] = max(widths[0], len(row[0]))
+		widths[1] = max(widths[1], len(row[1]))
+	}
+
+	var b strings.Builder
+	b.WriteString(generatedDocNotice)
+	b.WriteString("\n# Validation Codes\n\n")
+	fmt.Fprintf(&b, "| %-*s | %-*s |\n", widths[0], header[0], widths[1], header[1])
+	fmt.Fprintf(&b, "|%s|%s|\n", strings.Repeat("-", widths[0]+2), strings.Repeat("-", widths[1]+2))
+	for _, row := range rows {
+		fmt.Fprintf(&b, "| %-*s | %-*s |\n", widths[0], row[0], widths[1], markdownCell(row[1]))
+	}
+
+	for _, group := range p.Groups {
+		for _, info := range group.Codes {
+			fmt.Fprintf(&b, "\n## %s - %s\n", info.Code, info.Title)
+			fmt.Fprintf(&b, "[%s]: %s\n\n", info.Code, info.DocAnchor())
+			if info.Since != "" {
+				fmt.Fprintf(&b, "**Available since [%s](%s%s)**\n", info.Since, releasesURL, info.Since)
+			}
+			if info.Description != "" {
+				if info.Since != "" {
+					b.WriteString("\n")
+				}
+				fmt.Fprintf(&b, "%s\n", info.Description)
+			}
+			for _, example := range info.Examples {
+				fmt.Fprintf(&b, "\n```yaml\n%s\n```\n", strings.TrimSuffix(example, "\n"))
+			}
+		}
+	}
+	return []byte(b.String())
+}
+
+// markdownCell escapes a text with inline code to be used in a table cell.
+func markdownCell(text string) string {
+	parts := strings.Split(text, "`")
+	for i, part := range parts {
+		if i%2 == 0 {
+			part = strings.ReplaceAll(part, "<", "&lt;")
+		}
+		parts[i] = strings.ReplaceAll(part, "|", `\|`)
+	}
+	return strings.Join(parts, "`")
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+func stringsToAny(values []string) []any {
+	result := make([]any, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+	return result
+}
+
+// inlineHTML converts a text with inline code to HTML.
+func inlineHTML(text string) template.HTML {
+	parts := strings.Split(text, "`")
+	var b strings.Builder
+	for i, part := range parts {
+		switch {
+		case i%2 == 0:
+			b.WriteString(template.HTMLEscapeString(part))
+		case i == len(parts)-1:
+			// Unclosed inline code.
+			b.WriteString(template.HTMLEscapeString("`" + part))
+		default:
+			b.WriteString("<code>" + template.HTMLEscapeString(strings.TrimSpace(part)) + "</code>")
+		}
+	}
+	return template.HTML(b.String())
+}
+
+var htmlTemplates = template.Must(template.New("docs").Funcs(template.FuncMap{
+	"inline":      inlineHTML,
+	"yesNo":       yesNo,
+	"code":        func(s string) template.HTML { return inlineHTML(docValue(s)) },
+	"trimPrefix":  strings.TrimPrefix,
+	"releasesURL": func(version string) string { return releasesURL + version },
+}).Parse(`
+{{- define "head" -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ . }}</title>
+</head>
+<body>
+{{- end -}}
+
+{{- define "reference" -}}
+{{ template "head" .Title }}
+<h1>{{ .Title }}</h1>
+<p>Files and folders of {{ .Type }} packages, as defined by version {{ .Version }} of the package specification.</p>
+{{- if .Limits }}
+<h2>Limits</h2>
+<ul>
+{{- range .Limits }}
+<li>{{ . }}</li>
+{{- end }}
+</ul>
+{{- end }}
+<h2>Files and folders</h2>
+<table>
+<tr><th>Path</th><th>Type</th><th>Required</th><th>Release</th><th>Description</th></tr>
+{{- range .Items }}
+<tr><td>{{ code .Path }}</td><td>{{ .Type }}</td><td>{{ yesNo .Required }}</td><td>{{ .Release }}</td><td>{{ inline .Description }}{{ with .Format }} Format: <a href="#{{ .Anchor }}">{{ code .Heading }}</a>.{{ end }}</td></tr>
+{{- end }}
+</table>
+{{- if .Formats }}
+<h2>File formats</h2>
+{{- end }}
+{{- range .Formats }}
+<h3 id="{{ .Anchor }}">{{ code .Heading }}</h3>
+<p>Defined in <code>{{ .SpecPath }}</code>, for the files {{ range $i, $file := .Files }}{{ if $i }}, {{ end }}{{ code $file }}{{ end }}.</p>
+{{- with .Description }}
+<p>{{ inline . }}</p>
+{{- end }}
+{{- if .Properties }}
+<table>
+<tr><th>Property</th><th>Type</th><th>Required</th><th>Description</th></tr>
+{{- range .Properties }}
+<tr><td>{{ code .Name }}</td><td>{{ .Type }}</td><td>{{ yesNo .Required }}</td><td>{{ inline .Description }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- end }}
+</body>
+</html>
+{{ end -}}
+
+{{- define "validations" -}}
+{{ template "head" "Validation Codes" }}
+<h1>Validation Codes</h1>
+<table>
+<tr><th>Validation</th><th>Short description</th></tr>
+{{- range .Groups }}
+<tr><th><a href="#{{ trimPrefix (index .Codes 0).DocAnchor "#" }}">{{ .Prefix }}</a></th><th>{{ .Name }}</th></tr>
+{{- range .Codes }}
+<tr><td><a href="#{{ trimPrefix .DocAnchor "#" }}">{{ .Code }}</a></td><td>{{ .Title }}</td></tr>
+{{- end }}
+{{- end }}
+</table>
+{{- range .Groups }}
+{{- range .Codes }}
+<h2 id="{{ trimPrefix .DocAnchor "#" }}">{{ .Code }} - {{ .Title }}</h2>
+{{- with .Since }}
+<p><strong>Available since <a href="{{ releasesURL . }}">{{ . }}</a></strong></p>
+{{- end }}
+{{- with .Description }}
+<p>{{ inline . }}</p>
+{{- end }}
+{{- range .Examples }}
+<pre><code class="language-yaml">{{ . }}</code></pre>
+{{- end }}
+{{- end }}
+{{- end }}
+</body>
+</html>
+{{ end -}}
+`))
+
+// renderHTML renders a page with the given HTML template.
+func renderHTML(name string, page any) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(generatedDocNotice)
+	if err := htmlTemplates.ExecuteTemplate(&b, name, page); err != nil {
+		return nil, fmt.Errorf("failed to render %s page: %w", name, err)
+	}
+	return b.Bytes(), nil
+}
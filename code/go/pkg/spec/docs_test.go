@@ -0,0 +1,82 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
+)
+
+func TestReferenceDoc(t *testing.T) {
+	version := *semver.MustParse("3.6.0")
+
+	d, err := ReferenceDoc(version, "integration", DocsFormatMarkdown)
+	require.NoError(t, err)
+	doc := string(d)
+	assert.Contains(t, doc, "# Integration packages\n")
+	assert.Contains(t, doc, "| `manifest.yml` | file | yes | ga | The main package manifest file. Media type: `application/x-yaml`. Size limit: 5MB. Format: [`manifest.yml`](#manifestyml). |\n")
+	assert.Contains(t, doc, "| `data_stream/` | folder | no | ga |")
+	assert.Contains(t, doc, "\n### `manifest.yml`\n\nDefined in `integration/manifest.spec.yml`, for the files `manifest.yml`.\n")
+	assert.Contains(t, doc, "| `format_version` | string | yes | The version of the package specification format used by this package.")
+	assert.Contains(t, doc, "| `conditions.elastic.subscription` | string | no | The subscription required for this package. Allowed values: `basic`, `gold`, `platinum`, `enterprise`. Default: `basic`. Examples: `basic`. |\n")
+
+	d, err = ReferenceDoc(version, "integration", DocsFormatHTML)
+	require.NoError(t, err)
+	doc = string(d)
+	assert.Contains(t, doc, "<h1>Integration packages</h1>")
+	assert.Contains(t, doc, `<h3 id="manifestyml"><code>manifest.yml</code></h3>`)
+	assert.Contains(t, doc, "<tr><td><code>format_version</code></td><td>string</td><td>yes</td>")
+
+	_, err = ReferenceDoc(version, "integration", "pdf")
+	assert.ErrorContains(t, err, `unknown documentation format "pdf"`)
+	_, err = ReferenceDoc(version, "unknown", DocsFormatMarkdown)
+	assert.Error(t, err)
+}
+
+func TestValidationCodesDoc(t *testing.T) {
+	d, err := ValidationCodesDoc(DocsFormatMarkdown)
+	require.NoError(t, err)
+	doc := string(d)
+	for _, info := range specerrors.Codes() {
+		assert.Contains(t, doc, "\n## "+info.Code+" - "+info.Title+"\n["+info.Code+"]: "+info.DocAnchor()+"\n")
+	}
+	assert.Contains(t, doc, "| **[SVR][SVR00001]** | **Semantic Validation Rules**")
+	assert.Contains(t, doc, "```yaml\non_failure:\n  - set:\n      field: event.kind\n      value: pipeline_error\n```\n")
+
+	d, err = ValidationCodesDoc(DocsFormatHTML)
+	require.NoError(t, err)
+	assert.Contains(t, string(d), `<h2 id="svr00010---input-qualifier-is-required">SVR00010 - Input qualifier is required</h2>`)
+}
+
+func TestStaleDocs(t *testing.T) {
+	dir := t.TempDir()
+	version := *semver.MustParse("3.6.0")
+
+	docs, err := Docs(version, DocsFormatMarkdown)
+	require.NoError(t, err)
+	assert.Len(t, docs, 4)
+	for name, content := range docs {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, content, 0o644))
+	}
+
+	stale, err := StaleDocs(dir, version, DocsFormatMarkdown)
+	require.NoError(t, err)
+	assert.Empty(t, stale)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "validations.md"), []byte("# Validation Codes\n"), 0o644))
+	require.NoError(t, os.Remove(filepath.Join(dir, "reference", "content.md")))
+	stale, err = StaleDocs(dir, version, DocsFormatMarkdown)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"reference/content.md", "validations.md"}, stale)
+}
@@ -0,0 +1,166 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	packagespec "github.com/elastic/package-spec/v3"
+	"github.com/elastic/package-spec/v3/code/go/internal/spectypes"
+	"github.com/elastic/package-spec/v3/code/go/internal/yamlschema"
+)
+
+// JSONSchema is a standalone JSON Schema document for a kind of file in packages.
+type JSONSchema struct {
+	// Name identifies the schema, it is derived from the path of its spec file,
+	// as "integration.data_stream.manifest".
+	Name string
+
+	// SpecPath is the path of the spec file that defines the schema, as
+	// "integration/data_stream/manifest.spec.yml".
+	SpecPath string
+
+	// Files contains glob patterns matching the package files that follow the
+	// schema, relative to the root of the package.
+	Files []string
+
+	// Schema is the JSON Schema document, with the schemas it references
+	// included in its definitions.
+	Schema json.RawMessage
+}
+
+// JSONSchemas returns the JSON Schema documents of the files of packages of the
+// given type, as defined by the given version of the spec. The patches of the
+// spec are applied for the version, so the schemas can be used by other tools,
+// as editors, to validate files as the validator does. Checks that are not part
+// of the schemas, as semantic rules or formats specific to packages, are not
+// included.
+func JSONSchemas(version semver.Version, pkgType string) ([]JSONSchema, error) {
+	spec, err := Load(version, pkgType)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas []JSONSchema
+	err = spec.Walk(func(item *Item) error {
+		specPath := item.SchemaPath()
+		if item.IsDir() || specPath == "" {
+			return nil
+		}
+		glob := item.Path()
+		i := slices.IndexFunc(schemas, func(s JSONSchema) bool { return s.SpecPath == specPath })
+		if i >= 0 {
+			if !slices.Contains(schemas[i].Files, glob) {
+				schemas[i].Files = append(schemas[i].Files, glob)
+			}
+			return nil
+		}
+
+		schema, err := yamlschema.BundleSchema(packagespec.FS(), specPath, version)
+		if err != nil {
+			return fmt.Errorf("could not generate schema for %q: %w", specPath, err)
+		}
+		d, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not encode schema for %q: %w", specPath, err)
+		}
+		schemas = append(schemas, JSONSchema{
+			Name:     schemaName(specPath),
+			SpecPath: specPath,
+			Files:    []string{glob},
+			Schema:   d,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schemas, nil
+}
+
+// itemGlob returns a glob pattern for the names of an item. Patterns that cannot
+// be translated match any name.
+func itemGlob(item spectypes.ItemSpec) string {
+	if item.Name() != "" {
+		return item.Name()
+	}
+	glob, ok := patternGlob(item.Pattern())
+	if !ok {
+		return "*"
+	}
+	return glob
+}
+
+// patternGlob translates a regular expression for file names to a glob pattern.
+// It supports literals, any characters, character classes repeated with + or *,
+// and groups of literal alternatives.
+func patternGlob(pattern string) (string, bool) {
+	pattern = strings.TrimPrefix(pattern, "^")
+	pattern = strings.TrimSuffix(pattern, "$")
+	pattern = strings.ReplaceAll(pattern, "{PACKAGE_NAME}", ".+")
+
+	var glob strings.Builder
+	repeated := func(i int) bool {
+		return i < len(pattern) && (pattern[i] == '+' || pattern[i] == '*')
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '\\':
+			if i+1 == len(pattern) || !isPunct(pattern[i+1]) {
+				return "", false
+			}
+			i++
+			glob.WriteByte(pattern[i])
+		case '.', '[':
+			if c == '[' {
+				end := strings.IndexByte(pattern[i:], ']')
+				if end < 0 {
+					return "", false
+				}
+				i += end
+			}
+			if repeated(i + 1) {
+				glob.WriteByte('*')
+				i++
+			} else {
+				glob.WriteByte('?')
+			}
+		case '(':
+			end := strings.IndexByte(pattern[i:], ')')
+			if end < 0 || i+end+1 < len(pattern) && strings.ContainsRune("?+*{", rune(pattern[i+end+1])) {
+				return "", false
+			}
+			alternatives := strings.Split(pattern[i+1:i+end], "|")
+			for _, alternative := range alternatives {
+				if strings.ContainsAny(alternative, `\.[](){}?+*^$`) {
+					return "", false
+				}
+			}
+			glob.WriteString("{" + strings.Join(alternatives, ",") + "}")
+			i += end
+		case '+', '*', '?', '{', '}', ')', ']', '|', '^', '$':
+			return "", false
+		default:
+			glob.WriteByte(c)
+		}
+	}
+	return glob.String(), true
+}
+
+func isPunct(c byte) bool {
+	return strings.IndexByte(`.-_\[](){}?+*^$|/`, c) >= 0
+}
+
+// schemaName returns the name of a schema derived from the path of its spec file.
+func schemaName(specPath string) string {
+	name := strings.TrimSuffix(specPath, ".yml")
+	name = strings.TrimSuffix(name, ".spec")
+	return strings.ReplaceAll(name, "/", ".")
+}
@@ -0,0 +1,132 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package spec
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/elastic/gojsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPatternGlob(t *testing.T) {
+	cases := []struct {
+		pattern  string
+		expected string
+	}{
+		{`^.+\.yml$`, "*.yml"},
+		{`^.*\.(yml|yaml)$`, "*.{yml,yaml}"},
+		{`^test-[a-z0-9-]+\.log-config\.yml$`, "test-*.log-config.yml"},
+		{`^{PACKAGE_NAME}-.+\.json$`, "*-*.json"},
+		{`^.+\.md`, "*.md"},
+		{`^[a-z0-9]\.yml$`, "?.yml"},
+		{`^([a-z0-9]{2}|[a-z0-9][a-z0-9_]+[a-z0-9])$`, ""},
+		{`^sample_event(_[a-z0-9]+)?.json$`, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.pattern, func(t *testing.T) {
+			glob, ok := patternGlob(c.pattern)
+			if c.expected == "" {
+				assert.False(t, ok, glob)
+				return
+			}
+			assert.True(t, ok)
+			assert.Equal(t, c.expected, glob)
+		})
+	}
+}
+
+func TestJSONSchemas(t *testing.T) {
+	externalRef := regexp.MustCompile(`"\$ref": "[^#]`)
+
+	cases := []struct {
+		version string
+		pkgType string
+	}{
+		{"2.0.0", "integration"},
+		{"2.0.0", "input"},
+		{"3.0.0", "integration"},
+		{"3.6.0", "integration"},
+		{"3.6.0", "input"},
+		{"3.6.0", "content"},
+	}
+	for _, c := range cases {
+		t.Run(c.version+"/"+c.pkgType, func(t *testing.T) {
+			schemas, err := JSONSchemas(*semver.MustParse(c.version), c.pkgType)
+			require.NoError(t, err)
+			require.NotEmpty(t, schemas)
+
+			for _, schema := range schemas {
+				assert.NotEmpty(t, schema.Files, schema.Name)
+				assert.NotRegexp(t, externalRef, string(schema.Schema), schema.Name)
+				_, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schema.Schema))
+				assert.NoError(t, err, schema.Name)
+			}
+		})
+	}
+
+	_, err := JSONSchemas(*semver.MustParse("9999.0.0"), "integration")
+	assert.ErrorContains(t, err, "could not load specification for version [9999.0.0]")
+	_, err = JSONSchemas(*semver.MustParse("3.6.0"), "foo")
+	assert.ErrorContains(t, err, `could not load specification for package type "foo"`)
+}
+
+func TestJSONSchemasValidate(t *testing.T) {
+	schemas, err := JSONSchemas(*semver.MustParse("3.6.0"), "integration")
+	require.NoError(t, err)
+
+	find := func(name string) JSONSchema {
+		for _, schema := range schemas {
+			if schema.Name == name {
+				return schema
+			}
+		}
+		t.Fatalf("schema %q not found", name)
+		return JSONSchema{}
+	}
+
+	changelog := find("integration.changelog")
+	assert.Equal(t, "integration/changelog.spec.yml", changelog.SpecPath)
+	assert.Equal(t, []string{"changelog.yml"}, changelog.Files)
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(changelog.Schema))
+	require.NoError(t, err)
+
+	doc := readYAMLAsJSON(t, filepath.Join("..", "..", "..", "..", "test", "packages", "good_v3", "changelog.yml"))
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(doc))
+	require.NoError(t, err)
+	assert.True(t, result.Valid(), "%v", result.Errors())
+
+	manifest := find("integration.manifest")
+	schema, err = gojsonschema.NewSchema(gojsonschema.NewBytesLoader(manifest.Schema))
+	require.NoError(t, err)
+	result, err = schema.Validate(gojsonschema.NewStringLoader(`{"name": "foo", "unknown": true}`))
+	require.NoError(t, err)
+	assert.False(t, result.Valid())
+
+	dataStreamManifest := find("integration.data_stream.manifest")
+	assert.Equal(t, []string{"data_stream/*/manifest.yml"}, dataStreamManifest.Files)
+
+	customAgent := find("integration._dev.deploy.agent.custom-agent")
+	assert.Contains(t, string(customAgent.Schema), `"errorMessage": "hostname is not allowed in custom agent services (JSE00006)"`)
+}
+
+func readYAMLAsJSON(t *testing.T, path string) []byte {
+	t.Helper()
+	d, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var doc any
+	require.NoError(t, yaml.Unmarshal(d, &doc))
+	d, err = json.Marshal(doc)
+	require.NoError(t, err)
+	return d
+}
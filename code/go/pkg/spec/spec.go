@@ -0,0 +1,335 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	packagespec "github.com/elastic/package-spec/v3"
+	"github.com/elastic/package-spec/v3/code/go/internal/specschema"
+	"github.com/elastic/package-spec/v3/code/go/internal/spectypes"
+)
+
+// Release levels of items.
+const (
+	ReleaseGA   = "ga"
+	ReleaseBeta = "beta"
+)
+
+// Validation modes of items.
+const (
+	// ValidationModeSource is the mode of items only valid in source packages.
+	ValidationModeSource = spectypes.ValidationModeSource
+
+	// ValidationModeBuild is the mode of items only valid in built packages.
+	ValidationModeBuild = spectypes.ValidationModeBuild
+)
+
+// Spec is the read-only specification of the files and folders of the packages
+// of a type, as defined by a version of the spec.
+type Spec struct {
+	version semver.Version
+	pkgType string
+	root    *Item
+}
+
+// Load loads the specification of the packages of the given type for the given
+// version of the spec. The patches of the spec are applied for the version.
+func Load(version semver.Version, pkgType string) (*Spec, error) {
+	if _, err := packagespec.CheckVersion(version); err != nil {
+		return nil, fmt.Errorf("could not load specification for version [%s]: %w", version.String(), err)
+	}
+
+	rootSpec, err := specschema.NewFolderSpecLoader(packagespec.FS(), nil, version).Load(pkgType)
+	if err != nil {
+		return nil, fmt.Errorf("could not load specification for package type %q: %w", pkgType, err)
+	}
+
+	return &Spec{
+		version: version,
+		pkgType: pkgType,
+		root:    newItem(rootSpec, nil),
+	}, nil
+}
+
+// Version returns the version of the spec.
+func (s *Spec) Version() semver.Version {
+	return s.version
+}
+
+// Type returns the type of packages the spec is for.
+func (s *Spec) Type() string {
+	return s.pkgType
+}
+
+// Root returns the item of the root folder of the packages.
+func (s *Spec) Root() *Item {
+	return s.root
+}
+
+// WalkFunc is the type of the function called by Walk for each item.
+type WalkFunc func(item *Item) error
+
+// Walk walks the items of the spec in depth-first order, starting with the
+// contents of the root folder, and calls fn for each item. If fn returns
+// fs.SkipDir for a folder, its contents are skipped. Any other error stops the
+// walk and is returned.
+func (s *Spec) Walk(fn WalkFunc) error {
+	err := walk(s.root, fn)
+	if errors.Is(err, fs.SkipDir) {
+		return nil
+	}
+	return err
+}
+
+func walk(dir *Item, fn WalkFunc) error {
+	for _, item := range dir.contents {
+		err := fn(item)
+		if item.IsDir() && errors.Is(err, fs.SkipDir) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if item.IsDir() {
+			if err := walk(item, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Match returns the item that defines the file or folder in the given path,
+// relative to the root of a package. Placeholders of the package name in the
+// patterns of the items match the given package name, or any name if it is
+// empty. It returns nil if the spec doesn't define the path, this can happen
+// with valid paths in folders that allow additional contents.
+func (s *Spec) Match(relPath string, packageName string) (*Item, error) {
+	relPath = path.Clean(relPath)
+	if !fs.ValidPath(relPath) {
+		return nil, fmt.Errorf("invalid path %q", relPath)
+	}
+	if relPath == "." {
+		return s.root, nil
+	}
+
+	packageNamePattern := ".+"
+	if packageName != "" {
+		packageNamePattern = regexp.QuoteMeta(packageName)
+	}
+
+	item := s.root
+	for _, name := range strings.Split(relPath, "/") {
+		if !item.IsDir() {
+			return nil, nil
+		}
+		next, err := item.find(name, packageNamePattern)
+		if err != nil {
+			return nil, fmt.Errorf("could not match path %q: %w", relPath, err)
+		}
+		if next == nil {
+			return nil, nil
+		}
+		item = next
+	}
+	return item, nil
+}
+
+// Item is the read-only specification of a file or folder of packages.
+type Item struct {
+	spec     spectypes.ItemSpec
+	parent   *Item
+	contents []*Item
+}
+
+func newItem(spec spectypes.ItemSpec, parent *Item) *Item {
+	item := &Item{spec: spec, parent: parent}
+	for _, content := range spec.Contents() {
+		item.contents = append(item.contents, newItem(content, item))
+	}
+	return item
+}
+
+// find returns the item in the contents of this folder that defines the file or
+// folder with the given name, following the same rules as the validator.
+func (i *Item) find(name string, packageNamePattern string) (*Item, error) {
+	name, isLink := strings.CutSuffix(name, ".link")
+	for _, item := range i.contents {
+		isMatch, err := spectypes.MatchName(item.spec, name, packageNamePattern)
+		if err != nil {
+			return nil, err
+		}
+		if !isMatch {
+			continue
+		}
+		if isLink && item.Name() != name && !item.AllowLink() {
+			return nil, nil
+		}
+		return item, nil
+	}
+	return nil, nil
+}
+
+// Name returns the name of the item inside its parent, if it has a fixed name.
+func (i *Item) Name() string {
+	return i.spec.Name()
+}
+
+// Pattern returns the regular expression that the names of the item match, if
+// it doesn't have a fixed name. It can contain the placeholder {PACKAGE_NAME}
+// for the name of the package.
+func (i *Item) Pattern() string {
+	return i.spec.Pattern()
+}
+
+// ForbiddenPatterns returns the regular expressions that the names of the item
+// cannot match.
+func (i *Item) ForbiddenPatterns() []string {
+	return i.spec.ForbiddenPatterns()
+}
+
+// Matches returns true if the given name of a file or folder matches the name or
+// pattern of the item, and none of its forbidden patterns. Placeholders of the
+// package name match the given package name, or any name if it is empty.
+func (i *Item) Matches(name string, packageName string) (bool, error) {
+	packageNamePattern := ".+"
+	if packageName != "" {
+		packageNamePattern = regexp.QuoteMeta(packageName)
+	}
+	return spectypes.MatchName(i.spec, name, packageNamePattern)
+}
+
+// Path returns a glob pattern for the paths of the item, relative to the root of
+// the package, as "data_stream/*/manifest.yml". Name patterns that cannot be
+// translated to globs are replaced with "*". The path of the root folder is ".".
+func (i *Item) Path() string {
+	if i.parent == nil {
+		return "."
+	}
+	return path.Join(i.parent.Path(), itemGlob(i.spec))
+}
+
+// Parent returns the folder that contains the item, or nil for the root folder.
+func (i *Item) Parent() *Item {
+	return i.parent
+}
+
+// Contents returns the items that a folder can contain. The returned slice must
+// not be modified.
+func (i *Item) Contents() []*Item {
+	return i.contents
+}
+
+// Description returns the description of the item in the spec.
+func (i *Item) Description() string {
+	return i.spec.Description()
+}
+
+// IsDir returns true if the item is a folder.
+func (i *Item) IsDir() bool {
+	return i.parent == nil || i.spec.IsDir()
+}
+
+// Type returns the type of the item, "file" or "folder".
+func (i *Item) Type() string {
+	if i.parent == nil {
+		return spectypes.ItemTypeFolder
+	}
+	return i.spec.Type()
+}
+
+// Required returns true if the item must be present in packages.
+func (i *Item) Required() bool {
+	return i.spec.Required()
+}
+
+// Release returns the release level of the item, ReleaseGA or ReleaseBeta.
+func (i *Item) Release() string {
+	if i.spec.Release() == "" {
+		return ReleaseGA
+	}
+	return i.spec.Release()
+}
+
+// ValidationMode returns the mode in which the item is valid, ValidationModeSource,
+// ValidationModeBuild, or "" if it is valid in both modes.
+func (i *Item) ValidationMode() string {
+	return i.spec.ValidationMode()
+}
+
+// DevelopmentFolder returns true if the item is inside a development folder.
+func (i *Item) DevelopmentFolder() bool {
+	return i.spec.DevelopmentFolder()
+}
+
+// AdditionalContents returns true if the folder can contain files and folders not
+// defined in the spec.
+func (i *Item) AdditionalContents() bool {
+	return i.spec.AdditionalContents()
+}
+
+// AllowLink returns true if the item can be a link file, with the ".link" suffix.
+func (i *Item) AllowLink() bool {
+	return i.spec.AllowLink()
+}
+
+// ContentMediaType returns the expected media type of a file, with its parameters,
+// or an empty string if any content is allowed.
+func (i *Item) ContentMediaType() string {
+	if i.spec.ContentMediaType() == nil {
+		return ""
+	}
+	return i.spec.ContentMediaType().String()
+}
+
+// SchemaPath returns the path in the spec of the file with the schema of the
+// item, if it has one.
+func (i *Item) SchemaPath() string {
+	return i.spec.SchemaPath()
+}
+
+// Limits returns the limits of the item.
+func (i *Item) Limits() Limits {
+	return Limits{
+		TotalContents:       i.spec.MaxTotalContents(),
+		TotalSize:           uint64(i.spec.MaxTotalSize()),
+		FileSize:            uint64(i.spec.MaxFileSize()),
+		ConfigurationSize:   uint64(i.spec.MaxConfigurationSize()),
+		RelativePathSize:    uint64(i.spec.MaxRelativePathSize()),
+		FieldsPerDataStream: i.spec.MaxFieldsPerDataStream(),
+	}
+}
+
+// Limits contains the limits of an item. Sizes are in bytes, zero values mean
+// that there is no limit.
+type Limits struct {
+	// TotalContents is the maximum number of files and folders inside a folder
+	// and its subfolders.
+	TotalContents int
+
+	// TotalSize is the maximum size of a file, or of all the files inside a folder.
+	TotalSize uint64
+
+	// FileSize is the maximum size of each file.
+	FileSize uint64
+
+	// ConfigurationSize is the maximum size of a configuration file.
+	ConfigurationSize uint64
+
+	// RelativePathSize is the maximum size of a file referenced with a relative path.
+	RelativePathSize uint64
+
+	// FieldsPerDataStream is the maximum number of fields that each data stream
+	// can define.
+	FieldsPerDataStream int
+}
@@ -0,0 +1,148 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package spec
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	spec, err := Load(*semver.MustParse("3.4.0"), "integration")
+	require.NoError(t, err)
+	assert.Equal(t, "3.4.0", spec.Version().String())
+	assert.Equal(t, "integration", spec.Type())
+	assert.Equal(t, ".", spec.Root().Path())
+	assert.True(t, spec.Root().IsDir())
+	assert.Nil(t, spec.Root().Parent())
+
+	_, err = Load(*semver.MustParse("9999.0.0"), "integration")
+	assert.ErrorContains(t, err, "could not load specification for version [9999.0.0]")
+	_, err = Load(*semver.MustParse("3.4.0"), "foo")
+	assert.ErrorContains(t, err, `could not load specification for package type "foo"`)
+}
+
+func TestSpecMatch(t *testing.T) {
+	spec, err := Load(*semver.MustParse("3.4.0"), "integration")
+	require.NoError(t, err)
+
+	cases := []struct {
+		path        string
+		packageName string
+		expected    string
+	}{
+		{"manifest.yml", "", "manifest.yml"},
+		{"./data_stream/logs/manifest.yml", "", "data_stream/*/manifest.yml"},
+		{"data_stream/logs/elasticsearch/ingest_pipeline", "", "data_stream/*/elasticsearch/ingest_pipeline"},
+		{"data_stream/logs/elasticsearch/ingest_pipeline/default.yml", "", "data_stream/*/elasticsearch/ingest_pipeline/*.yml"},
+		{"data_stream/logs/elasticsearch/ingest_pipeline/default.yml.link", "", "data_stream/*/elasticsearch/ingest_pipeline/*.yml"},
+		{"data_stream/logs/elasticsearch/ilm/policy.yml.link", "", ""},
+		{"data_stream/logs/elasticsearch/unknown", "", ""},
+		{"manifest.yml/foo", "", ""},
+		{"kibana/dashboard/foo-overview.json", "", "kibana/dashboard/*-*.json"},
+		{"kibana/dashboard/foo-overview.json", "foo", "kibana/dashboard/*-*.json"},
+		{"kibana/dashboard/foo-overview.json", "bar", ""},
+		{"kibana/dashboard/foo-overview-ecs.json", "foo", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.path+"/"+c.packageName, func(t *testing.T) {
+			item, err := spec.Match(c.path, c.packageName)
+			require.NoError(t, err)
+			if c.expected == "" {
+				assert.Nil(t, item)
+				return
+			}
+			require.NotNil(t, item)
+			assert.Equal(t, c.expected, item.Path())
+		})
+	}
+
+	_, err = spec.Match("../manifest.yml", "")
+	assert.ErrorContains(t, err, `invalid path "../manifest.yml"`)
+}
+
+func TestItem(t *testing.T) {
+	spec, err := Load(*semver.MustParse("3.4.0"), "integration")
+	require.NoError(t, err)
+
+	item, err := spec.Match("data_stream/logs/elasticsearch", "")
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	assert.Equal(t, "elasticsearch", item.Name())
+	assert.Equal(t, "folder", item.Type())
+	assert.False(t, item.AdditionalContents())
+	assert.False(t, item.Required())
+	assert.Equal(t, ReleaseGA, item.Release())
+
+	var names []string
+	for _, content := range item.Contents() {
+		assert.Same(t, item, content.Parent())
+		names = append(names, content.Name())
+	}
+	assert.Equal(t, []string{"ilm", "ingest_pipeline"}, names)
+
+	pipeline, err := spec.Match("data_stream/logs/elasticsearch/ingest_pipeline/default.json", "")
+	require.NoError(t, err)
+	require.NotNil(t, pipeline)
+	assert.Equal(t, `^.+\.json$`, pipeline.Pattern())
+	assert.Equal(t, "Supporting ingest pipeline definitions in JSON", pipeline.Description())
+	assert.Equal(t, "application/json", pipeline.ContentMediaType())
+	assert.Equal(t, "integration/elasticsearch/pipeline.spec.yml", pipeline.SchemaPath())
+	assert.True(t, pipeline.AllowLink())
+	assert.False(t, pipeline.IsDir())
+
+	dev, err := spec.Match("_dev", "")
+	require.NoError(t, err)
+	require.NotNil(t, dev)
+	assert.Equal(t, ValidationModeSource, dev.ValidationMode())
+	assert.True(t, dev.DevelopmentFolder())
+
+	dashboard := spec.Root()
+	for _, name := range []string{"kibana", "dashboard"} {
+		dashboard, err = spec.Match(dashboard.Path()+"/"+name, "")
+		require.NoError(t, err)
+		require.NotNil(t, dashboard)
+	}
+	matches, err := dashboard.Contents()[0].Matches("foo-overview.json", "foo")
+	require.NoError(t, err)
+	assert.True(t, matches)
+	matches, err = dashboard.Contents()[0].Matches("foo-overview-ECS.json", "foo")
+	require.NoError(t, err)
+	assert.False(t, matches)
+
+	assert.NotZero(t, spec.Root().Limits().TotalSize)
+}
+
+func TestSpecWalk(t *testing.T) {
+	spec, err := Load(*semver.MustParse("3.4.0"), "integration")
+	require.NoError(t, err)
+
+	var paths []string
+	err = spec.Walk(func(item *Item) error {
+		paths = append(paths, item.Path())
+		if item.Name() == "data_stream" {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Contains(t, paths, "manifest.yml")
+	assert.Contains(t, paths, "data_stream")
+	assert.Contains(t, paths, "kibana/dashboard/*-*.json")
+	assert.NotContains(t, paths, "data_stream/*/manifest.yml")
+
+	err = spec.Walk(func(item *Item) error {
+		if item.Name() == "changelog.yml" {
+			return fs.ErrNotExist
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
@@ -0,0 +1,195 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package specerrors
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fingerprintLength is the number of hexadecimal characters of fingerprints.
+const fingerprintLength = 16
+
+// Baseline contains the findings of packages at some point in time, so later
+// validations can report only the findings that are not in the baseline.
+type Baseline struct {
+	// Packages contains the findings of each package, by package name.
+	Packages map[string][]BaselineFinding `yaml:"packages"`
+}
+
+// BaselineFinding is a validation error included in a baseline.
+type BaselineFinding struct {
+	// Code is the code of the error.
+	Code string `yaml:"code,omitempty"`
+
+	// File is the package-relative path of the file where the error was found, if known.
+	File string `yaml:"file,omitempty"`
+
+	// Fingerprint identifies the error by its code, file and message. Positions
+	// are not included, and neither is the location of the package.
+	Fingerprint string `yaml:"fingerprint"`
+}
+
+// NewBaseline creates an empty baseline.
+func NewBaseline() *Baseline {
+	return &Baseline{Packages: make(map[string][]BaselineFinding)}
+}
+
+// LoadBaseline reads a baseline file.
+func LoadBaseline(path string) (*Baseline, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadBaseline(f)
+}
+
+// ReadBaseline reads a baseline.
+func ReadBaseline(r io.Reader) (*Baseline, error) {
+	baseline := NewBaseline()
+	err := yaml.NewDecoder(r).Decode(baseline)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid baseline: %w", err)
+	}
+	if baseline.Packages == nil {
+		baseline.Packages = make(map[string][]BaselineFinding)
+	}
+	return baseline, nil
+}
+
+// Write writes the baseline in YAML format.
+func (b *Baseline) Write(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(b); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// Set replaces the findings of a package with the given errors. Location is the
+// location of the package, as given to the validator.
+func (b *Baseline) Set(name, location string, errs ValidationErrors) {
+	if len(errs) == 0 {
+		delete(b.Packages, name)
+		return
+	}
+	findings := make([]BaselineFinding, 0, len(errs))
+	for _, err := range errs {
+		findings = append(findings, newBaselineFinding(location, err))
+	}
+	slices.SortStableFunc(findings, func(a, b BaselineFinding) int {
+		return cmp.Or(
+			strings.Compare(a.File, b.File),
+			strings.Compare(a.Code, b.Code),
+			strings.Compare(a.Fingerprint, b.Fingerprint),
+		)
+	})
+	b.Packages[name] = findings
+}
+
+// Processor returns a processor that filters the errors of a package included
+// in the baseline. Location is the location of the package, as given to the validator.
+func (b *Baseline) Processor(name, location string) *BaselineProcessor {
+	return NewBaselineProcessor(b.Packages[name], location)
+}
+
+// BaselineProcessor is a processor to filter errors included in a baseline.
+type BaselineProcessor struct {
+	findings []BaselineFinding
+	location string
+}
+
+// NewBaselineProcessor creates a new BaselineProcessor for the findings of a package.
+func NewBaselineProcessor(findings []BaselineFinding, location string) *BaselineProcessor {
+	return &BaselineProcessor{
+		findings: findings,
+		location: location,
+	}
+}
+
+// Name returns the name of this BaselineProcessor.
+func (p BaselineProcessor) Name() string {
+	return "baseline"
+}
+
+// Process returns a new list of validation errors, without the ones included
+// in the baseline. Each finding of the baseline filters a single error, so new
+// occurrences of the same error are still reported.
+func (p BaselineProcessor) Process(issues ValidationErrors) (ProcessResult, error) {
+	pending := make(map[BaselineFinding]int)
+	for _, finding := range p.findings {
+		pending[finding]++
+	}
+
+	errs, filtered := issues.Collect(func(i ValidationError) bool {
+		finding := newBaselineFinding(p.location, i)
+		if pending[finding] == 0 {
+			return true
+		}
+		pending[finding]--
+		return false
+	})
+	return ProcessResult{Processed: errs, Removed: filtered}, nil
+}
+
+func newBaselineFinding(location string, err ValidationError) BaselineFinding {
+	finding := BaselineFinding{Code: err.Code()}
+	if pathErr, ok := err.(ValidationPathError); ok {
+		finding.File = pathErr.File()
+	}
+	finding.Fingerprint = Fingerprint(location, err)
+	return finding
+}
+
+// Fingerprint returns an identifier of the error, based on its code, file and
+// message. The location of the package and the position of the error are removed
+// from the message, so the same error found in different copies of a package, or
+// after changes in other lines of the file, has the same fingerprint.
+func Fingerprint(location string, err ValidationError) string {
+	message := err.Error()
+	if code := err.Code(); code != UnassignedCode {
+		message = strings.TrimSuffix(message, fmt.Sprintf(" (%s)", code))
+	}
+	if location = path.Clean(location); location != "." {
+		message = strings.ReplaceAll(message, location+"/", "")
+	}
+	message = removePosition(message, err)
+
+	var file string
+	if pathErr, ok := err.(ValidationPathError); ok {
+		file = pathErr.File()
+	}
+
+	hash := sha256.New()
+	for _, part := range []string{err.Code(), file, message} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:fingerprintLength]
+}
+
+// removePosition removes the position of the error from its message, in the
+// formats used by the messages of the validator, as "file.yml:3:5" or "at line 3".
+func removePosition(message string, err ValidationError) string {
+	posErr, ok := err.(ValidationPositionError)
+	if !ok || posErr.Line() == 0 {
+		return message
+	}
+	message = strings.ReplaceAll(message, fmt.Sprintf(":%d:%d", posErr.Line(), posErr.Column()), "")
+	message = strings.ReplaceAll(message, fmt.Sprintf(" at line %d", posErr.Line()), "")
+	return message
+}
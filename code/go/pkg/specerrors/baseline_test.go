@@ -0,0 +1,135 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package specerrors
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaselineProcessor(t *testing.T) {
+	pipelineErr := func(location string, line int) ValidationError {
+		return NewStructuredError(
+			fmt.Errorf("file \"%s/data_stream/logs/elasticsearch/ingest_pipeline/default.yml\" is invalid: set processor at line %d missing required tag", location, line),
+			CodePipelineTagRequired).
+			WithFile("data_stream/logs/elasticsearch/ingest_pipeline/default.yml").
+			WithPosition(line, 3)
+	}
+	dashboardErr := NewStructuredError(errors.New("dashboard without filter"), CodeKibanaDashboardWithoutFilter).
+		WithFile("kibana/dashboard/foo.json")
+
+	baseline := NewBaseline()
+	baseline.Set("foo", "/packages/foo", ValidationErrors{pipelineErr("/packages/foo", 4)})
+
+	cases := []struct {
+		title    string
+		name     string
+		location string
+		errs     ValidationErrors
+		expected []string
+	}{
+		{
+			title:    "same errors",
+			name:     "foo",
+			location: "/packages/foo",
+			errs:     ValidationErrors{pipelineErr("/packages/foo", 4)},
+		},
+		{
+			title:    "different location and position",
+			name:     "foo",
+			location: "./other/foo/",
+			errs:     ValidationErrors{pipelineErr("other/foo", 10)},
+		},
+		{
+			title:    "new errors",
+			name:     "foo",
+			location: "/packages/foo",
+			errs:     ValidationErrors{pipelineErr("/packages/foo", 4), dashboardErr},
+			expected: []string{"dashboard without filter (SVR00002)"},
+		},
+		{
+			title:    "new occurrences",
+			name:     "foo",
+			location: "/packages/foo",
+			errs:     ValidationErrors{pipelineErr("/packages/foo", 4), pipelineErr("/packages/foo", 8)},
+			expected: []string{pipelineErr("/packages/foo", 8).Error()},
+		},
+		{
+			title:    "other package",
+			name:     "bar",
+			location: "/packages/foo",
+			errs:     ValidationErrors{pipelineErr("/packages/foo", 4)},
+			expected: []string{pipelineErr("/packages/foo", 4).Error()},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			result, err := baseline.Processor(c.name, c.location).Process(c.errs)
+			require.NoError(t, err)
+
+			var processed []string
+			for _, e := range result.Processed {
+				processed = append(processed, e.Error())
+			}
+			assert.Equal(t, c.expected, processed)
+			assert.Len(t, result.Removed, len(c.errs)-len(c.expected))
+		})
+	}
+}
+
+func TestFingerprintPosition(t *testing.T) {
+	durationErr := func(line, column int) ValidationError {
+		return NewStructuredError(
+			fmt.Errorf("/packages/foo/manifest.yml:%d:%d error in variable \"interval\": negative min_duration value \"-5s\"", line, column),
+			CodeDurationVariables).
+			WithFile("manifest.yml").
+			WithPosition(line, column)
+	}
+	pipelineErr := func(line int) ValidationError {
+		return NewStructuredError(
+			fmt.Errorf("file \"/packages/foo/elasticsearch/ingest_pipeline/default.yml\" is invalid: set processor at line %d missing required tag", line),
+			CodePipelineTagRequired).
+			WithFile("elasticsearch/ingest_pipeline/default.yml").
+			WithPosition(line, 5)
+	}
+
+	// Lines added before the error shift its position.
+	assert.Equal(t, Fingerprint("/packages/foo", durationErr(2, 5)), Fingerprint("/packages/foo", durationErr(12, 7)))
+	assert.Equal(t, Fingerprint("/packages/foo", pipelineErr(3)), Fingerprint("/packages/foo", pipelineErr(30)))
+	assert.NotEqual(t, Fingerprint("/packages/foo", durationErr(2, 5)), Fingerprint("/packages/foo", pipelineErr(2)))
+}
+
+func TestBaselineReadWrite(t *testing.T) {
+	baseline := NewBaseline()
+	baseline.Set("foo", "foo", ValidationErrors{
+		NewStructuredError(errors.New("dashboard without filter"), CodeKibanaDashboardWithoutFilter).WithFile("kibana/dashboard/foo.json"),
+		NewStructuredErrorf("error without code"),
+	})
+	baseline.Set("bar", "bar", nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, baseline.Write(&buf))
+
+	read, err := ReadBaseline(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, baseline, read)
+	require.Len(t, read.Packages["foo"], 2)
+	assert.Equal(t, BaselineFinding{
+		Code:        CodeKibanaDashboardWithoutFilter,
+		File:        "kibana/dashboard/foo.json",
+		Fingerprint: read.Packages["foo"][1].Fingerprint,
+	}, read.Packages["foo"][1])
+	assert.Len(t, read.Packages["foo"][1].Fingerprint, fingerprintLength)
+	assert.NotContains(t, read.Packages, "bar")
+
+	_, err = ReadBaseline(bytes.NewBufferString("packages: foo"))
+	assert.ErrorContains(t, err, "invalid baseline")
+}
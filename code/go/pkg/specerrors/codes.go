@@ -0,0 +1,494 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package specerrors
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CodeInfo describes a validation code.
+type CodeInfo struct {
+	// Code is the unique identifier of the validation.
+	Code string
+
+	// Title is a short description of the validation.
+	Title string
+
+	// Since is the first version of the spec that reports this validation. It is
+	// empty if the validation is reported for all versions.
+	Since string
+
+	// Description explains what the validation checks, it can be empty.
+	Description string
+
+	// Examples contains snippets of valid package files, in YAML.
+	Examples []string
+}
+
+var codes = []CodeInfo{
+	{
+		Code:  MessageRenameToEventOriginalValidation,
+		Title: "Rename message to event.original",
+		Since: "3.1.0",
+		Description: "Ingest pipelines that rename `message` to `event.original` must do it only when " +
+			"`event.original` is not set, and must remove `message` otherwise.",
+	},
+	{
+		Code:        CodeSchemaRequired,
+		Title:       "Required property is missing",
+		Description: "A property required by the schema of the file is not defined.",
+	},
+	{
+		Code:        CodeSchemaAdditionalProperty,
+		Title:       "Additional property is not allowed",
+		Description: "The file defines a property that is not included in the schema of the file.",
+	},
+	{
+		Code:        CodeSchemaInvalidType,
+		Title:       "Invalid type",
+		Description: "The value of a property is not of the type expected by the schema of the file.",
+	},
+	{
+		Code:        CodeSchemaValueNotAllowed,
+		Title:       "Value is not allowed",
+		Description: "The value of a property is not one of the values allowed by the schema of the file.",
+	},
+	{
+		Code:        CodeSchemaMustNotBePresent,
+		Title:       "Property must not be present",
+		Description: "A property is defined in a context where the schema of the file doesn't allow it.",
+	},
+	{
+		Code:        CodeSchemaInvalidFormat,
+		Title:       "Invalid format",
+		Description: "The value of a property doesn't match the pattern or the format expected by the schema of the file, as relative paths that must exist in the package.",
+	},
+	{
+		Code:        CodeSchemaOutOfRange,
+		Title:       "Value out of range",
+		Description: "The value of a property, its length or its number of items, is out of the limits defined by the schema of the file.",
+	},
+	{
+		Code:        CodeSchemaInvalid,
+		Title:       "Schema validation failed",
+		Description: "The file doesn't conform to its schema, for reasons not covered by more specific codes.",
+	},
+	{
+		Code:  CodeNonGASpecOnGAPackage,
+		Title: "Non GA spec used in GA package",
+		Since: "3.0.1",
+		Description: "Packages with a GA version (1.0.0 or later, without prerelease) cannot use " +
+			"a format_version of the spec that is still in development.",
+	},
+	{
+		Code:  CodePrereleaseFeatureOnGAPackage,
+		Title: "Prerelease feature used in GA package",
+		Since: "3.0.0",
+		Description: "Packages with a GA version (1.0.0 or later, without prerelease) cannot use " +
+			"features that are still in beta or technical preview.",
+	},
+	{
+		Code:  CodeExpiredExclusion,
+		Title: "Expired exclusion",
+		Since: "3.7.0",
+		Description: "Exclusions in `validation.yml` defined until a date or a package version are not " +
+			"applied after that date, or from that version. They must be reviewed and removed or extended.",
+	},
+	{
+		Code:  CodeUnusedExclusion,
+		Title: "Unused exclusion",
+		Description: "Exclusions in `validation.yml` that don't exclude any validation error can be removed. " +
+			"This validation is only reported when requested.",
+	},
+	{
+		Code:        CodeUnexpectedItem,
+		Title:       "Unexpected item in folder",
+		Description: "A file or folder is not expected in this location of the package, or it is a file where a folder is expected, or the other way around.",
+	},
+	{
+		Code:        CodeRequiredItemMissing,
+		Title:       "Required item is missing",
+		Description: "A file or folder required in this location of the package is not present.",
+	},
+	{
+		Code:        CodeItemNotAllowedInMode,
+		Title:       "Item not allowed in validation mode",
+		Description: "A file or folder is only allowed in source or in built packages, as `_dev` folders or `.link` files.",
+	},
+	{
+		Code:        CodeSizeLimitExceeded,
+		Title:       "Size limit exceeded",
+		Description: "A file, or the contents of a folder, exceed the size or the number of files allowed by the spec, or a file with a defined media type is empty.",
+	},
+	{
+		Code:        CodeInvalidFileFormat,
+		Title:       "Invalid file format",
+		Description: "A file cannot be parsed, or its contents don't match the expected media type.",
+	},
+	{
+		Code:        CodeUnreadablePackage,
+		Title:       "Package cannot be validated",
+		Description: "The package, or some of its files, cannot be read or validated.",
+	},
+	{
+		Code:        CodeTechnicalPreviewMode,
+		Title:       "Validation mode in technical preview",
+		Description: "The requested validation mode is in technical preview, reported only when warnings are treated as errors.",
+	},
+	{
+		Code:        CodeRuleTimeout,
+		Title:       "Semantic rule timeout",
+		Description: "A semantic rule reached the timeout configured in the validator, as rules fetching remote resources, so the package is not completely validated.",
+	},
+	{
+		Code:        CodeInvalidSpecRelease,
+		Title:       "Invalid release level in the spec",
+		Description: "The spec defines a folder with a release level other than `beta` or `ga`. This is an error in the spec, not in the package.",
+	},
+	{
+		Code:        CodeRemoteResourceUnavailable,
+		Title:       "Remote resource unavailable",
+		Description: "A resource needed by a validation, as the categories of the package registry, could not be fetched, so the package is not completely validated.",
+	},
+	{
+		Code:        CodeKibanaDashboardWithQueryButNoFilter,
+		Title:       "Dashboard with query but no filter",
+		Since:       "2.13.0",
+		Description: "Dashboards must use filters to select the data of the package, queries are not enough.",
+	},
+	{
+		Code:        CodeKibanaDashboardWithoutFilter,
+		Title:       "Dashboard without filter",
+		Since:       "2.13.0",
+		Description: "Dashboards, or all their panels, must define filters to select the data of the package.",
+	},
+	{
+		Code:        CodeKibanaDanglingObjectsIDs,
+		Title:       "Dangling object IDs",
+		Since:       "2.13.0",
+		Description: "Kibana objects can only reference other objects included in the package.",
+	},
+	{
+		Code:        CodeVisualizationByValue,
+		Title:       "Visualization by value",
+		Since:       "3.0.0",
+		Description: "Dashboards should define visualizations by value instead of referencing visualization objects.",
+	},
+	{
+		Code:        CodeMinimumKibanaVersion,
+		Title:       "Minimum Kibana version",
+		Since:       "3.0.0",
+		Description: "Some features, like saved object tags, require a minimum version in the `conditions.kibana.version` condition.",
+	},
+	{
+		Code:  CodePipelineTagRequired,
+		Title: "Processor tag is required",
+		Since: "3.6.0",
+		Description: "Every processor in an ingest pipeline must include a unique tag, which is used to " +
+			"annotate the processor in metrics and logs. Processors in the global pipeline " +
+			"on_failure handler are excluded from this check.",
+		Examples: []string{
+			"set:\n" +
+				"  tag: set_event_category\n" +
+				"  field: event.category\n" +
+				"  value: [network]\n",
+		},
+	},
+	{
+		Code:  CodeKibanaTagDuplicates,
+		Title: "Kibana tag is duplicate",
+		Since: "3.5.5",
+		Description: "Kibana tags declared under `kibana/tags.yml` are duplicated or package tags under " +
+			"`kibana/tag` directory are sharing the same id.",
+	},
+	{
+		Code:  CodePipelineOnFailureEventKind,
+		Title: "Pipeline failure handler must set event.kind",
+		Since: "3.6.0",
+		Description: "The global on_failure handler for an ingest pipeline must set `event.kind` to " +
+			"`pipeline_error`. This value indicates that an error occurred during the ingestion of this " +
+			"event, and that event data may be missing, inconsistent, or incorrect.",
+		Examples: []string{
+			"on_failure:\n" +
+				"  - set:\n" +
+				"      field: event.kind\n" +
+				"      value: pipeline_error\n",
+		},
+	},
+	{
+		Code:  CodePipelineOnFailureMessage,
+		Title: "Pipeline failure handler must set error.message",
+		Since: "3.6.0",
+		Description: "The global on_failure handler for an ingest pipeline must set or append to " +
+			"`error.message`, including `_ingest.on_failure_processor_type`, `_ingest.on_failure_processor_tag`, " +
+			"`_ingest.on_failure_message` and `_ingest.pipeline`. In cases where more than one `error.message` " +
+			"value is expected or could occur, the `append` processor should be used.",
+		Examples: []string{
+			"on_failure:\n" +
+				"  - append:\n" +
+				"      field: error.message\n" +
+				"      value: >-\n" +
+				"        Processor '{{{ _ingest.on_failure_processor_type }}}'\n" +
+				"        with tag '{{{ _ingest.on_failure_processor_tag }}}'\n" +
+				"        in pipeline '{{{ _ingest.pipeline }}}'\n" +
+				"        failed with message '{{{ _ingest.on_failure_message }}}'\n",
+			"on_failure:\n" +
+				"  - set:\n" +
+				"      field: error.message\n" +
+				"      value: >-\n" +
+				"        Processor '{{{ _ingest.on_failure_processor_type }}}'\n" +
+				"        with tag '{{{ _ingest.on_failure_processor_tag }}}'\n" +
+				"        in pipeline '{{{ _ingest.pipeline }}}'\n" +
+				"        failed with message '{{{ _ingest.on_failure_message }}}'\n",
+		},
+	},
+	{
+		Code:  CodeIntegrationInputQualifierRequired,
+		Title: "Input qualifier is required",
+		Since: "3.6.0",
+		Description: "Inputs in a policy template must have a name when there are multiple inputs " +
+			"of the same type.",
+	},
+	{
+		Code:        CodeVersionIntegrity,
+		Title:       "Version not in changelog",
+		Description: "The version of the package must be the version of the latest entry in the changelog.",
+	},
+	{
+		Code:        CodeChangelogLinks,
+		Title:       "Invalid changelog link",
+		Description: "Links to GitHub in the changelog must point to pull requests.",
+	},
+	{
+		Code:        CodePrerelease,
+		Title:       "Invalid prerelease version",
+		Description: "Prerelease tags in package versions must follow the supported formats.",
+	},
+	{
+		Code:        CodeFieldGroups,
+		Title:       "Field group with unit or metric type",
+		Description: "Field groups cannot define units or metric types.",
+	},
+	{
+		Code:        CodeFieldsLimits,
+		Title:       "Too many fields",
+		Description: "Data streams cannot define more fields than the limit of the spec.",
+	},
+	{
+		Code:        CodeUniqueFields,
+		Title:       "Duplicated field",
+		Since:       "2.0.0",
+		Description: "Fields can be defined only once in each data stream.",
+	},
+	{
+		Code:        CodeDimensionFields,
+		Title:       "Invalid dimension field",
+		Description: "Dimension fields must be of one of the types supported for dimensions.",
+	},
+	{
+		Code:        CodeDateFields,
+		Title:       "Invalid date field",
+		Description: "Fields used as dates must be of one of the expected types.",
+	},
+	{
+		Code:        CodeRequiredFields,
+		Title:       "Required field missing",
+		Description: "Fields required by data streams, as `data_stream.*` fields, must be defined with the expected types.",
+	},
+	{
+		Code:        CodeExternalFieldsWithDevFolder,
+		Title:       "External field without build definition",
+		Description: "Fields imported from external sources require a `_dev/build/build.yml` file defining the dependencies.",
+	},
+	{
+		Code:        CodeILMPolicyPresent,
+		Title:       "ILM policy not found",
+		Since:       "2.0.0",
+		Description: "ILM policies referenced by data streams must be included in the data stream, with the expected name.",
+	},
+	{
+		Code:        CodeProfilesNonGA,
+		Title:       "Profiles in GA package",
+		Description: "The profiles data type is in technical preview and cannot be used in GA packages.",
+	},
+	{
+		Code:        CodeKibanaObjectIDs,
+		Title:       "Kibana object ID doesn't match file name",
+		Description: "The ID of Kibana objects must match the name of the file defining them.",
+	},
+	{
+		Code:        CodeRoutingRulesAndDataset,
+		Title:       "Routing rules without dataset",
+		Since:       "2.9.0",
+		Description: "Data streams with routing rules must define the `dataset`.",
+	},
+	{
+		Code:        CodeKibanaNoLegacyVisualizations,
+		Title:       "Legacy visualization",
+		Since:       "3.0.0",
+		Description: "Kibana visualizations must not use legacy visualization types.",
+	},
+	{
+		Code:        CodeDimensionsPresent,
+		Title:       "Time series data stream without dimensions",
+		Since:       "3.0.1",
+		Description: "Data streams with time series index mode must define dimension fields.",
+	},
+	{
+		Code:        CodeCapabilitiesRequired,
+		Title:       "Capability not required",
+		Since:       "2.10.0",
+		Description: "Packages including security rules must require the `security` capability.",
+	},
+	{
+		Code:        CodeRequiredVarGroups,
+		Title:       "Invalid required variable groups",
+		Description: "Groups of required variables must reference variables defined in the package, that are not required by themselves.",
+	},
+	{
+		Code:        CodeVarGroups,
+		Title:       "Invalid variable groups",
+		Since:       "3.6.0",
+		Description: "Variable groups must have unique names and options, and reference variables defined in the manifest, that are not required by themselves.",
+	},
+	{
+		Code:        CodeSections,
+		Title:       "Invalid variable sections",
+		Description: "Sections must have unique names, and variables can only reference sections defined at the same level.",
+	},
+	{
+		Code:        CodeDocsStructure,
+		Title:       "Invalid documentation structure",
+		Description: "Documentation files must include the sections enforced by `validation.yml`.",
+	},
+	{
+		Code:        CodeDeploymentModes,
+		Title:       "Deployment mode without inputs",
+		Description: "Deployment modes enabled in policy templates must be supported by at least one of their inputs.",
+	},
+	{
+		Code:        CodeDurationVariables,
+		Title:       "Invalid duration variable",
+		Since:       "3.5.0",
+		Description: "Duration variables must satisfy that `min_duration` <= `default` <= `max_duration`.",
+	},
+	{
+		Code:        CodeInputPackagesPolicyTemplates,
+		Title:       "Invalid input package policy template",
+		Description: "Policy templates of input packages must reference existing template files.",
+	},
+	{
+		Code:        CodeInputDynamicSignalTypes,
+		Title:       "Dynamic signal types not supported",
+		Since:       "3.6.0",
+		Description: "The `dynamic_signal_types` setting can only be used with `otelcol` inputs.",
+	},
+	{
+		Code:        CodeFleetReservedVars,
+		Title:       "Invalid Fleet reserved variable",
+		Since:       "3.6.1",
+		Description: "Variables reserved by Fleet must conform to what Fleet expects when they are defined in packages.",
+	},
+	{
+		Code:        CodeMinimumAgentVersion,
+		Title:       "Invalid minimum agent version",
+		Description: "The `agent.version` condition of the package must be a valid version constraint.",
+	},
+	{
+		Code:        CodeIntegrationPolicyTemplates,
+		Title:       "Agent template not found",
+		Description: "Agent input and stream templates referenced by integration packages must exist.",
+	},
+	{
+		Code:        CodePolicyTemplateDatastreamCategories,
+		Title:       "Policy template categories not in data stream",
+		Description: "Data streams referenced by policy templates must include the categories of the policy template.",
+	},
+	{
+		Code:        CodeDatastreamPackageCategories,
+		Title:       "Data stream category not in package",
+		Description: "The categories of the package must include the parent categories of the categories of its data streams.",
+	},
+	{
+		Code:        CodeStaticHandlebarsFiles,
+		Title:       "Invalid Handlebars template",
+		Description: "Handlebars templates of the package must be valid.",
+	},
+	{
+		Code:        CodeIntegrationInputsDeprecation,
+		Title:       "All inputs deprecated",
+		Since:       "3.6.0",
+		Description: "Integration packages with all their inputs deprecated must be deprecated too.",
+	},
+	{
+		Code:        CodeDeprecatedReplacedBy,
+		Title:       "Invalid deprecation replacement",
+		Since:       "3.6.0",
+		Description: "Deprecations replaced by other packages or features must define all the required settings.",
+	},
+	{
+		Code:        CodePackageReferences,
+		Title:       "Invalid package reference",
+		Since:       "3.6.0",
+		Description: "Packages referenced by policy templates and data streams must be input packages listed in the requirements of the manifest.",
+	},
+	{
+		Code:        CodeTestPackageRequirements,
+		Title:       "Invalid test package requirement",
+		Since:       "3.6.0",
+		Description: "Packages required in test configurations must be listed in the manifest, with compatible versions.",
+	},
+	{
+		Code:        CodeNoEmbeddedEcsInDynamicTemplates,
+		Title:       "Embedded ECS dynamic template in source package",
+		Description: "Dynamic templates with names starting with `_embedded_ecs` are added when building packages, and must not be included in source packages.",
+	},
+	{
+		Code:        CodeNoExternalFields,
+		Title:       "External field in built package",
+		Description: "Built packages must include full definitions of fields, instead of references to external fields.",
+	},
+	{
+		Code:        CodeStreamInputBundled,
+		Title:       "Package reference in built package",
+		Description: "Package references in policy templates and data streams must be resolved in built packages.",
+	},
+}
+
+// Codes returns the information of all the known validation codes.
+func Codes() []CodeInfo {
+	result := make([]CodeInfo, len(codes))
+	copy(result, codes)
+	return result
+}
+
+// DocAnchor returns the anchor of the section describing the code in the
+// validation codes documentation (docs/validations.md).
+func (c CodeInfo) DocAnchor() string {
+	heading := strings.ToLower(c.Code + " - " + c.Title)
+	var anchor strings.Builder
+	anchor.WriteString("#")
+	for _, r := range heading {
+		switch {
+		case r == ' ':
+			anchor.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			anchor.WriteRune(r)
+		}
+	}
+	return anchor.String()
+}
+
+// LookupCode returns the information of a validation code. Codes are matched
+// case-insensitively.
+func LookupCode(code string) (CodeInfo, bool) {
+	for _, info := range codes {
+		if strings.EqualFold(info.Code, code) {
+			return info, true
+		}
+	}
+	return CodeInfo{}, false
+}
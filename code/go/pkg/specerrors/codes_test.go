@@ -0,0 +1,49 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package specerrors
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodes(t *testing.T) {
+	seen := make(map[string]struct{})
+	for _, info := range Codes() {
+		assert.NotEmpty(t, info.Code)
+		assert.NotEmpty(t, info.Title, info.Code)
+		if info.Since != "" {
+			_, err := semver.NewVersion(info.Since)
+			assert.NoError(t, err, info.Code)
+		}
+
+		_, found := seen[info.Code]
+		assert.False(t, found, "duplicated code %s", info.Code)
+		seen[info.Code] = struct{}{}
+	}
+}
+
+func TestCodeDocAnchor(t *testing.T) {
+	info, found := LookupCode(MessageRenameToEventOriginalValidation)
+	require.True(t, found)
+	assert.Equal(t, "#jse00001---rename-message-to-eventoriginal", info.DocAnchor())
+
+	info, found = LookupCode(CodeNonGASpecOnGAPackage)
+	require.True(t, found)
+	assert.Equal(t, "#psr00001---non-ga-spec-used-in-ga-package", info.DocAnchor())
+}
+
+func TestLookupCode(t *testing.T) {
+	info, found := LookupCode("svr00006")
+	require.True(t, found)
+	assert.Equal(t, CodePipelineTagRequired, info.Code)
+	assert.Equal(t, "Processor tag is required", info.Title)
+
+	_, found = LookupCode("SVR99999")
+	assert.False(t, found)
+}
@@ -10,10 +10,30 @@ const (
 
 	// JSE - JSON Schema Errors that can be skipped
 	MessageRenameToEventOriginalValidation = "JSE00001"
+	CodeSchemaRequired                     = "JSE00002"
+	CodeSchemaAdditionalProperty           = "JSE00003"
+	CodeSchemaInvalidType                  = "JSE00004"
+	CodeSchemaValueNotAllowed              = "JSE00005"
+	CodeSchemaMustNotBePresent             = "JSE00006"
+	CodeSchemaInvalidFormat                = "JSE00007"
+	CodeSchemaOutOfRange                   = "JSE00008"
+	CodeSchemaInvalid                      = "JSE00009"
 
 	// PSR - Package Spec [General] Rule
 	CodeNonGASpecOnGAPackage         = "PSR00001"
 	CodePrereleaseFeatureOnGAPackage = "PSR00002"
+	CodeExpiredExclusion             = "PSR00003"
+	CodeUnusedExclusion              = "PSR00004"
+	CodeUnexpectedItem               = "PSR00005"
+	CodeRequiredItemMissing          = "PSR00006"
+	CodeItemNotAllowedInMode         = "PSR00007"
+	CodeSizeLimitExceeded            = "PSR00008"
+	CodeInvalidFileFormat            = "PSR00009"
+	CodeUnreadablePackage            = "PSR00010"
+	CodeTechnicalPreviewMode         = "PSR00011"
+	CodeRuleTimeout                  = "PSR00012"
+	CodeInvalidSpecRelease           = "PSR00013"
+	CodeRemoteResourceUnavailable    = "PSR00014"
 
 	// SVR - Semantic Validation Rules
 	CodeKibanaDashboardWithQueryButNoFilter = "SVR00001"
@@ -26,4 +46,70 @@ const (
 	CodePipelineOnFailureEventKind          = "SVR00008"
 	CodePipelineOnFailureMessage            = "SVR00009"
 	CodeIntegrationInputQualifierRequired   = "SVR00010"
+	CodeVersionIntegrity                    = "SVR00011"
+	CodeChangelogLinks                      = "SVR00012"
+	CodePrerelease                          = "SVR00013"
+	CodeFieldGroups                         = "SVR00014"
+	CodeFieldsLimits                        = "SVR00015"
+	CodeUniqueFields                        = "SVR00016"
+	CodeDimensionFields                     = "SVR00017"
+	CodeDateFields                          = "SVR00018"
+	CodeRequiredFields                      = "SVR00019"
+	CodeExternalFieldsWithDevFolder         = "SVR00020"
+	CodeILMPolicyPresent                    = "SVR00021"
+	CodeProfilesNonGA                       = "SVR00022"
+	CodeKibanaObjectIDs                     = "SVR00023"
+	CodeRoutingRulesAndDataset              = "SVR00024"
+	CodeKibanaNoLegacyVisualizations        = "SVR00025"
+	CodeDimensionsPresent                   = "SVR00026"
+	CodeCapabilitiesRequired                = "SVR00027"
+	CodeRequiredVarGroups                   = "SVR00028"
+	CodeVarGroups                           = "SVR00029"
+	CodeSections                            = "SVR00030"
+	CodeDocsStructure                       = "SVR00031"
+	CodeDeploymentModes                     = "SVR00032"
+	CodeDurationVariables                   = "SVR00033"
+	CodeInputPackagesPolicyTemplates        = "SVR00034"
+	CodeInputDynamicSignalTypes             = "SVR00035"
+	CodeFleetReservedVars                   = "SVR00036"
+	CodeMinimumAgentVersion                 = "SVR00037"
+	CodeIntegrationPolicyTemplates          = "SVR00038"
+	CodePolicyTemplateDatastreamCategories  = "SVR00039"
+	CodeDatastreamPackageCategories         = "SVR00040"
+	CodeStaticHandlebarsFiles               = "SVR00041"
+	CodeIntegrationInputsDeprecation        = "SVR00042"
+	CodeDeprecatedReplacedBy                = "SVR00043"
+	CodePackageReferences                   = "SVR00044"
+	CodeTestPackageRequirements             = "SVR00045"
+	CodeNoEmbeddedEcsInDynamicTemplates     = "SVR00046"
+	CodeNoExternalFields                    = "SVR00047"
+	CodeStreamInputBundled                  = "SVR00048"
 )
+
+// Severity is the severity level of a validation error.
+type Severity string
+
+// Severity levels of validation errors.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// severityLevels contains the order of the severity levels.
+var severityLevels = map[Severity]int{
+	SeverityInfo:    1,
+	SeverityWarning: 2,
+	SeverityError:   3,
+}
+
+// Valid returns true if the severity is one of the known severity levels.
+func (s Severity) Valid() bool {
+	_, found := severityLevels[s]
+	return found
+}
+
+// AtLeast returns true if the severity is equal or higher than the given one.
+func (s Severity) AtLeast(min Severity) bool {
+	return severityLevels[s] >= severityLevels[min]
+}
@@ -18,15 +18,39 @@ type ValidationError interface {
 }
 
 // ValidationPathError is the interface that validation errors related to paths must implement.
-type ValidationPathError interface { // TODO no validation error using this interface yet
-	// File returns the file path where the error was raised.
+type ValidationPathError interface {
+	// File returns the package-relative path of the file where the error was raised.
 	File() string
 }
 
+// ValidationPositionError is the interface that validation errors related to a position
+// inside a file must implement.
+type ValidationPositionError interface {
+	// Line returns the 1-based line where the error was raised, or 0 if unknown.
+	Line() int
+	// Column returns the 1-based column where the error was raised, or 0 if unknown.
+	Column() int
+}
+
 // ValidationSeverityError is the interface that validation errors related to severities must implement.
-type ValidationSeverityError interface { // TODO no validation error using this interface yet
-	// File returns the file path where the error was raised.
-	Severity() string
+type ValidationSeverityError interface {
+	// Severity returns the severity of the error.
+	Severity() Severity
+}
+
+// SeverityOf returns the severity of a validation error, SeverityError if it
+// doesn't have one.
+func SeverityOf(err ValidationError) Severity {
+	if severityErr, ok := err.(ValidationSeverityError); ok && severityErr.Severity() != "" {
+		return severityErr.Severity()
+	}
+	return SeverityError
+}
+
+// ValidationFixError is the interface that validation errors with a suggested fix must implement.
+type ValidationFixError interface {
+	// Fix returns a change that solves the error, or nil if there is none.
+	Fix() *Fix
 }
 
 // ValidationErrors is an error that contains an iterable collection of validation error messages.
@@ -62,6 +86,14 @@ func (ve ValidationErrors) Error() string {
 	return message.String()
 }
 
+// AtLeast returns the validation errors with the given severity or a higher one.
+func (ve ValidationErrors) AtLeast(severity Severity) ValidationErrors {
+	collected, _ := ve.Collect(func(elem ValidationError) bool {
+		return SeverityOf(elem).AtLeast(severity)
+	})
+	return collected
+}
+
 // Append adds more validation errors.
 func (ve *ValidationErrors) Append(moreErrs ValidationErrors) {
 	if len(moreErrs) == 0 {
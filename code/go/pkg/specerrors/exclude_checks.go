@@ -6,7 +6,8 @@ package specerrors
 
 // ExcludeCheck is a processor to filter errors according to their messages.
 type ExcludeCheck struct {
-	code string
+	code     string
+	position configPosition
 }
 
 // NewExcludeCheck creates a new ExcludeCheck processor.
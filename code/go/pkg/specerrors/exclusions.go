@@ -0,0 +1,212 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package specerrors
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+const untilDateLayout = "2006-01-02"
+
+// now returns the current time, it can be replaced in tests.
+var now = time.Now
+
+// Exclusion is an entry of validation.yml that excludes errors, optionally only
+// in some files or data streams, and optionally until a date or a package version.
+type Exclusion struct {
+	// Code is the code of the errors to exclude. If empty, errors with any code
+	// are excluded, including errors without code, what requires to set Paths or DataStreams.
+	Code string `yaml:"code"`
+
+	// Paths contains glob patterns of the files where errors are excluded, relative
+	// to the package root. Patterns matching a directory apply to all its files.
+	Paths []string `yaml:"paths"`
+
+	// DataStreams contains the names of the data streams where errors are excluded.
+	DataStreams []string `yaml:"data_streams"`
+
+	// Reason explains why the errors are excluded, it is required.
+	Reason string `yaml:"reason"`
+
+	// Until is a date (YYYY-MM-DD) or a package version. After this date, or from
+	// this version, the exclusion is not applied anymore and an error is reported.
+	Until string `yaml:"until"`
+}
+
+// Validate checks that the exclusion is well defined.
+func (e Exclusion) Validate() error {
+	if e.Code == "" && len(e.Paths) == 0 && len(e.DataStreams) == 0 {
+		return errors.New("code, paths or data streams must be defined")
+	}
+	if strings.TrimSpace(e.Reason) == "" {
+		return errors.New("reason is required")
+	}
+	for _, pattern := range e.Paths {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+		}
+	}
+	if e.Until != "" {
+		if _, _, err := e.parseUntil(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseUntil returns the date or the version of Until, only one of them is returned.
+func (e Exclusion) parseUntil() (time.Time, *semver.Version, error) {
+	if date, err := time.Parse(untilDateLayout, e.Until); err == nil {
+		return date, nil, nil
+	}
+	version, err := semver.StrictNewVersion(e.Until)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("until %q must be a date (YYYY-MM-DD) or a package version", e.Until)
+	}
+	return time.Time{}, version, nil
+}
+
+// ExcludeScoped is a processor to filter errors according to an exclusion.
+type ExcludeScoped struct {
+	exclusion      Exclusion
+	packageVersion string
+	position       configPosition
+}
+
+// NewExcludeScoped creates a new ExcludeScoped processor. The package version is
+// used to check if the exclusion expired, when it is defined until a version.
+func NewExcludeScoped(exclusion Exclusion, packageVersion string) *ExcludeScoped {
+	return &ExcludeScoped{
+		exclusion:      exclusion,
+		packageVersion: packageVersion,
+	}
+}
+
+// Name returns the name of this ExcludeScoped processor.
+func (p ExcludeScoped) Name() string {
+	return "exclude-scoped"
+}
+
+// Process returns a new list of validation errors filtered. If the exclusion
+// expired, errors are not filtered, and an error about the expiration is added.
+func (p ExcludeScoped) Process(issues ValidationErrors) (ProcessResult, error) {
+	if err := p.exclusion.Validate(); err != nil {
+		return ProcessResult{}, fmt.Errorf("invalid exclusion: %w", err)
+	}
+
+	expired, err := p.expired()
+	if err != nil {
+		return ProcessResult{}, err
+	}
+	if expired {
+		expiredErr := NewStructuredError(
+			fmt.Errorf("file \"%s\" is invalid: exclusion of %s expired (until %s): %s", configPath, p.description(), p.exclusion.Until, p.exclusion.Reason),
+			CodeExpiredExclusion).WithFile(configPath)
+		if p.position.line > 0 {
+			expiredErr = expiredErr.WithPosition(p.position.line, p.position.column)
+		}
+		processed := slices.Clone(issues)
+		processed = append(processed, expiredErr)
+		return ProcessResult{Processed: processed}, nil
+	}
+
+	errs, filtered := issues.Collect(func(i ValidationError) bool {
+		return !p.matches(i)
+	})
+	return ProcessResult{Processed: errs, Removed: filtered}, nil
+}
+
+func (p ExcludeScoped) expired() (bool, error) {
+	if p.exclusion.Until == "" {
+		return false, nil
+	}
+	date, version, err := p.exclusion.parseUntil()
+	if err != nil {
+		return false, err
+	}
+	if version == nil {
+		// The exclusion applies until the end of the day.
+		return !now().UTC().Before(date.AddDate(0, 0, 1)), nil
+	}
+	if p.packageVersion == "" {
+		return false, nil
+	}
+	packageVersion, err := semver.NewVersion(p.packageVersion)
+	if err != nil {
+		return false, fmt.Errorf("invalid package version %q: %w", p.packageVersion, err)
+	}
+	return !packageVersion.LessThan(version), nil
+}
+
+func (p ExcludeScoped) matches(issue ValidationError) bool {
+	if p.exclusion.Code != "" && p.exclusion.Code != issue.Code() {
+		return false
+	}
+	if len(p.exclusion.Paths) == 0 && len(p.exclusion.DataStreams) == 0 {
+		return true
+	}
+
+	pathErr, ok := issue.(ValidationPathError)
+	if !ok || pathErr.File() == "" {
+		return false
+	}
+	file := path.Clean(pathErr.File())
+
+	if len(p.exclusion.Paths) > 0 && !slices.ContainsFunc(p.exclusion.Paths, func(pattern string) bool {
+		return matchPathPattern(pattern, file)
+	}) {
+		return false
+	}
+	if len(p.exclusion.DataStreams) > 0 && !slices.Contains(p.exclusion.DataStreams, dataStreamOf(file)) {
+		return false
+	}
+	return true
+}
+
+func (p ExcludeScoped) description() string {
+	var scope []string
+	if len(p.exclusion.Paths) > 0 {
+		scope = append(scope, "paths "+strings.Join(p.exclusion.Paths, ", "))
+	}
+	if len(p.exclusion.DataStreams) > 0 {
+		scope = append(scope, "data streams "+strings.Join(p.exclusion.DataStreams, ", "))
+	}
+	code := p.exclusion.Code
+	if code == "" {
+		code = "all errors"
+	}
+	if len(scope) == 0 {
+		return code
+	}
+	return fmt.Sprintf("%s in %s", code, strings.Join(scope, " and "))
+}
+
+// matchPathPattern returns true if the pattern matches the file, or any of
+// the directories containing it.
+func matchPathPattern(pattern, file string) bool {
+	for f := file; f != "." && f != "/"; f = path.Dir(f) {
+		if matched, _ := path.Match(pattern, f); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// dataStreamOf returns the name of the data stream containing the file, if any.
+func dataStreamOf(file string) string {
+	dataStream, found := strings.CutPrefix(file, "data_stream/")
+	if !found {
+		return ""
+	}
+	name, _, _ := strings.Cut(dataStream, "/")
+	return name
+}
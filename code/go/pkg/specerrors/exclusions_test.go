@@ -0,0 +1,168 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package specerrors
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExcludeScoped(t *testing.T) {
+	now = func() time.Time { return time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { now = time.Now })
+
+	issues := ValidationErrors{
+		NewStructuredError(errors.New("dashboard error"), "SVR00002").WithFile("kibana/dashboard/foo-legacy.json"),
+		NewStructuredError(errors.New("other dashboard error"), "SVR00002").WithFile("kibana/dashboard/foo.json"),
+		NewStructuredError(errors.New("pipeline error"), "SVR00006").WithFile("data_stream/logs/elasticsearch/ingest_pipeline/default.yml"),
+		NewStructuredError(errors.New("unassigned pipeline error"), UnassignedCode).WithFile("data_stream/logs/elasticsearch/ingest_pipeline/default.yml"),
+		NewStructuredError(errors.New("metrics pipeline error"), "SVR00006").WithFile("data_stream/metrics/elasticsearch/ingest_pipeline/default.yml"),
+		NewStructuredError(errors.New("error without file"), "SVR00006"),
+	}
+
+	cases := []struct {
+		title          string
+		exclusion      Exclusion
+		packageVersion string
+		removed        []string
+		added          []string
+	}{
+		{
+			title:     "code in all the package",
+			exclusion: Exclusion{Code: "SVR00002", Reason: "legacy"},
+			removed:   []string{"dashboard error (SVR00002)", "other dashboard error (SVR00002)"},
+		},
+		{
+			title:     "code in paths",
+			exclusion: Exclusion{Code: "SVR00002", Paths: []string{"kibana/dashboard/*-legacy.json"}, Reason: "legacy"},
+			removed:   []string{"dashboard error (SVR00002)"},
+		},
+		{
+			title:     "directory path",
+			exclusion: Exclusion{Code: "SVR00002", Paths: []string{"kibana"}, Reason: "legacy"},
+			removed:   []string{"dashboard error (SVR00002)", "other dashboard error (SVR00002)"},
+		},
+		{
+			title:     "code in data streams",
+			exclusion: Exclusion{Code: "SVR00006", DataStreams: []string{"logs"}, Reason: "legacy"},
+			removed:   []string{"pipeline error (SVR00006)"},
+		},
+		{
+			title:     "any code in data streams",
+			exclusion: Exclusion{DataStreams: []string{"logs"}, Reason: "legacy"},
+			removed:   []string{"pipeline error (SVR00006)", "unassigned pipeline error"},
+		},
+		{
+			title:     "paths and data streams",
+			exclusion: Exclusion{Paths: []string{"data_stream/*/elasticsearch"}, DataStreams: []string{"metrics"}, Reason: "legacy"},
+			removed:   []string{"metrics pipeline error (SVR00006)"},
+		},
+		{
+			title:     "not expired date",
+			exclusion: Exclusion{Code: "SVR00002", Reason: "legacy", Until: "2026-06-15"},
+			removed:   []string{"dashboard error (SVR00002)", "other dashboard error (SVR00002)"},
+		},
+		{
+			title:     "expired date",
+			exclusion: Exclusion{Code: "SVR00002", Reason: "legacy", Until: "2026-06-14"},
+			added:     []string{`file "validation.yml" is invalid: exclusion of SVR00002 expired (until 2026-06-14): legacy (PSR00003)`},
+		},
+		{
+			title:          "not expired version",
+			exclusion:      Exclusion{Code: "SVR00002", Reason: "legacy", Until: "2.0.0"},
+			packageVersion: "1.9.0",
+			removed:        []string{"dashboard error (SVR00002)", "other dashboard error (SVR00002)"},
+		},
+		{
+			title:          "expired version",
+			exclusion:      Exclusion{DataStreams: []string{"logs"}, Reason: "legacy", Until: "2.0.0"},
+			packageVersion: "2.0.0",
+			added:          []string{`file "validation.yml" is invalid: exclusion of all errors in data streams logs expired (until 2.0.0): legacy (PSR00003)`},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			result, err := NewExcludeScoped(c.exclusion, c.packageVersion).Process(issues)
+			require.NoError(t, err)
+
+			var removed []string
+			for _, e := range result.Removed {
+				removed = append(removed, e.Error())
+			}
+			assert.ElementsMatch(t, c.removed, removed)
+
+			require.Len(t, result.Processed, len(issues)-len(c.removed)+len(c.added))
+			for i, message := range c.added {
+				added := result.Processed[len(issues)+i]
+				assert.Equal(t, message, added.Error())
+				assert.Equal(t, CodeExpiredExclusion, added.Code())
+				assert.Equal(t, "validation.yml", added.(ValidationPathError).File())
+			}
+		})
+	}
+}
+
+func TestExclusionValidate(t *testing.T) {
+	cases := []struct {
+		exclusion Exclusion
+		expected  string
+	}{
+		{Exclusion{Code: "SVR00002", Reason: "legacy"}, ""},
+		{Exclusion{Paths: []string{"kibana"}, Reason: "legacy", Until: "2026-01-01"}, ""},
+		{Exclusion{DataStreams: []string{"logs"}, Reason: "legacy", Until: "1.0.0"}, ""},
+		{Exclusion{Reason: "legacy"}, "code, paths or data streams must be defined"},
+		{Exclusion{Code: "SVR00002"}, "reason is required"},
+		{Exclusion{Paths: []string{"kibana/["}, Reason: "legacy"}, `invalid path pattern "kibana/["`},
+		{Exclusion{Code: "SVR00002", Reason: "legacy", Until: "soon"}, `until "soon" must be a date (YYYY-MM-DD) or a package version`},
+	}
+
+	for _, c := range cases {
+		err := c.exclusion.Validate()
+		if c.expected == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.ErrorContains(t, err, c.expected)
+		}
+	}
+}
+
+func TestLoadConfigFilterExclusions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"manifest.yml": &fstest.MapFile{Data: []byte("name: foo\nversion: 1.2.0\n")},
+		"validation.yml": &fstest.MapFile{Data: []byte(`
+errors:
+  exclusions:
+    - code: SVR00002
+      paths:
+        - kibana/dashboard/*-legacy.json
+      reason: Legacy dashboards are going to be removed.
+      until: 2026-12-31
+`)},
+	}
+
+	config, err := LoadConfigFilter(fsys)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0", config.PackageVersion)
+	assert.Equal(t, []Exclusion{{
+		Code:   "SVR00002",
+		Paths:  []string{"kibana/dashboard/*-legacy.json"},
+		Reason: "Legacy dashboards are going to be removed.",
+		Until:  "2026-12-31",
+	}}, config.Errors.Exclusions)
+
+	fsys["validation.yml"] = &fstest.MapFile{Data: []byte(`
+errors:
+  exclusions:
+    - code: SVR00002
+`)}
+	_, err = LoadConfigFilter(fsys)
+	assert.ErrorContains(t, err, "invalid config file validation.yml: errors.exclusions.0: reason is required")
+}
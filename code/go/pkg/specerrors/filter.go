@@ -53,6 +53,37 @@ func (r *Filter) Run(allErrors ValidationErrors) (FilterResult, error) {
 	}, nil
 }
 
+// UnusedExclusionsErrors returns an error for each exclusion of the configuration
+// file that didn't remove any error, located in the entry of the exclusion.
+// Expired exclusions are not included, as they are already reported as errors.
+func UnusedExclusionsErrors(unused []Processor) ValidationErrors {
+	var errs ValidationErrors
+	for _, p := range unused {
+		var description string
+		var position configPosition
+		switch p := p.(type) {
+		case ExcludeCheck:
+			description, position = p.code, p.position
+		case ExcludeScoped:
+			if expired, err := p.expired(); err != nil || expired {
+				continue
+			}
+			description, position = p.description(), p.position
+		default:
+			continue
+		}
+
+		err := NewStructuredError(
+			fmt.Errorf("file \"%s\" is invalid: exclusion of %s is not used, no validation error was excluded", configPath, description),
+			CodeUnusedExclusion).WithFile(configPath)
+		if position.line > 0 {
+			err = err.WithPosition(position.line, position.column)
+		}
+		errs = append(errs, err)
+	}
+	return errs
+}
+
 func nilOrValidationErrors(errs ValidationErrors) error {
 	if len(errs) == 0 {
 		return nil
@@ -69,6 +100,21 @@ func (r *Filter) AddProcessors(items []Processor) {
 type ConfigFilter struct {
 	Errors                Processors            `yaml:"errors"`
 	DocsStructureEnforced DocsStructureEnforced `yaml:"docs_structure_enforced"`
+
+	// PackageVersion is the version of the package, used to check if exclusions
+	// defined until a version expired. It is read from the package manifest.
+	PackageVersion string `yaml:"-"`
+
+	// excludeChecksPositions and exclusionsPositions contain the positions of the
+	// entries in the configuration file, if it was loaded from a file.
+	excludeChecksPositions []configPosition
+	exclusionsPositions    []configPosition
+}
+
+// configPosition is the position of an entry in the configuration file.
+type configPosition struct {
+	line   int
+	column int
 }
 
 // DocsStructureEnforced forces documentation to follow a specific structure, with specific sections and titles.
@@ -86,7 +132,12 @@ type DocsStructureSkip struct {
 
 // Processors represents the list of processors in the configuration file
 type Processors struct {
-	ExcludeChecks []string `yaml:"exclude_checks"`
+	ExcludeChecks []string    `yaml:"exclude_checks"`
+	Exclusions    []Exclusion `yaml:"exclusions"`
+
+	// Severity contains the severity of the errors with each code. It is applied
+	// by the validator, as the severity decides which errors are reported.
+	Severity map[string]Severity `yaml:"severity"`
 }
 
 // LoadConfigFilter reads the config file and returns a ConfigFilter struct
@@ -98,19 +149,103 @@ func LoadConfigFilter(fsys fs.FS) (*ConfigFilter, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file %s: %w", configPath, err)
 	}
+	var node yaml.Node
+	err = yaml.Unmarshal(yamlFile, &node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	}
 	var config ConfigFilter
-	err = yaml.Unmarshal(yamlFile, &config)
+	err = node.Decode(&config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
 	}
+	config.excludeChecksPositions = sequencePositions(&node, "errors", "exclude_checks")
+	config.exclusionsPositions = sequencePositions(&node, "errors", "exclusions")
+	for i, exclusion := range config.Errors.Exclusions {
+		if err := exclusion.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: errors.exclusions.%d: %w", configPath, i, err)
+		}
+	}
+	for code, severity := range config.Errors.Severity {
+		if !severity.Valid() {
+			return nil, fmt.Errorf("invalid config file %s: errors.severity.%s: unknown severity %q", configPath, code, severity)
+		}
+	}
+
+	config.PackageVersion, err = readPackageVersion(fsys)
+	if err != nil {
+		return nil, err
+	}
 	return &config, nil
 }
 
+// sequencePositions returns the positions of the items of the sequence found
+// in the given path of mapping keys.
+func sequencePositions(node *yaml.Node, keys ...string) []configPosition {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for _, key := range keys {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var value *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				value = node.Content[i+1]
+				break
+			}
+		}
+		if value == nil {
+			return nil
+		}
+		node = value
+	}
+	if node.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	positions := make([]configPosition, len(node.Content))
+	for i, item := range node.Content {
+		positions[i] = configPosition{line: item.Line, column: item.Column}
+	}
+	return positions
+}
+
+// readPackageVersion returns the version in the package manifest, or an empty
+// string if there is no manifest.
+func readPackageVersion(fsys fs.FS) (string, error) {
+	d, err := fs.ReadFile(fsys, "manifest.yml")
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read package manifest: %w", err)
+	}
+	var manifest struct {
+		Version string `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(d, &manifest); err != nil {
+		return "", fmt.Errorf("failed to parse package manifest: %w", err)
+	}
+	return manifest.Version, nil
+}
+
 // NewFilter creates a new filter given a configuration
 func NewFilter(config *ConfigFilter) *Filter {
 	var filters []Processor
-	for _, code := range config.Errors.ExcludeChecks {
+	for i, code := range config.Errors.ExcludeChecks {
 		exclude := NewExcludeCheck(code)
+		if i < len(config.excludeChecksPositions) {
+			exclude.position = config.excludeChecksPositions[i]
+		}
+		filters = append(filters, *exclude)
+	}
+	for i, exclusion := range config.Errors.Exclusions {
+		exclude := NewExcludeScoped(exclusion, config.PackageVersion)
+		if i < len(config.exclusionsPositions) {
+			exclude.position = config.exclusionsPositions[i]
+		}
 		filters = append(filters, *exclude)
 	}
 
@@ -10,6 +10,7 @@ import (
 	"os"
 	"slices"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -169,3 +170,45 @@ func TestLoadConfigFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestUnusedExclusionsErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"validation.yml": &fstest.MapFile{Data: []byte(`errors:
+  exclude_checks:
+    - CODE01
+    - CODE02
+  exclusions:
+    - code: CODE03
+      paths:
+        - kibana
+      reason: Used exclusion.
+    - data_streams:
+        - logs
+      reason: Unused exclusion.
+    - code: CODE04
+      reason: Expired exclusion.
+      until: 2000-01-01
+`)},
+	}
+	config, err := LoadConfigFilter(fsys)
+	require.NoError(t, err)
+
+	result, err := NewFilter(config).Run(ValidationErrors{
+		createValidationError("other error", "CODE01"),
+		NewStructuredError(errors.New("dashboard error"), "CODE03").WithFile("kibana/dashboard/foo.json"),
+	})
+	require.NoError(t, err)
+
+	errs := UnusedExclusionsErrors(result.UnusedProcessors)
+	require.Len(t, errs, 2)
+
+	assert.Equal(t, `file "validation.yml" is invalid: exclusion of CODE02 is not used, no validation error was excluded (PSR00004)`, errs[0].Error())
+	assert.Equal(t, CodeUnusedExclusion, errs[0].Code())
+	assert.Equal(t, "validation.yml", errs[0].(ValidationPathError).File())
+	assert.Equal(t, 4, errs[0].(ValidationPositionError).Line())
+	assert.Equal(t, 7, errs[0].(ValidationPositionError).Column())
+
+	assert.Equal(t, `file "validation.yml" is invalid: exclusion of all errors in data streams logs is not used, no validation error was excluded (PSR00004)`, errs[1].Error())
+	assert.Equal(t, 10, errs[1].(ValidationPositionError).Line())
+	assert.Equal(t, 7, errs[1].(ValidationPositionError).Column())
+}
@@ -0,0 +1,170 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package specerrors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// Fix is a machine-applicable change that solves a validation error.
+type Fix struct {
+	// Description is a human-readable summary of the change.
+	Description string `json:"description"`
+
+	// File is the package-relative path of the file to change.
+	File string `json:"file"`
+
+	// Edits are the changes to apply to the file. They must not overlap.
+	Edits []Edit `json:"edits"`
+}
+
+// Edit replaces the bytes between Start and End of a file with Text. Start and
+// End are 0-based byte offsets, an edit with the same Start and End inserts Text.
+type Edit struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Text  string `json:"text"`
+}
+
+// InsertLine returns an edit that inserts text at the beginning of the given
+// 1-based line of content. Lines after the end of content are inserted at the end.
+func InsertLine(content []byte, line int, text string) Edit {
+	offset := 0
+	for current := 1; current < line && offset < len(content); offset++ {
+		if content[offset] == '\n' {
+			current++
+		}
+	}
+	return Edit{Start: offset, End: offset, Text: text}
+}
+
+func (e Edit) overlaps(other Edit) bool {
+	if e.Start == other.Start {
+		return true
+	}
+	return e.Start < other.End && other.Start < e.End
+}
+
+// FixResult contains the result of applying fixes.
+type FixResult struct {
+	// Applied contains the errors whose fixes were applied.
+	Applied ValidationErrors
+
+	// Skipped contains the errors whose fixes were not applied, because they
+	// overlap with other fixes or are out of the bounds of the file.
+	// Validating the package again reports them with updated fixes.
+	Skipped ValidationErrors
+}
+
+// ApplyFixes applies the fixes of the given errors to the package in the given
+// directory. Errors without fix are ignored. Fixes are applied in order, fixes
+// that overlap with previous ones are skipped.
+func ApplyFixes(dir string, errs ValidationErrors) (FixResult, error) {
+	var result FixResult
+
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return result, err
+	}
+	defer root.Close()
+
+	type fileFixes struct {
+		content []byte
+		edits   []Edit
+		changed bool
+	}
+	var files []string
+	fixes := make(map[string]*fileFixes)
+	for _, err := range errs {
+		fixErr, ok := err.(ValidationFixError)
+		if !ok || fixErr.Fix() == nil {
+			continue
+		}
+		fix := fixErr.Fix()
+
+		f, found := fixes[fix.File]
+		if !found {
+			content, err := root.ReadFile(fix.File)
+			if err != nil {
+				return result, fmt.Errorf("failed to read file %q: %w", fix.File, err)
+			}
+			f = &fileFixes{content: content}
+			fixes[fix.File] = f
+			files = append(files, fix.File)
+		}
+
+		if !canApply(f.content, f.edits, fix.Edits) {
+			result.Skipped = append(result.Skipped, err)
+			continue
+		}
+		f.edits = append(f.edits, fix.Edits...)
+		f.changed = true
+		result.Applied = append(result.Applied, err)
+	}
+
+	for _, file := range files {
+		f := fixes[file]
+		if !f.changed {
+			continue
+		}
+		info, err := root.Stat(file)
+		if err != nil {
+			return result, fmt.Errorf("failed to stat file %q: %w", file, err)
+		}
+		if err := root.WriteFile(file, applyEdits(f.content, f.edits), info.Mode().Perm()); err != nil {
+			return result, fmt.Errorf("failed to write file %q: %w", file, err)
+		}
+	}
+
+	return result, nil
+}
+
+// ApplyEdits returns a copy of content with the given edits applied. Edits must
+// be in the bounds of content and must not overlap.
+func ApplyEdits(content []byte, edits ...Edit) ([]byte, error) {
+	if !canApply(content, nil, edits) {
+		return nil, errors.New("edits overlap or are out of bounds")
+	}
+	return applyEdits(content, edits), nil
+}
+
+// canApply checks that the edits are in the bounds of content and don't overlap
+// with the already accepted edits, nor between them.
+func canApply(content []byte, accepted []Edit, edits []Edit) bool {
+	if len(edits) == 0 {
+		return false
+	}
+	for i, edit := range edits {
+		if edit.Start < 0 || edit.End < edit.Start || edit.End > len(content) {
+			return false
+		}
+		for _, other := range accepted {
+			if edit.overlaps(other) {
+				return false
+			}
+		}
+		for _, other := range edits[:i] {
+			if edit.overlaps(other) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func applyEdits(content []byte, edits []Edit) []byte {
+	edits = slices.Clone(edits)
+	slices.SortFunc(edits, func(a, b Edit) int {
+		return b.Start - a.Start
+	})
+	result := slices.Clone(content)
+	for _, edit := range edits {
+		result = slices.Replace(result, edit.Start, edit.End, []byte(edit.Text)...)
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package specerrors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertLine(t *testing.T) {
+	content := []byte("first\nsecond\nthird")
+
+	cases := []struct {
+		line     int
+		expected int
+	}{
+		{line: 1, expected: 0},
+		{line: 2, expected: 6},
+		{line: 3, expected: 13},
+		{line: 10, expected: len(content)},
+	}
+
+	for _, c := range cases {
+		edit := InsertLine(content, c.line, "new\n")
+		assert.Equal(t, Edit{Start: c.expected, End: c.expected, Text: "new\n"}, edit, "line %d", c.line)
+	}
+}
+
+func TestApplyEdits(t *testing.T) {
+	content := []byte("type: profiling\n")
+
+	result, err := ApplyEdits(content, Edit{Start: 6, End: 15, Text: "profiles"}, Edit{Start: 0, End: 0, Text: "# data stream\n"})
+	require.NoError(t, err)
+	assert.Equal(t, "# data stream\ntype: profiles\n", string(result))
+	assert.Equal(t, "type: profiling\n", string(content))
+
+	_, err = ApplyEdits(content, Edit{Start: 6, End: 15, Text: "a"}, Edit{Start: 10, End: 12, Text: "b"})
+	assert.Error(t, err)
+	_, err = ApplyEdits(content, Edit{Start: 6, End: 100, Text: "a"})
+	assert.Error(t, err)
+}
+
+func TestApplyFixes(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifest.yml"), []byte("name: foo\nversion: 1.0.0\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "docs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docs", "README.md"), []byte("# Foo\n"), 0644))
+
+	fixedErr := func(file string, edits ...Edit) ValidationError {
+		return NewStructuredErrorf("some error").WithFix(Fix{File: file, Edits: edits})
+	}
+	errs := ValidationErrors{
+		fixedErr("manifest.yml", Edit{Start: 0, End: 0, Text: "---\n"}),
+		NewStructuredErrorf("error without fix"),
+		fixedErr("manifest.yml", Edit{Start: 19, End: 24, Text: "2.0.0"}),
+		fixedErr("docs/README.md", Edit{Start: 2, End: 5, Text: "Bar"}),
+		fixedErr("manifest.yml", Edit{Start: 0, End: 0, Text: "# insert at the same offset\n"}),
+		fixedErr("manifest.yml", Edit{Start: 20, End: 21, Text: "overlapping"}),
+		fixedErr("manifest.yml", Edit{Start: 25, End: 100, Text: "out of bounds"}),
+		fixedErr("manifest.yml"),
+	}
+
+	result, err := ApplyFixes(dir, errs)
+	require.NoError(t, err)
+	assert.Equal(t, ValidationErrors{errs[0], errs[2], errs[3]}, result.Applied)
+	assert.Equal(t, ValidationErrors{errs[4], errs[5], errs[6], errs[7]}, result.Skipped)
+
+	manifest, err := os.ReadFile(filepath.Join(dir, "manifest.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "---\nname: foo\nversion: 2.0.0\n", string(manifest))
+
+	readme, err := os.ReadFile(filepath.Join(dir, "docs", "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Bar\n", string(readme))
+
+	_, err = ApplyFixes(dir, ValidationErrors{fixedErr("../outside.yml", Edit{Text: "foo"})})
+	assert.Error(t, err)
+}
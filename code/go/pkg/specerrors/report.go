@@ -0,0 +1,123 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package specerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// PackageReport contains the validation errors found in a single package.
+type PackageReport struct {
+	// Path is the path of the validated package, as it was given to the validator.
+	Path string
+
+	// Errors contains the errors found while validating the package, it
+	// is empty if the package is valid.
+	Errors ValidationErrors
+}
+
+// Valid returns true if none of the errors of the report has SeverityError.
+func (r PackageReport) Valid() bool {
+	for _, err := range r.Errors {
+		if SeverityOf(err) == SeverityError {
+			return false
+		}
+	}
+	return true
+}
+
+// NewPackageReport creates the report of a package from the error returned
+// by the validator. Errors that are not validation errors are reported as
+// a single uncoded error.
+func NewPackageReport(path string, err error) PackageReport {
+	report := PackageReport{Path: path}
+	if err == nil {
+		return report
+	}
+
+	var errs ValidationErrors
+	if errors.As(err, &errs) {
+		report.Errors = errs
+		return report
+	}
+
+	var validationErr ValidationError
+	if errors.As(err, &validationErr) {
+		report.Errors = ValidationErrors{validationErr}
+		return report
+	}
+
+	report.Errors = ValidationErrors{NewStructuredError(err, UnassignedCode)}
+	return report
+}
+
+// reportFinding is the representation of a validation error in reports.
+type reportFinding struct {
+	Code     string   `json:"code,omitempty"`
+	Message  string   `json:"message"`
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	Severity Severity `json:"severity"`
+	Fix      *Fix     `json:"fix,omitempty"`
+}
+
+func newReportFinding(err ValidationError) reportFinding {
+	finding := reportFinding{
+		Code:     err.Code(),
+		Message:  err.Error(),
+		Severity: SeverityOf(err),
+	}
+
+	// Structured errors include the code in the message, report it only once.
+	if structuredErr, ok := err.(*StructuredError); ok && structuredErr.Unwrap() != nil {
+		finding.Message = structuredErr.Unwrap().Error()
+	}
+	if pathErr, ok := err.(ValidationPathError); ok {
+		finding.File = pathErr.File()
+	}
+	if positionErr, ok := err.(ValidationPositionError); ok {
+		finding.Line = positionErr.Line()
+		finding.Column = positionErr.Column()
+	}
+	if fixErr, ok := err.(ValidationFixError); ok {
+		finding.Fix = fixErr.Fix()
+	}
+	return finding
+}
+
+type jsonReport struct {
+	Packages []jsonPackageReport `json:"packages"`
+}
+
+type jsonPackageReport struct {
+	Path   string          `json:"path"`
+	Valid  bool            `json:"valid"`
+	Errors []reportFinding `json:"errors"`
+}
+
+// WriteJSONReport writes the given package reports as a JSON document.
+func WriteJSONReport(w io.Writer, reports ...PackageReport) error {
+	doc := jsonReport{
+		Packages: make([]jsonPackageReport, len(reports)),
+	}
+	for i, report := range reports {
+		findings := make([]reportFinding, len(report.Errors))
+		for j, err := range report.Errors {
+			findings[j] = newReportFinding(err)
+		}
+		doc.Packages[i] = jsonPackageReport{
+			Path:   report.Path,
+			Valid:  report.Valid(),
+			Errors: findings,
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
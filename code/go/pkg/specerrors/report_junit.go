@@ -0,0 +1,131 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package specerrors
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+const (
+	junitSuitesName = "package-spec"
+
+	// junitValidationTestName is the name of the test case used for
+	// errors without code, and for valid packages.
+	junitValidationTestName = "validation"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnitReport writes the given package reports as JUnit XML. There is a
+// test suite per package, and a test case per rule and file, which fails if
+// any of its findings has error severity. Findings with lower severities are
+// included in the output of the test case. Valid packages contain a single
+// passing test case.
+func WriteJUnitReport(w io.Writer, reports ...PackageReport) error {
+	doc := junitTestSuites{Name: junitSuitesName}
+	for _, report := range reports {
+		suite := newJUnitTestSuite(report)
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func newJUnitTestSuite(report PackageReport) junitTestSuite {
+	type testCaseKey struct {
+		code string
+		file string
+	}
+	var keys []testCaseKey
+	findings := make(map[testCaseKey][]reportFinding)
+	for _, err := range report.Errors {
+		finding := newReportFinding(err)
+		key := testCaseKey{code: finding.Code, file: finding.File}
+		if _, found := findings[key]; !found {
+			keys = append(keys, key)
+		}
+		findings[key] = append(findings[key], finding)
+	}
+
+	suite := junitTestSuite{Name: report.Path}
+	if len(keys) == 0 {
+		suite.TestCases = []junitTestCase{{
+			Name:      junitValidationTestName,
+			ClassName: report.Path,
+		}}
+	}
+	for _, key := range keys {
+		testCase := junitTestCase{
+			Name:      key.code,
+			ClassName: key.file,
+		}
+		if testCase.Name == "" {
+			testCase.Name = junitValidationTestName
+		}
+		if testCase.ClassName == "" {
+			testCase.ClassName = report.Path
+		}
+
+		var failures, others []string
+		for _, finding := range findings[key] {
+			if finding.Severity == SeverityError {
+				failures = append(failures, finding.Message)
+			} else {
+				others = append(others, string(finding.Severity)+": "+finding.Message)
+			}
+		}
+		if len(failures) > 0 {
+			testCase.Failure = &junitFailure{
+				Message: failures[0],
+				Type:    key.code,
+				Text:    strings.Join(failures, "\n"),
+			}
+			suite.Failures++
+		}
+		testCase.SystemOut = strings.Join(others, "\n")
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	suite.Tests = len(suite.TestCases)
+	return suite
+}
@@ -0,0 +1,184 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package specerrors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchema    = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolName  = "package-spec"
+	sarifToolURI   = "https://github.com/elastic/package-spec"
+	sarifLevelNone = "none"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult                    `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// WriteSARIFReport writes the given package reports as a SARIF 2.1.0 log with
+// a single run. Locations are package-relative URIs, whose base is the absolute
+// file URI of the package path, defined in the originalUriBaseIds of the run with
+// an identifier for each package.
+func WriteSARIFReport(w io.Writer, reports ...PackageReport) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           sarifToolName,
+				InformationURI: sarifToolURI,
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	seenRules := make(map[string]struct{})
+	for i, report := range reports {
+		baseID := fmt.Sprintf("PACKAGE%d", i)
+		for _, err := range report.Errors {
+			finding := newReportFinding(err)
+			if finding.Code != "" {
+				if _, found := seenRules[finding.Code]; !found {
+					seenRules[finding.Code] = struct{}{}
+					run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: finding.Code})
+				}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    finding.Code,
+				Level:     sarifLevel(finding.Severity),
+				Message:   sarifMessage{Text: finding.Message},
+				Locations: sarifLocations(baseID, finding),
+			})
+			if finding.File != "" && run.OriginalURIBaseIDs[baseID].URI == "" {
+				base, err := sarifBaseURI(report.Path)
+				if err != nil {
+					return err
+				}
+				if run.OriginalURIBaseIDs == nil {
+					run.OriginalURIBaseIDs = make(map[string]sarifArtifactLocation)
+				}
+				run.OriginalURIBaseIDs[baseID] = sarifArtifactLocation{URI: base}
+			}
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "note"
+	default:
+		return sarifLevelNone
+	}
+}
+
+// sarifBaseURI returns the absolute file URI of the package path, ending with a
+// slash so package-relative URIs are resolved inside it.
+func sarifBaseURI(packagePath string) (string, error) {
+	abs, err := filepath.Abs(packagePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path of package %q: %w", packagePath, err)
+	}
+	uriPath := filepath.ToSlash(abs)
+	if !strings.HasPrefix(uriPath, "/") {
+		// Windows paths, as C:/packages, need a leading slash in file URIs.
+		uriPath = "/" + uriPath
+	}
+	if !strings.HasSuffix(uriPath, "/") {
+		uriPath += "/"
+	}
+	return (&url.URL{Scheme: "file", Path: uriPath}).String(), nil
+}
+
+func sarifLocations(baseID string, finding reportFinding) []sarifLocation {
+	if finding.File == "" {
+		return nil
+	}
+	location := sarifLocation{
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{
+				URI:       (&url.URL{Path: finding.File}).String(),
+				URIBaseID: baseID,
+			},
+		},
+	}
+	if finding.Line > 0 {
+		location.PhysicalLocation.Region = &sarifRegion{
+			StartLine:   finding.Line,
+			StartColumn: finding.Column,
+		}
+	}
+	return []sarifLocation{location}
+}
@@ -0,0 +1,211 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package specerrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testReports() []PackageReport {
+	return []PackageReport{
+		NewPackageReport("packages/good", nil),
+		NewPackageReport("packages/bad", ValidationErrors{
+			NewStructuredError(errors.New("missing required tag"), CodePipelineTagRequired).
+				WithFile("data_stream/logs/elasticsearch/ingest_pipeline/default.yml").
+				WithPosition(4, 5).
+				WithFix(Fix{
+					Description: "Add tag \"set_1\" to the set processor",
+					File:        "data_stream/logs/elasticsearch/ingest_pipeline/default.yml",
+					Edits:       []Edit{{Start: 40, End: 40, Text: "      tag: set_1\n"}},
+				}),
+			NewStructuredError(errors.New("duplicated tag"), CodePipelineTagRequired).
+				WithFile("data_stream/logs/elasticsearch/ingest_pipeline/default.yml").
+				WithPosition(9, 5),
+			NewStructuredErrorf("field owner: type is required").
+				WithFile("manifest.yml").
+				WithPosition(12, 1),
+			NewStructuredError(errors.New("dashboard without filter"), CodeKibanaDashboardWithoutFilter).
+				WithFile("kibana/dashboard/dashboard.json").
+				WithSeverity(SeverityWarning),
+		}),
+		NewPackageReport("packages/broken", errors.New("could not read package")),
+	}
+}
+
+func TestNewPackageReport(t *testing.T) {
+	report := NewPackageReport("foo", nil)
+	assert.Equal(t, "foo", report.Path)
+	assert.Empty(t, report.Errors)
+
+	errs := ValidationErrors{NewStructuredErrorf("foo")}
+	report = NewPackageReport("foo", errs)
+	assert.Equal(t, errs, report.Errors)
+
+	report = NewPackageReport("foo", errors.New("unexpected error"))
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, "unexpected error", report.Errors[0].Error())
+}
+
+func TestWriteJSONReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteJSONReport(&buf, testReports()...)
+	require.NoError(t, err)
+
+	expected := `{
+  "packages": [
+    {
+      "path": "packages/good",
+      "valid": true,
+      "errors": []
+    },
+    {
+      "path": "packages/bad",
+      "valid": false,
+      "errors": [
+        {
+          "code": "SVR00006",
+          "message": "missing required tag",
+          "file": "data_stream/logs/elasticsearch/ingest_pipeline/default.yml",
+          "line": 4,
+          "column": 5,
+          "severity": "error",
+          "fix": {
+            "description": "Add tag \"set_1\" to the set processor",
+            "file": "data_stream/logs/elasticsearch/ingest_pipeline/default.yml",
+            "edits": [
+              {
+                "start": 40,
+                "end": 40,
+                "text": "      tag: set_1\n"
+              }
+            ]
+          }
+        },
+        {
+          "code": "SVR00006",
+          "message": "duplicated tag",
+          "file": "data_stream/logs/elasticsearch/ingest_pipeline/default.yml",
+          "line": 9,
+          "column": 5,
+          "severity": "error"
+        },
+        {
+          "message": "field owner: type is required",
+          "file": "manifest.yml",
+          "line": 12,
+          "column": 1,
+          "severity": "error"
+        },
+        {
+          "code": "SVR00002",
+          "message": "dashboard without filter",
+          "file": "kibana/dashboard/dashboard.json",
+          "severity": "warning"
+        }
+      ]
+    },
+    {
+      "path": "packages/broken",
+      "valid": false,
+      "errors": [
+        {
+          "message": "could not read package",
+          "severity": "error"
+        }
+      ]
+    }
+  ]
+}
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteSARIFReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteSARIFReport(&buf, testReports()...)
+	require.NoError(t, err)
+
+	var log sarifLog
+	err = json.Unmarshal(buf.Bytes(), &log)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, "package-spec", run.Tool.Driver.Name)
+	assert.Equal(t, []sarifRule{{ID: "SVR00006"}, {ID: "SVR00002"}}, run.Tool.Driver.Rules)
+
+	base, err := filepath.Abs(filepath.Join("packages", "bad"))
+	require.NoError(t, err)
+	baseURI, err := url.Parse(run.OriginalURIBaseIDs["PACKAGE1"].URI)
+	require.NoError(t, err)
+	assert.Equal(t, "file", baseURI.Scheme)
+	assert.True(t, strings.HasSuffix(baseURI.Path, filepath.ToSlash(base)+"/"), baseURI.Path)
+	assert.Len(t, run.OriginalURIBaseIDs, 1)
+
+	require.Len(t, run.Results, 5)
+	assert.Equal(t, sarifResult{
+		RuleID:  "SVR00006",
+		Level:   "error",
+		Message: sarifMessage{Text: "missing required tag"},
+		Locations: []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: "data_stream/logs/elasticsearch/ingest_pipeline/default.yml", URIBaseID: "PACKAGE1"},
+				Region:           &sarifRegion{StartLine: 4, StartColumn: 5},
+			},
+		}},
+	}, run.Results[0])
+	assert.Equal(t, "", run.Results[2].RuleID)
+	assert.Equal(t, "warning", run.Results[3].Level)
+	assert.Nil(t, run.Results[3].Locations[0].PhysicalLocation.Region)
+	assert.Empty(t, run.Results[4].Locations)
+}
+
+func TestWriteJUnitReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteJUnitReport(&buf, testReports()...)
+	require.NoError(t, err)
+
+	var suites junitTestSuites
+	err = xml.Unmarshal(buf.Bytes(), &suites)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, suites.Tests)
+	assert.Equal(t, 3, suites.Failures)
+	require.Len(t, suites.Suites, 3)
+
+	good := suites.Suites[0]
+	assert.Equal(t, "packages/good", good.Name)
+	require.Len(t, good.TestCases, 1)
+	assert.Nil(t, good.TestCases[0].Failure)
+
+	bad := suites.Suites[1]
+	assert.Equal(t, 3, bad.Tests)
+	assert.Equal(t, 2, bad.Failures)
+	require.Len(t, bad.TestCases, 3)
+	assert.Equal(t, "SVR00006", bad.TestCases[0].Name)
+	assert.Equal(t, "data_stream/logs/elasticsearch/ingest_pipeline/default.yml", bad.TestCases[0].ClassName)
+	require.NotNil(t, bad.TestCases[0].Failure)
+	assert.Equal(t, "missing required tag\nduplicated tag", bad.TestCases[0].Failure.Text)
+	assert.Equal(t, "validation", bad.TestCases[1].Name)
+	assert.Equal(t, "manifest.yml", bad.TestCases[1].ClassName)
+	assert.Nil(t, bad.TestCases[2].Failure)
+	assert.Equal(t, "warning: dashboard without filter", bad.TestCases[2].SystemOut)
+
+	broken := suites.Suites[2]
+	require.Len(t, broken.TestCases, 1)
+	assert.Equal(t, "packages/broken", broken.TestCases[0].ClassName)
+	require.NotNil(t, broken.TestCases[0].Failure)
+}
@@ -0,0 +1,38 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package specerrors
+
+import "fmt"
+
+// SchemaError is a validation error found when validating the content of a
+// file with its JSON schema.
+type SchemaError struct {
+	// Keyword is the type of the failed validation, as reported by the schema
+	// validator, e.g. "required" or "additional_property_not_allowed".
+	Keyword string
+
+	// Field is the path of the invalid value in the document, with its elements
+	// separated by dots, e.g. "processors.0.rename". It is "(root)" for the
+	// root of the document.
+	Field string
+
+	// SchemaPath is the location in the spec of the keyword of the failed
+	// validation, as a spec file and a JSON pointer in its schema, e.g.
+	// "integration/manifest.spec.yml#/properties/name/pattern". It is empty if
+	// the location cannot be determined.
+	SchemaPath string
+
+	// Details contains the parameters of the failed validation, e.g. the
+	// "property" that is required, or the "allowed" values.
+	Details map[string]any
+
+	// Description is a human-readable description of the error.
+	Description string
+}
+
+// Error returns the message of the error, including the field.
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("field %s: %s", e.Field, e.Description)
+}
@@ -0,0 +1,79 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package specerrors
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeverity(t *testing.T) {
+	assert.True(t, SeverityError.Valid())
+	assert.True(t, SeverityWarning.Valid())
+	assert.True(t, SeverityInfo.Valid())
+	assert.False(t, Severity("critical").Valid())
+	assert.False(t, Severity("").Valid())
+
+	assert.True(t, SeverityError.AtLeast(SeverityWarning))
+	assert.True(t, SeverityWarning.AtLeast(SeverityWarning))
+	assert.False(t, SeverityInfo.AtLeast(SeverityWarning))
+	assert.False(t, SeverityWarning.AtLeast(SeverityError))
+}
+
+func TestSeverityOf(t *testing.T) {
+	err := NewStructuredErrorf("foo")
+	assert.Equal(t, SeverityError, SeverityOf(err))
+
+	warning := NewStructuredError(errors.New("foo"), "SVR00002").WithSeverity(SeverityWarning)
+	assert.Equal(t, SeverityWarning, SeverityOf(warning))
+	assert.Equal(t, SeverityWarning, SeverityOf(NewStructuredError(warning, UnassignedCode)))
+
+	report := NewPackageReport("foo", ValidationErrors{warning})
+	assert.True(t, report.Valid())
+	report = NewPackageReport("foo", ValidationErrors{warning, err})
+	assert.False(t, report.Valid())
+}
+
+func TestValidationErrorsAtLeast(t *testing.T) {
+	err := NewStructuredErrorf("foo")
+	warning := NewStructuredErrorf("bar").WithSeverity(SeverityWarning)
+	info := NewStructuredErrorf("baz").WithSeverity(SeverityInfo)
+	errs := ValidationErrors{info, err, warning}
+
+	assert.Equal(t, ValidationErrors{err}, errs.AtLeast(SeverityError))
+	assert.Equal(t, ValidationErrors{err, warning}, errs.AtLeast(SeverityWarning))
+	assert.Equal(t, errs, errs.AtLeast(SeverityInfo))
+	assert.Empty(t, ValidationErrors{info}.AtLeast(SeverityWarning))
+}
+
+func TestLoadConfigFilterSeverity(t *testing.T) {
+	fsys := fstest.MapFS{
+		"validation.yml": &fstest.MapFile{Data: []byte(`
+errors:
+  severity:
+    SVR00002: warning
+    JSE00001: info
+`)},
+	}
+
+	config, err := LoadConfigFilter(fsys)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Severity{
+		"SVR00002": SeverityWarning,
+		"JSE00001": SeverityInfo,
+	}, config.Errors.Severity)
+
+	fsys["validation.yml"] = &fstest.MapFile{Data: []byte(`
+errors:
+  severity:
+    SVR00002: critical
+`)}
+	_, err = LoadConfigFilter(fsys)
+	assert.ErrorContains(t, err, `invalid config file validation.yml: errors.severity.SVR00002: unknown severity "critical"`)
+}
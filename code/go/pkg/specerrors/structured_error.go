@@ -4,12 +4,20 @@
 
 package specerrors
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // StructuredError generic validation error
 type StructuredError struct {
-	err  error
-	code string
+	err      error
+	code     string
+	file     string
+	line     int
+	column   int
+	severity Severity
+	fix      *Fix
 }
 
 // NewStructuredError creates a generic validation error
@@ -25,6 +33,31 @@ func NewStructuredErrorf(format string, a ...any) *StructuredError {
 	return NewStructuredError(fmt.Errorf(format, a...), UnassignedCode)
 }
 
+// WithFile sets the package-relative path of the file where the error was raised.
+func (e *StructuredError) WithFile(file string) *StructuredError {
+	e.file = file
+	return e
+}
+
+// WithPosition sets the line and column, both 1-based, where the error was raised.
+func (e *StructuredError) WithPosition(line, column int) *StructuredError {
+	e.line = line
+	e.column = column
+	return e
+}
+
+// WithSeverity sets the severity of the error.
+func (e *StructuredError) WithSeverity(severity Severity) *StructuredError {
+	e.severity = severity
+	return e
+}
+
+// WithFix sets a change that solves the error.
+func (e *StructuredError) WithFix(fix Fix) *StructuredError {
+	e.fix = &fix
+	return e
+}
+
 // Error returns the message error
 func (e *StructuredError) Error() string {
 	if e.code == "" {
@@ -33,9 +66,81 @@ func (e *StructuredError) Error() string {
 	return fmt.Sprintf("%s (%s)", e.err.Error(), e.code)
 }
 
-// Code returns a unique code assigned to this error
+// Code returns a unique code assigned to this error.
+// If it was not set, the code of the wrapped error is returned, if any.
 func (e *StructuredError) Code() string {
-	return e.code
+	if e.code != "" {
+		return e.code
+	}
+	var validationErr ValidationError
+	if errors.As(e.err, &validationErr) {
+		return validationErr.Code()
+	}
+	return UnassignedCode
+}
+
+// File returns the package-relative path of the file where the error was raised.
+// If it was not set, the file of the wrapped error is returned, if any.
+func (e *StructuredError) File() string {
+	if e.file != "" {
+		return e.file
+	}
+	var pathErr ValidationPathError
+	if errors.As(e.err, &pathErr) {
+		return pathErr.File()
+	}
+	return ""
+}
+
+// Line returns the line where the error was raised, or 0 if unknown.
+// If it was not set, the line of the wrapped error is returned, if any.
+func (e *StructuredError) Line() int {
+	line, _ := e.position()
+	return line
+}
+
+// Column returns the column where the error was raised, or 0 if unknown.
+// If it was not set, the column of the wrapped error is returned, if any.
+func (e *StructuredError) Column() int {
+	_, column := e.position()
+	return column
+}
+
+func (e *StructuredError) position() (int, int) {
+	if e.line > 0 {
+		return e.line, e.column
+	}
+	var positionErr ValidationPositionError
+	if errors.As(e.err, &positionErr) {
+		return positionErr.Line(), positionErr.Column()
+	}
+	return 0, 0
+}
+
+// Severity returns the severity of the error. If it was not set, the severity
+// of the wrapped error is returned, or SeverityError by default.
+func (e *StructuredError) Severity() Severity {
+	if e.severity != "" {
+		return e.severity
+	}
+	var severityErr ValidationSeverityError
+	if errors.As(e.err, &severityErr) {
+		return severityErr.Severity()
+	}
+	return SeverityError
+}
+
+// Fix returns a change that solves the error, or nil if there is none.
+// If it was not set, the fix of the wrapped error is returned, if any.
+func (e *StructuredError) Fix() *Fix {
+	if e.fix != nil {
+		return e.fix
+	}
+	var fixErr ValidationFixError
+	if errors.As(e.err, &fixErr) {
+		return fixErr.Fix()
+	}
+	return nil
 }
 
 // Unwrap returns the wrapped error
@@ -0,0 +1,130 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package specerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type locatedError struct {
+	file         string
+	line, column int
+	severity     Severity
+}
+
+func (e locatedError) Error() string      { return "located error" }
+func (e locatedError) File() string       { return e.file }
+func (e locatedError) Line() int          { return e.line }
+func (e locatedError) Column() int        { return e.column }
+func (e locatedError) Severity() Severity { return e.severity }
+
+func TestStructuredErrorLocation(t *testing.T) {
+	err := NewStructuredErrorf("field foo: bar").
+		WithFile("data_stream/logs/manifest.yml").
+		WithPosition(3, 5)
+
+	assert.Equal(t, "field foo: bar", err.Error())
+	assert.Equal(t, UnassignedCode, err.Code())
+	assert.Equal(t, "data_stream/logs/manifest.yml", err.File())
+	assert.Equal(t, 3, err.Line())
+	assert.Equal(t, 5, err.Column())
+	assert.Equal(t, SeverityError, err.Severity())
+}
+
+func TestStructuredErrorNoLocation(t *testing.T) {
+	err := NewStructuredError(errors.New("something failed"), "SVR00001")
+
+	assert.Equal(t, "something failed (SVR00001)", err.Error())
+	assert.Empty(t, err.File())
+	assert.Zero(t, err.Line())
+	assert.Zero(t, err.Column())
+	assert.Equal(t, SeverityError, err.Severity())
+}
+
+func TestStructuredErrorWrappedLocation(t *testing.T) {
+	wrapped := locatedError{
+		file:     "manifest.yml",
+		line:     10,
+		column:   2,
+		severity: SeverityWarning,
+	}
+
+	cases := []struct {
+		title            string
+		err              *StructuredError
+		expectedFile     string
+		expectedLine     int
+		expectedColumn   int
+		expectedSeverity Severity
+	}{
+		{
+			title:            "inherited from wrapped error",
+			err:              NewStructuredError(fmt.Errorf("wrapping: %w", wrapped), UnassignedCode),
+			expectedFile:     "manifest.yml",
+			expectedLine:     10,
+			expectedColumn:   2,
+			expectedSeverity: SeverityWarning,
+		},
+		{
+			title:            "inherited from wrapped structured error",
+			err:              NewStructuredError(NewStructuredErrorf("foo").WithFile("changelog.yml").WithPosition(1, 1), "SVR00002"),
+			expectedFile:     "changelog.yml",
+			expectedLine:     1,
+			expectedColumn:   1,
+			expectedSeverity: SeverityError,
+		},
+		{
+			title: "overridden",
+			err: NewStructuredError(wrapped, UnassignedCode).
+				WithFile("docs/README.md").
+				WithPosition(4, 1).
+				WithSeverity(SeverityInfo),
+			expectedFile:     "docs/README.md",
+			expectedLine:     4,
+			expectedColumn:   1,
+			expectedSeverity: SeverityInfo,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			assert.Equal(t, c.expectedFile, c.err.File())
+			assert.Equal(t, c.expectedLine, c.err.Line())
+			assert.Equal(t, c.expectedColumn, c.err.Column())
+			assert.Equal(t, c.expectedSeverity, c.err.Severity())
+		})
+	}
+}
+
+func TestStructuredErrorWrappedCode(t *testing.T) {
+	wrapped := NewStructuredError(errors.New("field foo: bar is required"), "JSE00002")
+
+	err := NewStructuredErrorf("file \"manifest.yml\" is invalid: %w", wrapped)
+	assert.Equal(t, "JSE00002", err.Code())
+	assert.Equal(t, "file \"manifest.yml\" is invalid: field foo: bar is required (JSE00002)", err.Error())
+
+	err = NewStructuredError(fmt.Errorf("file \"manifest.yml\" is invalid: %w", wrapped), "SVR00001")
+	assert.Equal(t, "SVR00001", err.Code())
+}
+
+func TestStructuredErrorWrappedFix(t *testing.T) {
+	fix := Fix{
+		Description: "Start the document with '---'",
+		File:        "manifest.yml",
+		Edits:       []Edit{{Text: "---\n"}},
+	}
+	wrapped := NewStructuredErrorf("document dashes are required").WithFix(fix)
+
+	err := NewStructuredError(wrapped, "PSR00009")
+	if assert.NotNil(t, err.Fix()) {
+		assert.Equal(t, fix, *err.Fix())
+	}
+
+	assert.Nil(t, NewStructuredErrorf("something failed").Fix())
+}
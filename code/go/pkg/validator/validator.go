@@ -6,16 +6,25 @@ package validator
 
 import (
 	"archive/zip"
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
-	"log"
+	"log/slog"
+	"maps"
 	"os"
+	"runtime"
+	"sync"
+	"time"
 
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/elastic/package-spec/v3/code/go/internal/fspath"
 	"github.com/elastic/package-spec/v3/code/go/internal/linkedfiles"
-	"github.com/elastic/package-spec/v3/code/go/internal/packages"
+	internalpackages "github.com/elastic/package-spec/v3/code/go/internal/packages"
 	"github.com/elastic/package-spec/v3/code/go/internal/validator"
 	"github.com/elastic/package-spec/v3/code/go/internal/validator/common"
+	"github.com/elastic/package-spec/v3/code/go/pkg/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
@@ -31,11 +40,29 @@ var (
 	BuildMode Mode = validator.BuildMode
 )
 
+// Stats contains statistics about the validation of a package.
+type Stats = validator.Stats
+
+// FolderStats contains statistics about the validation of a folder against its spec.
+type FolderStats = validator.FolderStats
+
+// RuleStats contains statistics about a semantic rule.
+type RuleStats = validator.RuleStats
+
 // Validator holds the configuration for a package validation run.
 // Create one with NewValidator, then call ValidateFromPath, ValidateFromZip, or ValidateFromFS.
+// The Context variants of these methods allow to cancel the validation or to limit its duration.
 type Validator struct {
 	mode             Mode
 	warningsAsErrors bool
+	severities       map[string]specerrors.Severity
+	minimumSeverity  specerrors.Severity
+	ruleTimeout      time.Duration
+	concurrency      int
+	rules            []Rule
+	unusedExclusions bool
+	logger           *slog.Logger
+	statsHook        func(Stats)
 }
 
 // Option configures a Validator.
@@ -49,6 +76,105 @@ func WithWarningsAsErrors(enabled bool) Option {
 	return func(v *Validator) { v.warningsAsErrors = enabled }
 }
 
+// WithSeverities overrides the severity of the errors with the given codes. The
+// severities configured in the validation.yml file of the package take precedence
+// over these ones. Warnings are still promoted to errors if warnings are reported
+// as errors.
+func WithSeverities(severities map[string]specerrors.Severity) Option {
+	return func(v *Validator) {
+		if v.severities == nil {
+			v.severities = make(map[string]specerrors.Severity, len(severities))
+		}
+		maps.Copy(v.severities, severities)
+	}
+}
+
+// WithMinimumSeverity sets the minimum severity of the errors returned by the
+// validation. Errors with lower severity are only logged with the logger of the
+// validator. By default only errors with SeverityError are returned.
+func WithMinimumSeverity(severity specerrors.Severity) Option {
+	return func(v *Validator) { v.minimumSeverity = severity }
+}
+
+// WithRuleTimeout limits the time each semantic rule can spend validating a package.
+// Rules that reach the timeout, such as rules fetching remote resources, are
+// reported with a PSR00012 validation error, as their results can be incomplete.
+// No limit is applied when timeout is zero.
+func WithRuleTimeout(timeout time.Duration) Option {
+	return func(v *Validator) { v.ruleTimeout = timeout }
+}
+
+// WithConcurrency sets the maximum number of semantic rules run in parallel when
+// validating a package. Errors are reported in the same order regardless of the
+// concurrency. If n is zero or negative, the number of usable CPUs is used. By
+// default rules are run sequentially.
+func WithConcurrency(n int) Option {
+	return func(v *Validator) {
+		if n <= 0 {
+			n = runtime.GOMAXPROCS(0)
+		}
+		v.concurrency = n
+	}
+}
+
+// Rule is a custom semantic rule, run after the rules of the spec. The same
+// conditions used by the rules of the spec control when it is run.
+type Rule struct {
+	// Name identifies the rule in the statistics of the validation and in the
+	// errors of rules reaching the timeout, the name of the Validate function is
+	// used if empty.
+	Name string
+	// Validate checks the package, and returns the errors found. The package
+	// must be treated as read-only, and can be shared with other rules running
+	// in parallel.
+	Validate func(ctx context.Context, pkg *packages.Package) specerrors.ValidationErrors
+	// Since is the first version of the spec the rule is run for, if set.
+	Since *semver.Version
+	// Until is the version of the spec from which the rule is not run anymore, if set.
+	Until *semver.Version
+	// Types are the package types the rule is run for, all types if nil.
+	Types []string
+	// Modes are the validation modes the rule is run in, all modes if nil.
+	Modes []Mode
+	// Code is assigned to the errors returned by the rule without code, if set.
+	Code string
+}
+
+// WithRules adds custom semantic rules to the validation. Errors found by these
+// rules are reported along with the errors of the rules of the spec.
+func WithRules(rules ...Rule) Option {
+	return func(v *Validator) { v.rules = append(v.rules, rules...) }
+}
+
+// WithUnusedExclusions controls whether the exclusions in the validation.yml file
+// of the package that don't exclude any error are reported. They are reported as
+// errors with code PSR00004, located in their entries. Errors are not filtered by
+// the validator.
+func WithUnusedExclusions(enabled bool) Option {
+	return func(v *Validator) { v.unusedExclusions = enabled }
+}
+
+// WithLogger sets the logger used for the messages of the validation, such as
+// the errors with a severity lower than the minimum severity. Messages are
+// discarded if logger is nil, or if no logger is set.
+func WithLogger(logger *slog.Logger) Option {
+	return func(v *Validator) {
+		if logger == nil {
+			logger = slog.New(slog.DiscardHandler)
+		}
+		v.logger = logger
+	}
+}
+
+// WithStats sets a function called with the statistics of each validation, such
+// as the time spent, the files read and the errors found by each semantic rule
+// and in each folder. The errors are counted before applying severities. It is
+// not called if the validation is interrupted. Collecting the statistics adds
+// some overhead to the validation.
+func WithStats(hook func(Stats)) Option {
+	return func(v *Validator) { v.statsHook = hook }
+}
+
 // New creates a Validator for the given mode and options.
 func New(mode Mode, opts ...Option) (*Validator, error) {
 	if !mode.Valid() {
@@ -57,16 +183,33 @@ func New(mode Mode, opts ...Option) (*Validator, error) {
 	v := &Validator{
 		mode:             mode,
 		warningsAsErrors: common.IsDefinedWarningsAsErrors(),
+		minimumSeverity:  specerrors.SeverityError,
+		concurrency:      1,
+		logger:           slog.New(slog.DiscardHandler),
 	}
 	for _, opt := range opts {
 		opt(v)
 	}
+	if !v.minimumSeverity.Valid() {
+		return nil, fmt.Errorf("invalid minimum severity %q", v.minimumSeverity)
+	}
+	for code, severity := range v.severities {
+		if !severity.Valid() {
+			return nil, fmt.Errorf("invalid severity %q for code %s", severity, code)
+		}
+	}
 
 	return v, nil
 }
 
 // ValidateFromPath validates the package at path on disk.
 func (v *Validator) ValidateFromPath(path string) error {
+	return v.ValidateFromPathContext(context.Background(), path)
+}
+
+// ValidateFromPathContext validates the package at path on disk. If the context is
+// done before the validation finishes, the error of the context is returned.
+func (v *Validator) ValidateFromPathContext(ctx context.Context, path string) error {
 	fsys := os.DirFS(path)
 	if v.mode == BuildMode {
 		fsys = linkedfiles.NewBlockFS(fsys)
@@ -74,12 +217,19 @@ func (v *Validator) ValidateFromPath(path string) error {
 		fsys = linkedfiles.NewFS(path, fsys)
 	}
 
-	return v.validate(path, fsys)
+	return v.validate(ctx, path, fsys)
 }
 
 // ValidateFromZip validates the package stored in a zip file.
 // Zip files are supported in LegacyMode and BuildMode only.
 func (v *Validator) ValidateFromZip(zipPath string) error {
+	return v.ValidateFromZipContext(context.Background(), zipPath)
+}
+
+// ValidateFromZipContext validates the package stored in a zip file. If the context
+// is done before the validation finishes, the error of the context is returned.
+// Zip files are supported in LegacyMode and BuildMode only.
+func (v *Validator) ValidateFromZipContext(ctx context.Context, zipPath string) error {
 	if v.mode != LegacyMode && v.mode != BuildMode {
 		return errors.New("zip files are only supported in LegacyMode or BuildMode")
 	}
@@ -104,11 +254,18 @@ func (v *Validator) ValidateFromZip(zipPath string) error {
 	}
 
 	fsys := linkedfiles.NewBlockFS(subDir)
-	return v.validate(zipPath, fsys)
+	return v.validate(ctx, zipPath, fsys)
 }
 
 // ValidateFromFS validates the package accessible through fsys at location.
 func (v *Validator) ValidateFromFS(location string, fsys fs.FS) error {
+	return v.ValidateFromFSContext(context.Background(), location, fsys)
+}
+
+// ValidateFromFSContext validates the package accessible through fsys at location.
+// If the context is done before the validation finishes, the error of the context
+// is returned.
+func (v *Validator) ValidateFromFSContext(ctx context.Context, location string, fsys fs.FS) error {
 	if v.mode == LegacyMode {
 		// If we are not explicitly using the linkedfiles.FS, we wrap fsys with
 		// a linkedfiles.BlockFS to block the use of linked files.
@@ -121,11 +278,15 @@ func (v *Validator) ValidateFromFS(location string, fsys fs.FS) error {
 		return errors.New("block linked files are not supported in SourceMode")
 	}
 
-	return v.validate(location, fsys)
+	return v.validate(ctx, location, fsys)
 }
 
-func (v *Validator) validate(location string, fsys fs.FS) error {
-	pkg, err := packages.NewPackageFromFS(location, fsys)
+func (v *Validator) validate(ctx context.Context, location string, fsys fs.FS) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	pkg, err := internalpackages.NewPackageFromFS(location, fsys)
 	if err != nil {
 		return err
 	}
@@ -137,24 +298,133 @@ func (v *Validator) validate(location string, fsys fs.FS) error {
 	if err != nil {
 		return err
 	}
-	spec.WarningsAsErrors = v.warningsAsErrors
+	spec.RuleTimeout = v.ruleTimeout
+	spec.Concurrency = v.concurrency
+	spec.Rules = v.specRules(pkg)
+	spec.Logger = v.logger
+	if v.statsHook != nil {
+		spec.Stats = &validator.Stats{}
+	}
 
-	errs := spec.ValidatePackage(*pkg)
+	errs := spec.ValidatePackage(ctx, *pkg)
+	if err := ctx.Err(); err != nil {
+		// Errors are incomplete if the validation was interrupted.
+		return err
+	}
+	if v.statsHook != nil {
+		v.statsHook(*spec.Stats)
+	}
 
 	if v.mode != LegacyMode {
-		err := specerrors.NewStructuredErrorf("validation mode '%s' is in technical preview", v.mode)
-		if v.warningsAsErrors {
-			errs = append(errs, err)
-		} else {
-			log.Printf("Warning: %s", err.Error())
-		}
+		err := specerrors.NewStructuredError(fmt.Errorf("validation mode '%s' is in technical preview", v.mode), specerrors.CodeTechnicalPreviewMode).
+			WithSeverity(specerrors.SeverityWarning)
+		errs = append(errs, err)
 	}
+	if v.unusedExclusions {
+		errs = append(errs, unusedExclusions(fsys, errs)...)
+	}
+
+	errs = v.applySeverities(ctx, fsys, errs)
 	if len(errs) > 0 {
 		return errs
 	}
 	return nil
 }
 
+// applySeverities sets the configured severities to the given errors, and returns
+// the ones with the minimum severity. Errors with lower severity are logged with
+// the logger of the validator.
+func (v *Validator) applySeverities(ctx context.Context, fsys fs.FS, errs specerrors.ValidationErrors) specerrors.ValidationErrors {
+	severities := maps.Clone(v.severities)
+	if config, err := specerrors.LoadConfigFilter(fsys); err == nil && config != nil {
+		if severities == nil {
+			severities = make(map[string]specerrors.Severity, len(config.Errors.Severity))
+		}
+		maps.Copy(severities, config.Errors.Severity)
+	}
+
+	var result specerrors.ValidationErrors
+	for _, err := range errs {
+		severity := specerrors.SeverityOf(err)
+		if configured, found := severities[err.Code()]; found {
+			severity = configured
+		}
+		if v.warningsAsErrors && severity == specerrors.SeverityWarning {
+			severity = specerrors.SeverityError
+		}
+		if severity != specerrors.SeverityOf(err) {
+			err = specerrors.NewStructuredError(err, specerrors.UnassignedCode).WithSeverity(severity)
+		}
+
+		if !severity.AtLeast(v.minimumSeverity) {
+			v.logFinding(ctx, severity, err)
+			continue
+		}
+		result = append(result, err)
+	}
+	return result
+}
+
+// logFinding logs an error that is not returned because of its severity.
+func (v *Validator) logFinding(ctx context.Context, severity specerrors.Severity, err specerrors.ValidationError) {
+	level := slog.LevelInfo
+	if severity == specerrors.SeverityWarning {
+		level = slog.LevelWarn
+	}
+	attrs := []slog.Attr{slog.String("code", err.Code())}
+	if pathErr, ok := err.(specerrors.ValidationPathError); ok && pathErr.File() != "" {
+		attrs = append(attrs, slog.String("file", pathErr.File()))
+	}
+	v.logger.LogAttrs(ctx, level, err.Error(), attrs...)
+}
+
+// unusedExclusions returns the errors for the exclusions of the validation.yml file
+// that don't exclude any of the given errors. Problems in the file itself are
+// reported by the validation of the package.
+func unusedExclusions(fsys fs.FS, errs specerrors.ValidationErrors) specerrors.ValidationErrors {
+	config, err := specerrors.LoadConfigFilter(fsys)
+	if err != nil {
+		return nil
+	}
+	result, err := specerrors.NewFilter(config).Run(errs)
+	if err != nil {
+		return nil
+	}
+	return specerrors.UnusedExclusionsErrors(result.UnusedProcessors)
+}
+
+// specRules adapts the custom rules to validate the given package. All the rules
+// share the same view of the package, and its model with the rules of the spec.
+func (v *Validator) specRules(pkg *internalpackages.Package) []validator.Rule {
+	view := sync.OnceValues(func() (*packages.Package, error) {
+		return packages.LoadFromFS(pkg.Path(), pkg)
+	})
+
+	rules := make([]validator.Rule, len(v.rules))
+	for i, rule := range v.rules {
+		name := rule.Name
+		if name == "" {
+			name = validator.RuleFuncName(rule.Validate)
+		}
+		rules[i] = validator.Rule{
+			Name: name,
+			Validate: func(ctx context.Context, _ fspath.FS) specerrors.ValidationErrors {
+				p, err := view()
+				if err != nil {
+					return specerrors.ValidationErrors{specerrors.NewStructuredError(fmt.Errorf("failed to load package: %w", err), specerrors.CodeUnreadablePackage)}
+				}
+				return rule.Validate(ctx, p)
+			},
+			Since: rule.Since,
+			Until: rule.Until,
+			Types: rule.Types,
+			Modes: rule.Modes,
+			Code:  rule.Code,
+		}
+	}
+	return rules
+}
+
 // ValidateFromPath is a convenience function that creates a new Validator in LegacyMode and calls ValidateFromPath.
 // Deprecated: Use NewValidator and ValidateFromPath instead.
 func ValidateFromPath(path string) error {
@@ -95,7 +95,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0.type: 0.type must be one of the following: "aggregate_metric_double", "alias", "histogram", "constant_keyword", "text", "match_only_text", "keyword", "long", "integer", "short", "byte", "double", "float", "half_float", "scaled_float", "date", "date_nanos", "boolean", "binary", "integer_range", "float_range", "long_range", "double_range", "date_range", "ip_range", "group", "geo_point", "object", "ip", "nested", "flattened", "wildcard", "version", "unsigned_long", "geo_shape"`,
+				`field 0.type: 0.type must be one of the following: "aggregate_metric_double", "alias", "histogram", "constant_keyword", "text", "match_only_text", "keyword", "long", "integer", "short", "byte", "double", "float", "half_float", "scaled_float", "date", "date_nanos", "boolean", "binary", "integer_range", "float_range", "long_range", "double_range", "date_range", "ip_range", "group", "geo_point", "object", "ip", "nested", "flattened", "wildcard", "version", "unsigned_long", "geo_shape" (JSE00005)`,
 			},
 		},
 		{
@@ -108,7 +108,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0.type: 0.type must be one of the following: "aggregate_metric_double", "alias", "histogram", "constant_keyword", "text", "match_only_text", "keyword", "long", "integer", "short", "byte", "double", "float", "half_float", "scaled_float", "date", "date_nanos", "boolean", "binary", "integer_range", "float_range", "long_range", "double_range", "date_range", "ip_range", "group", "geo_point", "object", "ip", "nested", "flattened", "wildcard", "version", "unsigned_long", "geo_shape"`,
+				`field 0.type: 0.type must be one of the following: "aggregate_metric_double", "alias", "histogram", "constant_keyword", "text", "match_only_text", "keyword", "long", "integer", "short", "byte", "double", "float", "half_float", "scaled_float", "date", "date_nanos", "boolean", "binary", "integer_range", "float_range", "long_range", "double_range", "date_range", "ip_range", "group", "geo_point", "object", "ip", "nested", "flattened", "wildcard", "version", "unsigned_long", "geo_shape" (JSE00005)`,
 			},
 		},
 		{
@@ -122,7 +122,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field "my_custom_date" of type keyword can't set date_format. date_format is allowed for date field type only`,
+				`field "my_custom_date" of type keyword can't set date_format. date_format is allowed for date field type only (SVR00018)`,
 			},
 		},
 		{
@@ -135,7 +135,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0: object_type is required`,
+				`field 0: object_type is required (JSE00002)`,
 			},
 		},
 		{
@@ -155,7 +155,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0.type: 0.type must be one of the following: "group", "nested"`,
+				`field 0.type: 0.type must be one of the following: "group", "nested" (JSE00005)`,
 			},
 		},
 		{
@@ -171,7 +171,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0.enabled: 0.enabled does not match: false`,
+				`field 0.enabled: 0.enabled does not match: false (JSE00005)`,
 			},
 		},
 		{
@@ -188,7 +188,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0.enabled: 0.enabled does not match: true`,
+				`field 0.enabled: 0.enabled does not match: true (JSE00005)`,
 			},
 		},
 		{
@@ -244,7 +244,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0.type: 0.type must be one of the following: "object"`,
+				`field 0.type: 0.type must be one of the following: "object" (JSE00005)`,
 			},
 		},
 
@@ -272,7 +272,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0: metrics is required`,
+				`field 0: metrics is required (JSE00002)`,
 			},
 		},
 		{
@@ -286,7 +286,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0: default_metric is required`,
+				`field 0: default_metric is required (JSE00002)`,
 			},
 		},
 		{
@@ -301,7 +301,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0.metrics.2: 0.metrics.2 must be one of the following: "min", "max", "sum", "value_count", "avg"`,
+				`field 0.metrics.2: 0.metrics.2 must be one of the following: "min", "max", "sum", "value_count", "avg" (JSE00005)`,
 			},
 		},
 		{
@@ -316,8 +316,8 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0: Must not be present`,
-				`field 0: Must not be present`,
+				`field 0: Must not be present (JSE00006)`,
+				`field 0: Must not be present (JSE00006)`,
 			},
 		},
 
@@ -367,7 +367,7 @@ func TestValidateFields(t *testing.T) {
 			specVersion:     semver.MustParse("3.0.3"),
 			patches:         []patch{timeSeriesPatch},
 			expectedErrors: []string{
-				"time series mode enabled but no dimensions configured",
+				"time series mode enabled but no dimensions configured (SVR00026)",
 			},
 		},
 		{
@@ -383,7 +383,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0.type: 0.type must be one of the following: "histogram", "aggregate_metric_double", "long", "integer", "short", "byte", "double", "float", "half_float", "scaled_float", "unsigned_long"`,
+				`field 0.type: 0.type must be one of the following: "histogram", "aggregate_metric_double", "long", "integer", "short", "byte", "double", "float", "half_float", "scaled_float", "unsigned_long" (JSE00005)`,
 			},
 		},
 		{
@@ -398,7 +398,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0: type is required`,
+				`field 0: type is required (JSE00002)`,
 			},
 		},
 		{
@@ -415,7 +415,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field "example.agent.call_duration" of type histogram can't be a dimension, allowed types for dimensions: constant_keyword, keyword, long, integer, short, byte, double, float, half_float, scaled_float, unsigned_long, ip`,
+				`field "example.agent.call_duration" of type histogram can't be a dimension, allowed types for dimensions: constant_keyword, keyword, long, integer, short, byte, double, float, half_float, scaled_float, unsigned_long, ip (SVR00017)`,
 			},
 		},
 		{
@@ -431,7 +431,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0.type: 0.type must be one of the following: "histogram", "aggregate_metric_double", "long", "integer", "short", "byte", "double", "float", "half_float", "scaled_float", "unsigned_long"`,
+				`field 0.type: 0.type must be one of the following: "histogram", "aggregate_metric_double", "long", "integer", "short", "byte", "double", "float", "half_float", "scaled_float", "unsigned_long" (JSE00005)`,
 			},
 		},
 
@@ -463,7 +463,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0: Must not be present`,
+				`field 0: Must not be present (JSE00006)`,
 			},
 		},
 		{
@@ -477,7 +477,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0.runtime: Invalid type. Expected: string, given: integer`,
+				`field 0.runtime: Invalid type. Expected: string, given: integer (JSE00004)`,
 			},
 		},
 		{
@@ -491,7 +491,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0: Must not be present`,
+				`field 0: Must not be present (JSE00006)`,
 			},
 		},
 		{
@@ -506,7 +506,7 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				`field 0: Must not be present`,
+				`field 0: Must not be present (JSE00006)`,
 			},
 		},
 	}
@@ -6,20 +6,27 @@ package validator
 
 import (
 	"archive/zip"
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
+	"log/slog"
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/Masterminds/semver/v3"
 	cp "github.com/otiai10/copy"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/package-spec/v3/code/go/internal/linkedfiles"
+	"github.com/elastic/package-spec/v3/code/go/pkg/packages"
 	"github.com/elastic/package-spec/v3/code/go/pkg/specerrors"
 )
 
@@ -56,6 +63,7 @@ func Test_ValidateFromPath(t *testing.T) {
 		"good_alert_rule_templates":              {},
 		"good_requires":                          {},
 		"good_package_reference_policy_template": {},
+		"good_scoped_exclusions":                 {},
 		"good_datastream_categories_match":       {},
 		"good_datastream_package_categories":     {},
 		"deploy_custom_agent":                    {},
@@ -77,46 +85,46 @@ func Test_ValidateFromPath(t *testing.T) {
 		"bad_integration_group": {
 			"manifest.yml",
 			[]string{
-				"field group: Does not match pattern '^[a-z0-9_]+$'",
+				"field group: Does not match pattern '^[a-z0-9_]+$' (JSE00007)",
 			},
 		},
 		"bad_input_group": {
 			"manifest.yml",
 			[]string{
-				"field group: Does not match pattern '^[a-z0-9_]+$'",
+				"field group: Does not match pattern '^[a-z0-9_]+$' (JSE00007)",
 			},
 		},
 		"bad_duration_vars": {
 			"manifest.yml",
 			[]string{
-				`field vars.1: Must not be present`,
-				`field vars.2: Must not be present`,
+				`field vars.1: Must not be present (JSE00006)`,
+				`field vars.2: Must not be present (JSE00006)`,
 			},
 		},
 		"bad_additional_content": {
 			"bad-bad",
 			[]string{
-				"directory name inside package bad_additional_content contains -: bad-bad",
+				"directory name inside package bad_additional_content contains -: bad-bad (PSR00005)",
 			},
 		},
 		"bad_deploy_variants": {
 			"_dev/deploy/variants.yml",
 			[]string{
-				"field (root): default is required",
-				"field variants: Invalid type. Expected: object, given: array",
+				"field (root): default is required (JSE00002)",
+				"field variants: Invalid type. Expected: object, given: array (JSE00004)",
 			},
 		},
 		"missing_pipeline_dashes": {
 			"data_stream/foo/elasticsearch/ingest_pipeline/default.yml",
 			[]string{
-				"document dashes are required (start the document with '---')",
+				"document dashes are required (start the document with '---') (PSR00009)",
 			},
 		},
 		"missing_image_files": {
 			"manifest.yml",
 			[]string{
-				"field screenshots.0.src: relative path is invalid, target doesn't exist or it exceeds the file size limit",
-				"field icons.0.src: relative path is invalid, target doesn't exist or it exceeds the file size limit",
+				"field screenshots.0.src: relative path is invalid, target doesn't exist or it exceeds the file size limit (JSE00007)",
+				"field icons.0.src: relative path is invalid, target doesn't exist or it exceeds the file size limit (JSE00007)",
 			},
 		},
 		"integration_benchmarks": {},
@@ -125,25 +133,25 @@ func Test_ValidateFromPath(t *testing.T) {
 		"input_groups_bad_data_stream": {
 			"manifest.yml",
 			[]string{
-				"field policy_templates.2.data_streams.1: data stream doesn't exist",
+				"field policy_templates.2.data_streams.1: data stream doesn't exist (JSE00007)",
 			},
 		},
 		"bad_github_owner": {
 			"manifest.yml",
 			[]string{
-				"field owner.github: Does not match pattern '^(([a-zA-Z0-9-_]+)|([a-zA-Z0-9-_]+\\/[a-zA-Z0-9-_]+))$'",
+				"field owner.github: Does not match pattern '^(([a-zA-Z0-9-_]+)|([a-zA-Z0-9-_]+\\/[a-zA-Z0-9-_]+))$' (JSE00007)",
 			},
 		},
 		"bad_owner_type": {
 			"manifest.yml",
 			[]string{
-				`field owner.type: owner.type must be one of the following: "elastic", "partner", "community"`,
+				`field owner.type: owner.type must be one of the following: "elastic", "partner", "community" (JSE00005)`,
 			},
 		},
 		"bad_owner_type_missing": {
 			"manifest.yml",
 			[]string{
-				`field owner: type is required`,
+				`field owner: type is required (JSE00002)`,
 			},
 		},
 		"missing_version": {
@@ -155,19 +163,19 @@ func Test_ValidateFromPath(t *testing.T) {
 		"deploy_custom_agent_invalid_property": {
 			"_dev/deploy/agent/custom-agent.yml",
 			[]string{
-				"field services.docker-custom-agent: Must not be present",
+				"field services.docker-custom-agent: hostname is not allowed in custom agent services (JSE00006)",
 			},
 		},
 		"invalid_field_for_version": {
 			"manifest.yml",
 			[]string{
-				"field (root): Additional property license is not allowed",
+				"field (root): Additional property license is not allowed (JSE00003)",
 			},
 		},
 		"bad_release_tag": {
 			"manifest.yml",
 			[]string{
-				"field (root): Additional property release is not allowed",
+				"field (root): Additional property release is not allowed (JSE00003)",
 			},
 		},
 		"bad_datastream_categories_mismatch": {
@@ -185,68 +193,68 @@ func Test_ValidateFromPath(t *testing.T) {
 		"bad_custom_ilm_policy": {
 			"data_stream/test/manifest.yml",
 			[]string{
-				fmt.Sprintf("field ilm_policy: ILM policy \"logs-bad_custom_ilm_policy.test-notexists\" not found in package, expected definition in \"%sbad_custom_ilm_policy/data_stream/test/elasticsearch/ilm/notexists.json\"", osTestBasePath),
+				fmt.Sprintf("field ilm_policy: ILM policy \"logs-bad_custom_ilm_policy.test-notexists\" not found in package, expected definition in \"%sbad_custom_ilm_policy/data_stream/test/elasticsearch/ilm/notexists.json\" (SVR00021)", osTestBasePath),
 			},
 		},
 		"bad_select": {
 			"data_stream/foo_stream/manifest.yml",
 			[]string{
-				"field streams.0.vars.1: options is required",
-				"field streams.0.vars.3: Must not be present",
+				"field streams.0.vars.1: options is required (JSE00002)",
+				"field streams.0.vars.3: Must not be present (JSE00006)",
 			},
 		},
 		"bad_policy_api_format": {
 			"data_stream/foo/_dev/test/system/test-default-config.yml",
 			[]string{
-				"field policy_api_format: policy_api_format must be one of the following: \"legacy\", \"simplified\"",
+				"field policy_api_format: policy_api_format must be one of the following: \"legacy\", \"simplified\" (JSE00005)",
 			},
 		},
 		"bad_skip_ignored_fields": {
 			"data_stream/foo/_dev/test/system/test-default-config.yml",
 			[]string{
-				"field skip_ignored_fields: Invalid type. Expected: array, given: boolean",
+				"field skip_ignored_fields: Invalid type. Expected: array, given: boolean (JSE00004)",
 			},
 		},
 		"bad_profiling_symbolizer": {
 			"data_stream/example/manifest.yml",
 			[]string{
-				"profiles data type cannot be used in GA packages",
+				"profiles data type cannot be used in GA packages (SVR00022)",
 			},
 		},
 		"bad_secret_vars": {
 			"manifest.yml",
 			[]string{
-				"field vars.0: Additional property secret is not allowed",
+				"field vars.0: Additional property secret is not allowed (JSE00003)",
 			},
 		},
 		"bad_secret_vars_v3": {
 			"manifest.yml",
 			[]string{
-				"field vars.0: variable identified as possible secret, secret parameter required to be set to true or false",
-				"field vars.1: variable identified as possible secret, secret parameter required to be set to true or false",
+				"field vars.0: variable identified as possible secret, secret parameter required to be set to true or false (JSE00002)",
+				"field vars.1: variable identified as possible secret, secret parameter required to be set to true or false (JSE00002)",
 			},
 		},
 		"bad_lifecycle": {
 			"data_stream/test/lifecycle.yml",
 			[]string{
-				"field (root): Additional property max_age is not allowed",
+				"field (root): Additional property max_age is not allowed (JSE00003)",
 			},
 		},
 		"bad_saved_object_tags": {
 			"kibana/tags.yml",
 			[]string{
-				`field 0.asset_types.11: 0.asset_types.11 must be one of the following: "dashboard", "visualization", "search", "map", "lens", "index_pattern", "security_rule", "csp_rule_template", "alerting_rule_template", "slo_template", "ml_module", "osquery_pack_asset", "osquery_saved_query"`,
-				`field 0.asset_types.12: 0.asset_types.12 must be one of the following: "dashboard", "visualization", "search", "map", "lens", "index_pattern", "security_rule", "csp_rule_template", "alerting_rule_template", "slo_template", "ml_module", "osquery_pack_asset", "osquery_saved_query"`,
-				`field 1.asset_ids.1: Invalid type. Expected: string, given: integer`,
-				`field 2: text is required`,
-				`field 3: asset_types is required`,
+				`field 0.asset_types.11: 0.asset_types.11 must be one of the following: "dashboard", "visualization", "search", "map", "lens", "index_pattern", "security_rule", "csp_rule_template", "alerting_rule_template", "slo_template", "ml_module", "osquery_pack_asset", "osquery_saved_query" (JSE00005)`,
+				`field 0.asset_types.12: 0.asset_types.12 must be one of the following: "dashboard", "visualization", "search", "map", "lens", "index_pattern", "security_rule", "csp_rule_template", "alerting_rule_template", "slo_template", "ml_module", "osquery_pack_asset", "osquery_saved_query" (JSE00005)`,
+				`field 1.asset_ids.1: Invalid type. Expected: string, given: integer (JSE00004)`,
+				`field 2: text is required (JSE00002)`,
+				`field 3: asset_types is required (JSE00002)`,
 			},
 		},
 		"bad_dotted_fields": {
 			"manifest.yml",
 			[]string{
-				"field conditions: Additional property elastic.subscription is not allowed",
-				"field conditions: Additional property kibana.version is not allowed",
+				"field conditions: Additional property elastic.subscription is not allowed (JSE00003)",
+				"field conditions: Additional property kibana.version is not allowed (JSE00003)",
 			},
 		},
 		"bad_dangling_object_ids": {
@@ -258,248 +266,248 @@ func Test_ValidateFromPath(t *testing.T) {
 		"kibana_legacy_visualizations": {
 			"kibana/dashboard/kibana_legacy_visualizations-c36e9b90-596c-11ee-adef-4fe896364076.json",
 			[]string{
-				"\"Dashboard with mixed by-value visualizations\" contains legacy visualization: \"TSVB time series\" (timeseries, TSVB)",
-				"\"Dashboard with mixed by-value visualizations\" contains legacy visualization: \"TSVB gauge\" (gauge, TSVB)",
-				"\"Dashboard with mixed by-value visualizations\" contains legacy visualization: \"Aggs-based table\" (table, Aggs-based)",
-				"\"Dashboard with mixed by-value visualizations\" contains legacy visualization: \"Aggs-based tag cloud\" (tagcloud, Aggs-based)",
-				"\"Dashboard with mixed by-value visualizations\" contains legacy visualization: \"\" (heatmap, Aggs-based)",
-				"\"Dashboard with mixed by-value visualizations\" contains legacy visualization: \"Timelion time series\" (timelion, Timelion)",
+				"\"Dashboard with mixed by-value visualizations\" contains legacy visualization: \"TSVB time series\" (timeseries, TSVB) (SVR00025)",
+				"\"Dashboard with mixed by-value visualizations\" contains legacy visualization: \"TSVB gauge\" (gauge, TSVB) (SVR00025)",
+				"\"Dashboard with mixed by-value visualizations\" contains legacy visualization: \"Aggs-based table\" (table, Aggs-based) (SVR00025)",
+				"\"Dashboard with mixed by-value visualizations\" contains legacy visualization: \"Aggs-based tag cloud\" (tagcloud, Aggs-based) (SVR00025)",
+				"\"Dashboard with mixed by-value visualizations\" contains legacy visualization: \"\" (heatmap, Aggs-based) (SVR00025)",
+				"\"Dashboard with mixed by-value visualizations\" contains legacy visualization: \"Timelion time series\" (timelion, Timelion) (SVR00025)",
 			},
 		},
 		"bad_provider_permissions": {
 			"manifest.yml",
 			[]string{
-				`field provider_permissions.0: provider is required`,
+				`field provider_permissions.0: provider is required (JSE00002)`,
 			},
 		},
 		"bad_provider_permissions_missing_name": {
 			"manifest.yml",
 			[]string{
-				`field provider_permissions.0.permissions.0: name is required`,
+				`field provider_permissions.0.permissions.0: name is required (JSE00002)`,
 			},
 		},
 		"bad_provider_permissions_extra_field": {
 			"manifest.yml",
 			[]string{
-				`field provider_permissions.0.permissions.0: Additional property resources is not allowed`,
+				`field provider_permissions.0.permissions.0: Additional property resources is not allowed (JSE00003)`,
 			},
 		},
 		"bad_deployment_mode": {
 			"manifest.yml",
 			[]string{
-				`field policy_templates.0.deployment_modes: Additional property default is not allowed`,
-				`field policy_templates.0.inputs.0.vars.0.hide_in_deployment_modes.0: policy_templates.0.inputs.0.vars.0.hide_in_deployment_modes.0 must be one of the following: "agentless"`,
+				`field policy_templates.0.deployment_modes: Additional property default is not allowed (JSE00003)`,
+				`field policy_templates.0.inputs.0.vars.0.hide_in_deployment_modes.0: policy_templates.0.inputs.0.vars.0.hide_in_deployment_modes.0 must be one of the following: "agentless" (JSE00005)`,
 			},
 		},
 		"bad_deployment_mode_without_identities": {
 			"manifest.yml",
 			[]string{
-				`field policy_templates.0.deployment_modes.agentless: organization is required`,
-				`field policy_templates.0.deployment_modes.agentless: division is required`,
-				`field policy_templates.0.deployment_modes.agentless: team is required`,
+				`field policy_templates.0.deployment_modes.agentless: organization is required (JSE00002)`,
+				`field policy_templates.0.deployment_modes.agentless: division is required (JSE00002)`,
+				`field policy_templates.0.deployment_modes.agentless: team is required (JSE00002)`,
 			},
 		},
 		"bad_deployment_mode_resources": {
 			"manifest.yml",
 			[]string{
-				`field policy_templates.0.deployment_modes.agentless.resources.requests: Additional property disk is not allowed`,
+				`field policy_templates.0.deployment_modes.agentless.resources.requests: Additional property disk is not allowed (JSE00003)`,
 			},
 		},
 		"bad_requires": {
 			"manifest.yml",
 			[]string{
-				`field requires.content.0.package: Does not match pattern '^[a-z0-9_]+$'`,
-				`field requires.input.0: version is required`,
-				`field requires.input.1.version: version "^1.0.0" for package "filelog_otel" must be a valid semantic version, constraints are not allowed`,
+				`field requires.content.0.package: Does not match pattern '^[a-z0-9_]+$' (JSE00007)`,
+				`field requires.input.0: version is required (JSE00002)`,
+				`field requires.input.1.version: version "^1.0.0" for package "filelog_otel" must be a valid semantic version, constraints are not allowed (SVR00044)`,
 			},
 		},
 		"bad_requires_old_version": {
 			"manifest.yml",
 			[]string{
-				`field (root): Additional property requires is not allowed`,
+				`field (root): Additional property requires is not allowed (JSE00003)`,
 			},
 		},
 		"bad_package_field_old_version": {
 			"manifest.yml",
 			[]string{
-				`field policy_templates.0.inputs.0: type is required`,
-				`field policy_templates.0.inputs.0: Additional property package is not allowed`,
+				`field policy_templates.0.inputs.0: type is required (JSE00002)`,
+				`field policy_templates.0.inputs.0: Additional property package is not allowed (JSE00003)`,
 			},
 		},
 		"bad_datastream_package_old_version": {
 			"data_stream/logs/manifest.yml",
 			[]string{
-				`field streams.0: input is required`,
-				`field streams.0: Additional property package is not allowed`,
+				`field streams.0: input is required (JSE00002)`,
+				`field streams.0: Additional property package is not allowed (JSE00003)`,
 			},
 		},
 		"bad_package_not_in_requires": {
 			"manifest.yml",
 			[]string{
-				`policy_templates[0].inputs[0] references package "missing_package" which is not listed in requires section`,
+				`policy_templates[0].inputs[0] references package "missing_package" which is not listed in requires section (SVR00044)`,
 			},
 		},
 		"bad_test_requires": {
 			"_dev/test/config.yml",
 			[]string{
-				`policy.requires[0] references package "missing_package" which is not listed in manifest requires section`,
-				`system.requires[0] package "sql_input" version "1.5.0" does not satisfy constraint "2.0.0"`,
+				`policy.requires[0] references package "missing_package" which is not listed in manifest requires section (SVR00045)`,
+				`system.requires[0] package "sql_input" version "1.5.0" does not satisfy constraint "2.0.0" (SVR00045)`,
 			},
 		},
 		"bad_policy_ignore_fields": {
 			"_dev/test/config.yml",
 			[]string{
-				"field system: Additional property ignore_fields is not allowed",
+				"field system: Additional property ignore_fields is not allowed (JSE00003)",
 			},
 		},
 		"bad_input_dataset_vars": {
 			"_dev/test/policy/test-vars.yml",
 			[]string{
-				`field vars.data_stream.dataset: Does not match pattern '^[a-zA-Z0-9]+[a-zA-Z0-9\._]*$'`,
+				`field vars.data_stream.dataset: Does not match pattern '^[a-zA-Z0-9]+[a-zA-Z0-9\._]*$' (JSE00007)`,
 			},
 		},
 		"bad_integration_dataset_vars": {
 			"data_stream/datasets/_dev/test/system/test-vars-config.yml",
 			[]string{
-				`field vars.data_stream.dataset: Does not match pattern '^[a-zA-Z0-9]+[a-zA-Z0-9\._]*$'`,
-				`field data_stream.vars.data_stream.dataset: Does not match pattern '^[a-zA-Z0-9]+[a-zA-Z0-9\._]*$'`,
+				`field vars.data_stream.dataset: Does not match pattern '^[a-zA-Z0-9]+[a-zA-Z0-9\._]*$' (JSE00007)`,
+				`field data_stream.vars.data_stream.dataset: Does not match pattern '^[a-zA-Z0-9]+[a-zA-Z0-9\._]*$' (JSE00007)`,
 			},
 		},
 		"bad_missing_capability_security_rules": {
 			"manifest.yml",
 			[]string{
-				"found security rule assets in package but security capability is missing",
+				"found security rule assets in package but security capability is missing (SVR00027)",
 			},
 		},
 		"bad_policy_template_behavior": {
 			"manifest.yml",
 			[]string{
-				"field policy_templates_behavior: policy_templates_behavior must be one of the following: \"all\"",
+				"field policy_templates_behavior: policy_templates_behavior must be one of the following: \"all\" (JSE00005)",
 			},
 		},
 		"bad_configuration_links": {
 			"manifest.yml",
 			[]string{
-				"field policy_templates.0.configuration_links: Array must have at least 1 items",
-				"field policy_templates.1.configuration_links.0: url is required",
-				"field policy_templates.1.configuration_links.1.url: Does not match pattern '^(http(s)?://|kbn:/)'",
-				"field policy_templates.1.configuration_links.2.url: Does not match pattern '^(http(s)?://|kbn:/)'",
+				"field policy_templates.0.configuration_links: Array must have at least 1 items (JSE00008)",
+				"field policy_templates.1.configuration_links.0: url is required (JSE00002)",
+				"field policy_templates.1.configuration_links.1.url: Does not match pattern '^(http(s)?://|kbn:/)' (JSE00007)",
+				"field policy_templates.1.configuration_links.2.url: Does not match pattern '^(http(s)?://|kbn:/)' (JSE00007)",
 			},
 		},
 		"bad_required_vars": {
 			"manifest.yml",
 			[]string{
-				`field policy_templates.0.inputs.0.required_vars.password.1: name is required`,
-				`required var "api_key" in optional group is defined as always required`,
-				`required var "password" in optional group is not defined`,
+				`field policy_templates.0.inputs.0.required_vars.password.1: name is required (JSE00002)`,
+				`required var "api_key" in optional group is defined as always required (SVR00028)`,
+				`required var "password" in optional group is not defined (SVR00028)`,
 			},
 		},
 		"bad_required_vars_data_streams": {
 			"data_stream/test/manifest.yml",
 			[]string{
-				`field streams.0.required_vars.empty_name.0: name is required`,
-				`required var "api_key" in optional group is defined as always required`,
-				`required var "password" in optional group is not defined`,
+				`field streams.0.required_vars.empty_name.0: name is required (JSE00002)`,
+				`required var "api_key" in optional group is defined as always required (SVR00028)`,
+				`required var "password" in optional group is not defined (SVR00028)`,
 			},
 		},
 		"bad_var_groups_missing_var": {
 			"manifest.yml",
 			[]string{
-				`var "non_existent_var" referenced in var_group "credential_type" option "direct_access_key" is not defined`,
+				`var "non_existent_var" referenced in var_group "credential_type" option "direct_access_key" is not defined (SVR00029)`,
 			},
 		},
 		"bad_sections_undefined_ref": {
 			"manifest.yml",
 			[]string{
-				`var "secret_access_key" references undefined section "nonexistent_section" in package root`,
+				`var "secret_access_key" references undefined section "nonexistent_section" in package root (SVR00030)`,
 			},
 		},
 		"bad_sections_duplicate_name": {
 			"manifest.yml",
 			[]string{
-				`duplicate section name "auth_section" in package root`,
+				`duplicate section name "auth_section" in package root (SVR00030)`,
 			},
 		},
 		"bad_var_groups_duplicate_name": {
 			"manifest.yml",
 			[]string{
-				`duplicate option name "direct_access_key" in var_group "credential_type"`,
+				`duplicate option name "direct_access_key" in var_group "credential_type" (SVR00029)`,
 			},
 		},
 		"bad_var_groups_required_var_in_required_group": {
 			"manifest.yml",
 			[]string{
-				`var "access_key_id" in required var_group "credential_type" should not have required: true (requirement is inferred from var_group)`,
+				`var "access_key_id" in required var_group "credential_type" should not have required: true (requirement is inferred from var_group) (SVR00029)`,
 			},
 		},
 		"bad_var_groups_required_var_in_optional_group": {
 			"manifest.yml",
 			[]string{
-				`var "access_key_id" in non-required var_group "credential_type" should not have required: true (var_group is optional)`,
+				`var "access_key_id" in non-required var_group "credential_type" should not have required: true (var_group is optional) (SVR00029)`,
 			},
 		},
 		"bad_agentless_release": {
 			"manifest.yml",
 			[]string{
-				`policy template "test" sets agentless.release but agentless is the only deployment mode; use the package version to indicate maturity instead`,
+				`policy template "test" sets agentless.release but agentless is the only deployment mode; use the package version to indicate maturity instead (SVR00032)`,
 			},
 		},
 		"bad_input_deployment_modes": {
 			"manifest.yml",
 			[]string{
-				`field policy_templates.0.inputs.0.deployment_modes.0: policy_templates.0.inputs.0.deployment_modes.0 must be one of the following: "default", "agentless"`,
-				`field policy_templates.0.inputs.1.deployment_modes: Array must have at least 1 items`,
-				`field policy_templates.0.inputs.2.deployment_modes: array items[0,1] must be unique`,
-				`input "test/metrics" in policy template "test" specifies unsupported deployment mode "invalid_mode"`,
-				`input "test/system" in policy template "test" specifies unsupported deployment mode "agentless"`,
-				`policy template "unsupported_modes" enables deployment mode "default" but no input supports this mode`,
+				`field policy_templates.0.inputs.0.deployment_modes.0: policy_templates.0.inputs.0.deployment_modes.0 must be one of the following: "default", "agentless" (JSE00005)`,
+				`field policy_templates.0.inputs.1.deployment_modes: Array must have at least 1 items (JSE00008)`,
+				`field policy_templates.0.inputs.2.deployment_modes: array items[0,1] must be unique (JSE00009)`,
+				`input "test/metrics" in policy template "test" specifies unsupported deployment mode "invalid_mode" (SVR00032)`,
+				`input "test/system" in policy template "test" specifies unsupported deployment mode "agentless" (SVR00032)`,
+				`policy template "unsupported_modes" enables deployment mode "default" but no input supports this mode (SVR00032)`,
 			},
 		},
 		"bad_discovery_fields": {
 			"manifest.yml",
 			[]string{
-				"field discovery.fields.0.name: Invalid type. Expected: string, given: integer",
-				"field discovery.fields.1: name is required",
-				"field discovery.fields.2: name is required",
-				"field discovery.fields.2: Additional property value is not allowed",
-				"field discovery.datasets.0.name: Invalid type. Expected: string, given: integer",
-				"field discovery.datasets.1: Additional property foo is not allowed",
+				"field discovery.fields.0.name: Invalid type. Expected: string, given: integer (JSE00004)",
+				"field discovery.fields.1: name is required (JSE00002)",
+				"field discovery.fields.2: name is required (JSE00002)",
+				"field discovery.fields.2: Additional property value is not allowed (JSE00003)",
+				"field discovery.datasets.0.name: Invalid type. Expected: string, given: integer (JSE00004)",
+				"field discovery.datasets.1: Additional property foo is not allowed (JSE00003)",
 			},
 		},
 		"bad_input_otel_old_version": {
 			"manifest.yml",
 			[]string{
-				"field policy_templates.0.input: Must not be present",
+				"field policy_templates.0.input: Must not be present (JSE00006)",
 			},
 		},
 		"bad_input_profiles_non_otel": {
 			"manifest.yml",
 			[]string{
-				"field policy_templates.0.input: policy_templates.0.input must be one of the following: \"otelcol\"",
+				"field policy_templates.0.input: policy_templates.0.input must be one of the following: \"otelcol\" (JSE00005)",
 			},
 		},
 		"bad_input_dynamic_signal_types_non_otel": {
 			"manifest.yml",
 			[]string{
-				"policy template \"sample\": dynamic_signal_types is only allowed when input is 'otelcol', got 'logfile'",
+				"policy template \"sample\": dynamic_signal_types is only allowed when input is 'otelcol', got 'logfile' (SVR00035)",
 			},
 		},
 		"bad_integration_dynamic_signal_types": {
 			"manifest.yml",
 			[]string{
-				"field policy_templates.0: Additional property dynamic_signal_types is not allowed",
+				"field policy_templates.0: Additional property dynamic_signal_types is not allowed (JSE00003)",
 			},
 		},
 		"bad_integration_dynamic_signal_types_non_otel": {
 			"manifest.yml",
 			[]string{
-				"policy template \"sample\": input type \"logfile\": dynamic_signal_types is only allowed when input is 'otelcol'",
+				"policy template \"sample\": input type \"logfile\": dynamic_signal_types is only allowed when input is 'otelcol' (SVR00035)",
 			},
 		},
 		"bad_integration_otel_old_version": {
 			"manifest.yml",
 			[]string{
-				"field policy_templates.0.inputs.0.type: Must not be present",
+				"field policy_templates.0.inputs.0.type: Must not be present (JSE00006)",
 			},
 		},
 		"bad_input_qualifier_ambiguous": {
@@ -511,85 +519,85 @@ func Test_ValidateFromPath(t *testing.T) {
 		"bad_input_qualifier_old_version": {
 			"manifest.yml",
 			[]string{
-				"field policy_templates.0.inputs.0.type: Must not be present",
-				"field policy_templates.0.inputs.0: Additional property name is not allowed",
+				"field policy_templates.0.inputs.0.type: Must not be present (JSE00006)",
+				"field policy_templates.0.inputs.0: Additional property name is not allowed (JSE00003)",
 			},
 		},
 		"bad_input_dynamic_signal_types_old_version": {
 			"manifest.yml",
 			[]string{
-				"field policy_templates.0: Additional property dynamic_signal_types is not allowed",
+				"field policy_templates.0: Additional property dynamic_signal_types is not allowed (JSE00003)",
 			},
 		},
 		"bad_input_dynamic_signal_type_with_type": {
 			"manifest.yml",
 			[]string{
-				"policy template \"otel_logs\": type field must not be set when dynamic_signal_types is true",
+				"policy template \"otel_logs\": type field must not be set when dynamic_signal_types is true (SVR00035)",
 			},
 		},
 		"bad_input_fleet_reserved_vars": {
 			"manifest.yml",
 			[]string{
-				"package root vars: variable \"data_stream.dataset\" must only be declared at stream level",
-				"policy template \"sample\": variable \"use_apm\" must be type \"bool\", got \"text\"",
-				"policy template \"sample\": variable \"data_stream.dataset\" must be type \"text\", got \"bool\"",
+				"package root vars: variable \"data_stream.dataset\" must only be declared at stream level (SVR00036)",
+				"policy template \"sample\": variable \"use_apm\" must be type \"bool\", got \"text\" (SVR00036)",
+				"policy template \"sample\": variable \"data_stream.dataset\" must be type \"text\", got \"bool\" (SVR00036)",
 			},
 		},
 		"bad_integration_fleet_reserved_vars": {
 			"data_stream/sample/manifest.yml",
 			[]string{
-				"stream with input type \"otelcol\": variable \"use_apm\" must be type \"bool\", got \"text\"",
-				"stream with input type \"otelcol\": variable \"use_apm\" must be \"traces\" data stream type or \"dynamic_signal_types: true\", got \"logs\" data stream type",
-				"stream with input type \"logfile\": variable \"use_apm\" must be \"otelcol\" input, got \"logfile\"",
-				"stream with input type \"logfile\": variable \"use_apm\" must be \"traces\" data stream type or \"dynamic_signal_types: true\", got \"logs\" data stream type",
-				"stream with input type \"logfile\": variable \"data_stream.dataset\" must be type \"text\", got \"yaml\"",
+				"stream with input type \"otelcol\": variable \"use_apm\" must be type \"bool\", got \"text\" (SVR00036)",
+				"stream with input type \"otelcol\": variable \"use_apm\" must be \"traces\" data stream type or \"dynamic_signal_types: true\", got \"logs\" data stream type (SVR00036)",
+				"stream with input type \"logfile\": variable \"use_apm\" must be \"otelcol\" input, got \"logfile\" (SVR00036)",
+				"stream with input type \"logfile\": variable \"use_apm\" must be \"traces\" data stream type or \"dynamic_signal_types: true\", got \"logs\" data stream type (SVR00036)",
+				"stream with input type \"logfile\": variable \"data_stream.dataset\" must be type \"text\", got \"yaml\" (SVR00036)",
 			},
 		},
 		"bad_input_template_path": {
 			"manifest.yml",
 			[]string{
-				"field policy_templates.0: template_path is required",
-				"policy template \"sql_query\" references template_path \"\": template_path is required for input type packages",
+				"field policy_templates.0: template_path is required (JSE00002)",
+				"policy template \"sql_query\" references template_path \"\": template_path is required for input type packages (SVR00034)",
 			},
 		},
 		"bad_input_both_template_path": {
 			"manifest.yml",
 			[]string{
-				"field policy_templates.0: Must not be present",
-				"policy template \"sample\" references template_path \"input.yml.hbs\": template file not found",
+				"field policy_templates.0: Must not be present (JSE00006)",
+				"policy template \"sample\" references template_path \"input.yml.hbs\": template file not found (SVR00034)",
 			},
 		},
 		"bad_agent_version_v3": {
 			"manifest.yml",
 			[]string{
-				"invalid agent.version condition: improper constraint: \"version\"",
+				"invalid agent.version condition: improper constraint: \"version\" (SVR00037)",
 			},
 		},
 		"bad_integration_stream_template_path": {
 			"data_stream/datasets/manifest.yml",
 			[]string{
-				"data stream \"data_stream/datasets\" stream input \"logfile\": template file not found",
+				"data stream \"data_stream/datasets\" stream input \"logfile\": template file not found (SVR00038)",
 			},
 		},
 		"bad_integration_stream_template_path_default": {
 			"data_stream/datasets/manifest.yml",
 			[]string{
-				"data stream \"data_stream/datasets\" stream input \"logfile\": template file not found",
+				"data stream \"data_stream/datasets\" stream input \"logfile\": template file not found (SVR00038)",
 			},
 		},
 		"bad_integration_input_template_path": {
 			"manifest.yml",
 			[]string{
-				"policy template \"sample\": failed validation for policy input \"logfile\": template file not found",
+				"policy template \"sample\": failed validation for policy input \"logfile\": template file not found (SVR00038)",
 			},
 		},
 		"bad_esql_view_content": {
 			"elasticsearch/esql_view/view.yml",
-			[]string{"field query: Invalid type. Expected: string, given: null"},
+			[]string{"field query: Invalid type. Expected: string, given: null (JSE00004)"},
 		},
 		"bad_esql_view_integration": {
 			"elasticsearch/esql_view/view.yml",
-			[]string{"field query: Invalid type. Expected: string, given: null"},
+			[]string{"field query: Invalid type. Expected: string, given: null (JSE00004)"},
 		},
 		"bad_content_duplicate_tags": {
 			"kibana/tags.yml",
@@ -609,39 +617,39 @@ func Test_ValidateFromPath(t *testing.T) {
 		"good_var_migrate_from":               {},
 		"bad_migrate_from": {
 			"manifest.yml",
-			[]string{`field policy_templates.0.inputs.0: Additional property migrate_from is not allowed`},
+			[]string{`field policy_templates.0.inputs.0: Additional property migrate_from is not allowed (JSE00003)`},
 		},
 		"bad_var_migrate_from": {
 			"data_stream/logs/manifest.yml",
-			[]string{`field streams.0.vars.1: Additional property migrate_from is not allowed`},
+			[]string{`field streams.0.vars.1: Additional property migrate_from is not allowed (JSE00003)`},
 		},
 		"bad_var_migrate_from_scope": {
 			"data_stream/logs/manifest.yml",
-			[]string{`field streams.0.vars.1.migrate_from.scope: streams.0.vars.1.migrate_from.scope must be one of the following: "input", "stream"`},
+			[]string{`field streams.0.vars.1.migrate_from.scope: streams.0.vars.1.migrate_from.scope must be one of the following: "input", "stream" (JSE00005)`},
 		},
 		"bad_deprecation_description": {
 			"manifest.yml",
-			[]string{"field deprecated.description: Invalid type. Expected: string, given: null"},
+			[]string{"field deprecated.description: Invalid type. Expected: string, given: null (JSE00004)"},
 		},
 		"bad_deprecation_since": {
 			"manifest.yml",
-			[]string{"field deprecated: since is required"},
+			[]string{"field deprecated: since is required (JSE00002)"},
 		},
 		"bad_deprecated_integration_policy_input": {
 			"manifest.yml",
-			[]string{"all inputs are deprecated but the integration package is not marked as deprecated"},
+			[]string{"all inputs are deprecated but the integration package is not marked as deprecated (SVR00042)"},
 		},
 		"good_deployer_system_benchmark": {},
 		"bad_deployer_system_benchmark": {
 			"_dev/benchmark/system/alert-benchmark.yml",
 			[]string{
-				"field deployer: deployer must be one of the following: \"docker\", \"tf\", \"k8s\"",
+				"field deployer: deployer must be one of the following: \"docker\", \"tf\", \"k8s\" (JSE00005)",
 			},
 		},
 		"bad_deployer_system_test": {
 			"data_stream/foo/_dev/test/system/test-default-config.yml",
 			[]string{
-				"field deployer: deployer must be one of the following: \"docker\", \"tf\", \"k8s\"",
+				"field deployer: deployer must be one of the following: \"docker\", \"tf\", \"k8s\" (JSE00005)",
 			},
 		},
 	}
@@ -731,7 +739,7 @@ func TestValidateItemNotAllowed(t *testing.T) {
 	for pkgName, invalidItemsPerFolder := range tests {
 		t.Run(pkgName, func(t *testing.T) {
 			t.Parallel()
-			requireErrorMessage(t, pkgName, invalidItemsPerFolder, "item [%s] is not allowed in folder [%s/%s]")
+			requireErrorMessage(t, pkgName, invalidItemsPerFolder, "item [%s] is not allowed in folder [%s/%s] (PSR00005)")
 		})
 	}
 }
@@ -748,7 +756,7 @@ func TestValidateItemNotExpected(t *testing.T) {
 	for pkgName, invalidItemsPerFolder := range tests {
 		t.Run(pkgName, func(t *testing.T) {
 			t.Parallel()
-			requireErrorMessage(t, pkgName, invalidItemsPerFolder, "item [%s] is not allowed in folder [%s/%s]")
+			requireErrorMessage(t, pkgName, invalidItemsPerFolder, "item [%s] is not allowed in folder [%s/%s] (PSR00005)")
 		})
 	}
 }
@@ -803,7 +811,7 @@ func TestValidateBadKibanaIDs(t *testing.T) {
 
 func TestValidateBadRuleIDs(t *testing.T) {
 	tests := map[string]string{
-		"bad_rule_ids": "kibana object ID [saved_object_id] should start with rule ID [rule_id]",
+		"bad_rule_ids": "kibana object ID [saved_object_id] should start with rule ID [rule_id] (SVR00023)",
 	}
 
 	for pkgName, expectedError := range tests {
@@ -829,14 +837,14 @@ func TestValidateMissingRequiredFields(t *testing.T) {
 		"good_v2":    {},
 		"good_input": {},
 		"missing_required_fields": {
-			`expected type "constant_keyword" for required field "data_stream.dataset", found "keyword" in "../../../../test/packages/missing_required_fields/data_stream/foo/fields/base-fields.yml"`,
-			`expected field "data_stream.type" with type "constant_keyword" not found in datastream "foo"`,
+			`expected type "constant_keyword" for required field "data_stream.dataset", found "keyword" in "../../../../test/packages/missing_required_fields/data_stream/foo/fields/base-fields.yml" (SVR00019)`,
+			`expected field "data_stream.type" with type "constant_keyword" not found in datastream "foo" (SVR00019)`,
 			// `expected field "data_stream.namespace" with type "constant_keyword" not found in transform "good_example_abc_1"`,
 			// `expected type "date" for required field "@timestamp", found "long" in "../../../../test/packages/missing_required_fields/elasticsearch/transform/good_example_abc_1/fields/base-fields.yml"`,
 		},
 		"missing_required_fields_input": {
-			`expected type "constant_keyword" for required field "data_stream.dataset", found "keyword" in "../../../../test/packages/missing_required_fields_input/fields/base-fields.yml"`,
-			`expected field "data_stream.type" with type "constant_keyword" not found`,
+			`expected type "constant_keyword" for required field "data_stream.dataset", found "keyword" in "../../../../test/packages/missing_required_fields_input/fields/base-fields.yml" (SVR00019)`,
+			`expected field "data_stream.type" with type "constant_keyword" not found (SVR00019)`,
 		},
 	}
 
@@ -873,8 +881,8 @@ func TestValidateMissingRequiredFields(t *testing.T) {
 
 func TestValidateVersionIntegrity(t *testing.T) {
 	tests := map[string]string{
-		"inconsistent_version": "current manifest version doesn't have changelog entry",
-		"same_version_twice":   "versions in changelog must be unique, found at least two same versions (0.0.2)",
+		"inconsistent_version": "current manifest version doesn't have changelog entry (SVR00011)",
+		"same_version_twice":   "versions in changelog must be unique, found at least two same versions (0.0.2) (SVR00011)",
 	}
 
 	for pkgName, expectedErrorMessage := range tests {
@@ -900,11 +908,11 @@ func TestValidateDuplicatedFields(t *testing.T) {
 		"good_v2":    {},
 		"good_input": {},
 		"bad_duplicated_fields": {
-			"field \"event.dataset\" is defined multiple times for data stream \"wrong\", found in: ../../../../test/packages/bad_duplicated_fields/data_stream/wrong/fields/base-fields.yml, ../../../../test/packages/bad_duplicated_fields/data_stream/wrong/fields/ecs.yml",
-			"field \"field1\" is defined multiple times for transform \"good_example_abc_1\", found in: ../../../../test/packages/bad_duplicated_fields/elasticsearch/transform/good_example_abc_1/fields/fields.yml, ../../../../test/packages/bad_duplicated_fields/elasticsearch/transform/good_example_abc_1/fields/more-fields.yml",
+			"field \"event.dataset\" is defined multiple times for data stream \"wrong\", found in: ../../../../test/packages/bad_duplicated_fields/data_stream/wrong/fields/base-fields.yml, ../../../../test/packages/bad_duplicated_fields/data_stream/wrong/fields/ecs.yml (SVR00016)",
+			"field \"field1\" is defined multiple times for transform \"good_example_abc_1\", found in: ../../../../test/packages/bad_duplicated_fields/elasticsearch/transform/good_example_abc_1/fields/fields.yml, ../../../../test/packages/bad_duplicated_fields/elasticsearch/transform/good_example_abc_1/fields/more-fields.yml (SVR00016)",
 		},
 		"bad_duplicated_fields_input": {
-			"field \"event.dataset\" is defined multiple times, found in: ../../../../test/packages/bad_duplicated_fields_input/fields/base-fields.yml, ../../../../test/packages/bad_duplicated_fields_input/fields/ecs.yml",
+			"field \"event.dataset\" is defined multiple times, found in: ../../../../test/packages/bad_duplicated_fields_input/fields/base-fields.yml, ../../../../test/packages/bad_duplicated_fields_input/fields/ecs.yml (SVR00016)",
 		},
 	}
 
@@ -944,16 +952,16 @@ func TestValidateMinimumKibanaVersions(t *testing.T) {
 		"good_input": {},
 		"good_v2":    {},
 		"custom_logs": {
-			"conditions.kibana.version must be ^8.8.0 or greater for non experimental input packages (version > 1.0.0)",
+			"conditions.kibana.version must be ^8.8.0 or greater for non experimental input packages (version > 1.0.0) (SVR00005)",
 		},
 		"httpjson_input": {
-			"conditions.kibana.version must be ^8.8.0 or greater for non experimental input packages (version > 1.0.0)",
+			"conditions.kibana.version must be ^8.8.0 or greater for non experimental input packages (version > 1.0.0) (SVR00005)",
 		},
 		"sql_input": {
-			"conditions.kibana.version must be ^8.8.0 or greater for non experimental input packages (version > 1.0.0)",
+			"conditions.kibana.version must be ^8.8.0 or greater for non experimental input packages (version > 1.0.0) (SVR00005)",
 		},
 		"bad_runtime_kibana_version": {
-			"conditions.kibana.version must be ^8.10.0 or greater to include runtime fields",
+			"conditions.kibana.version must be ^8.10.0 or greater to include runtime fields (SVR00005)",
 		},
 	}
 
@@ -1000,7 +1008,7 @@ func TestValidateWarnings(t *testing.T) {
 			"conditions.kibana.version must be ^8.10.0 or greater to include saved object tags file: kibana/tags.yml (SVR00005)",
 		},
 		"bad_readme_structure": {
-			"missing required section 'Overview' in file 'README_part1.md'\nmissing required section 'How do I deploy this integration?' in file 'README_part2.md'",
+			"missing required section 'Overview' in file 'README_part1.md'\nmissing required section 'How do I deploy this integration?' in file 'README_part2.md' (SVR00031)",
 		},
 		"good_readme_structure": {},
 	}
@@ -1053,7 +1061,7 @@ func TestValidateExternalFieldsWithoutDevFolder(t *testing.T) {
 			"data_stream/foo/fields/ecs.yml",
 			"",
 			[]string{
-				"field container.id with external key defined (\"ecs\") but no _dev/build/build.yml found",
+				"field container.id with external key defined (\"ecs\") but no _dev/build/build.yml found (SVR00020)",
 			},
 		},
 		{
@@ -1061,7 +1069,7 @@ func TestValidateExternalFieldsWithoutDevFolder(t *testing.T) {
 			"data_stream/foo/fields/ecs.yml",
 			"dependencies: {}\n",
 			[]string{
-				"field container.id with external key defined (\"ecs\") but no definition found for it (_dev/build/build.yml)",
+				"field container.id with external key defined (\"ecs\") but no definition found for it (_dev/build/build.yml) (SVR00020)",
 			},
 		},
 	}
@@ -1123,16 +1131,16 @@ func TestValidateRoutingRules(t *testing.T) {
 		"good":    {},
 		"good_v2": {},
 		"bad_routing_rules": {
-			`routing rules defined in data stream "rules" but dataset field is missing: dataset field is required in manifest for data stream "rules"`,
+			`routing rules defined in data stream "rules" but dataset field is missing: dataset field is required in manifest for data stream "rules" (SVR00024)`,
 		},
 		"bad_routing_rules_wrong_spec": {
-			`item [routing_rules.yml] is not allowed in folder [../../../../test/packages/bad_routing_rules_wrong_spec/data_stream/rules]`,
+			`item [routing_rules.yml] is not allowed in folder [../../../../test/packages/bad_routing_rules_wrong_spec/data_stream/rules] (PSR00005)`,
 		},
 		"bad_routing_rules_missing_if": {
-			`file "../../../../test/packages/bad_routing_rules_missing_if/data_stream/rules/routing_rules.yml" is invalid: field 0.rules.0: if is required`,
+			`file "../../../../test/packages/bad_routing_rules_missing_if/data_stream/rules/routing_rules.yml" is invalid: field 0.rules.0: if is required (JSE00002)`,
 		},
 		"bad_routing_rules_missing_target_dataset": {
-			`file "../../../../test/packages/bad_routing_rules_missing_target_dataset/data_stream/rules/routing_rules.yml" is invalid: field 0.rules.0: target_dataset is required`,
+			`file "../../../../test/packages/bad_routing_rules_missing_target_dataset/data_stream/rules/routing_rules.yml" is invalid: field 0.rules.0: target_dataset is required (JSE00002)`,
 		},
 	}
 
@@ -1163,8 +1171,8 @@ func TestValidateIngestPipelines(t *testing.T) {
 		"skip_pipeline_rename_validation": {},
 		"bad_ingest_pipeline": {
 			"test": []string{
-				"field processors.1: Additional property reroute is not allowed",
-				"field processors.2.foreach.processor: Additional property paint is not allowed",
+				"field processors.1: Additional property reroute is not allowed (JSE00003)",
+				"field processors.2.foreach.processor: Additional property paint is not allowed (JSE00003)",
 			},
 			"bad_rename_message": []string{
 				"field processors.1.rename: rename \"message\" to \"event.original\" processor requires if: 'ctx.event?.original == null' (JSE00001)",
@@ -1178,7 +1186,7 @@ func TestValidateIngestPipelines(t *testing.T) {
 		"bad_pipeline_tags": {
 			"example": []string{
 				"set processor at line 4 missing required tag (SVR00006)",
-				"set processor at line 15 has duplicate tag value: \"set_sample_field\"",
+				"set processor at line 15 has duplicate tag value: \"set_sample_field\" (SVR00006)",
 			},
 		},
 		"bad_pipeline_on_failure": {
@@ -1248,7 +1256,7 @@ func TestValidateForbiddenDataStreamName(t *testing.T) {
 	require.Error(t, err)
 
 	expectedErrorMessages := []string{
-		fmt.Sprintf(`item [integration] is not allowed in folder [%s]`, path.Join(pkgPath, "data_stream")),
+		fmt.Sprintf(`item [integration] is not allowed in folder [%s] (PSR00005)`, path.Join(pkgPath, "data_stream")),
 	}
 	errs, ok := err.(specerrors.ValidationErrors)
 	require.True(t, ok)
@@ -1274,9 +1282,9 @@ func TestLinksAreBlocked(t *testing.T) {
 
 func TestValidateHandlebarsFiles(t *testing.T) {
 	tests := map[string]string{
-		"bad_input_hbs":              "invalid handlebars template: error validating agent/input/input.yml.hbs: Parse error on line 10:\nExpecting OpenEndBlock, got: 'EOF'",
-		"bad_integration_hbs":        "invalid handlebars template: error validating data_stream/foo/agent/stream/filestream.yml.hbs: Parse error on line 43:\nExpecting OpenEndBlock, got: 'EOF'",
-		"bad_integration_hbs_linked": "invalid handlebars template: error validating ../bad_integration_hbs/data_stream/foo/agent/stream/filestream.yml.hbs: Parse error on line 43:\nExpecting OpenEndBlock, got: 'EOF'",
+		"bad_input_hbs":              "invalid handlebars template: error validating agent/input/input.yml.hbs: Parse error on line 10:\nExpecting OpenEndBlock, got: 'EOF' (SVR00041)",
+		"bad_integration_hbs":        "invalid handlebars template: error validating data_stream/foo/agent/stream/filestream.yml.hbs: Parse error on line 43:\nExpecting OpenEndBlock, got: 'EOF' (SVR00041)",
+		"bad_integration_hbs_linked": "invalid handlebars template: error validating ../bad_integration_hbs/data_stream/foo/agent/stream/filestream.yml.hbs: Parse error on line 43:\nExpecting OpenEndBlock, got: 'EOF' (SVR00041)",
 	}
 
 	for pkgName, expectedErrorMessage := range tests {
@@ -1587,6 +1595,408 @@ func TestWithWarningsAsErrors_option(t *testing.T) {
 	})
 }
 
+func TestWithSeverities_option(t *testing.T) {
+	t.Setenv("PACKAGE_SPEC_WARNINGS_AS_ERRORS", "false")
+	pkgPath := filepath.Join(t.TempDir(), "visualizations_by_reference")
+	err := cp.Copy(filepath.Join("..", "..", "..", "..", "test", "packages", "visualizations_by_reference"), pkgPath)
+	require.NoError(t, err)
+
+	findingsAt := func(t *testing.T, pkgPath string, opts ...Option) specerrors.ValidationErrors {
+		t.Helper()
+		v, err := New(LegacyMode, opts...)
+		require.NoError(t, err)
+		err = v.ValidateFromPath(pkgPath)
+		if err == nil {
+			return nil
+		}
+		var errs specerrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		return errs
+	}
+	findings := func(t *testing.T, opts ...Option) specerrors.ValidationErrors {
+		t.Helper()
+		return findingsAt(t, pkgPath, opts...)
+	}
+
+	t.Run("warnings_not_returned_by_default", func(t *testing.T) {
+		assert.Empty(t, findings(t))
+	})
+
+	t.Run("all_severities", func(t *testing.T) {
+		errs := findings(t, WithMinimumSeverity(specerrors.SeverityInfo))
+		require.NotEmpty(t, errs)
+		assert.Empty(t, errs.AtLeast(specerrors.SeverityError))
+	})
+
+	t.Run("minimum_severity", func(t *testing.T) {
+		errs := findings(t, WithMinimumSeverity(specerrors.SeverityWarning))
+		require.NotEmpty(t, errs)
+		for _, err := range errs {
+			assert.Equal(t, specerrors.CodeVisualizationByValue, err.Code())
+			assert.Equal(t, specerrors.SeverityWarning, specerrors.SeverityOf(err))
+		}
+	})
+
+	t.Run("raise_severity", func(t *testing.T) {
+		errs := findings(t, WithSeverities(map[string]specerrors.Severity{
+			specerrors.CodeVisualizationByValue: specerrors.SeverityError,
+		}))
+		require.NotEmpty(t, errs)
+		for _, err := range errs {
+			assert.Equal(t, specerrors.CodeVisualizationByValue, err.Code())
+			assert.Equal(t, specerrors.SeverityError, specerrors.SeverityOf(err))
+		}
+	})
+
+	t.Run("config_file_takes_precedence", func(t *testing.T) {
+		// Severities in validation.yml are supported since spec 3.7.0.
+		pkgPath := filepath.Join(t.TempDir(), "good_scoped_exclusions")
+		err := cp.Copy(filepath.Join("..", "..", "..", "..", "test", "packages", "good_scoped_exclusions"), pkgPath)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(pkgPath, "validation.yml"), []byte("errors:\n  severity:\n    SVR00006: info\n"), 0o644)
+		require.NoError(t, err)
+
+		errs := findingsAt(t, pkgPath, WithMinimumSeverity(specerrors.SeverityWarning), WithSeverities(map[string]specerrors.Severity{
+			specerrors.CodePipelineTagRequired: specerrors.SeverityError,
+		}))
+		assert.Empty(t, errs)
+
+		errs = findingsAt(t, pkgPath, WithMinimumSeverity(specerrors.SeverityInfo))
+		require.NotEmpty(t, errs)
+		for _, err := range errs {
+			assert.Equal(t, specerrors.CodePipelineTagRequired, err.Code())
+			assert.Equal(t, specerrors.SeverityInfo, specerrors.SeverityOf(err))
+		}
+	})
+
+	t.Run("invalid_severity", func(t *testing.T) {
+		_, err := New(LegacyMode, WithMinimumSeverity("critical"))
+		assert.ErrorContains(t, err, `invalid minimum severity "critical"`)
+	})
+}
+
+func TestWithLogger_option(t *testing.T) {
+	t.Setenv("PACKAGE_SPEC_WARNINGS_AS_ERRORS", "false")
+	pkgRootPath := filepath.Join("..", "..", "..", "..", "test", "packages", "visualizations_by_reference")
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	v, err := New(SourceMode, WithLogger(logger))
+	require.NoError(t, err)
+	require.NoError(t, v.ValidateFromPath(pkgRootPath))
+
+	assert.Contains(t, buf.String(), `level=DEBUG msg="validating package"`)
+	assert.Contains(t, buf.String(), `level=WARN msg="validation mode 'source' is in technical preview (PSR00011)" code=PSR00011`)
+	assert.Contains(t, buf.String(), "code=SVR00004")
+
+	v, err = New(SourceMode, WithLogger(nil))
+	require.NoError(t, err)
+	require.NoError(t, v.ValidateFromPath(pkgRootPath))
+}
+
+func TestWithStats_option(t *testing.T) {
+	pkgRootPath := filepath.Join("..", "..", "..", "..", "test", "packages", "bad_pipeline_tags")
+
+	customRule := Rule{
+		Validate: func(ctx context.Context, pkg *packages.Package) specerrors.ValidationErrors {
+			return nil
+		},
+	}
+	var stats []Stats
+	v, err := New(LegacyMode, WithConcurrency(4), WithRules(customRule), WithStats(func(s Stats) {
+		stats = append(stats, s)
+	}))
+	require.NoError(t, err)
+	err = v.ValidateFromPath(pkgRootPath)
+	var errs specerrors.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, stats, 1)
+
+	result := stats[0]
+	assert.Positive(t, result.Duration)
+
+	require.NotEmpty(t, result.Folders)
+	assert.Equal(t, ".", result.Folders[0].Path)
+	assert.Positive(t, result.Folders[0].FilesRead)
+
+	require.NotEmpty(t, result.Rules)
+	rules := make(map[string]RuleStats)
+	for _, rule := range result.Rules {
+		rules[rule.Name] = rule
+	}
+	assert.Equal(t, specerrors.CodePipelineTagRequired, rules["semantic.ValidatePipelineTags"].Code)
+	assert.Positive(t, rules["semantic.ValidatePipelineTags"].Findings)
+	assert.Positive(t, rules["semantic.ValidateVersionIntegrity"].FilesRead)
+	assert.Positive(t, result.ModelFilesRead)
+	assert.Contains(t, rules, "validator.TestWithStats_option")
+
+	findings := 0
+	for _, folder := range result.Folders {
+		findings += folder.Findings
+	}
+	for _, rule := range result.Rules {
+		findings += rule.Findings
+	}
+	assert.Equal(t, len(errs), findings)
+}
+
+func TestValidateContext(t *testing.T) {
+	goodPkg := filepath.Join("..", "..", "..", "..", "test", "packages", "good")
+	zipPath := writePackageZip(t, goodPkg, "good")
+
+	v, err := New(LegacyMode)
+	require.NoError(t, err)
+
+	t.Run("not_canceled", func(t *testing.T) {
+		require.NoError(t, v.ValidateFromPathContext(t.Context(), goodPkg))
+		require.NoError(t, v.ValidateFromZipContext(t.Context(), zipPath))
+		require.NoError(t, v.ValidateFromFSContext(t.Context(), goodPkg, os.DirFS(goodPkg)))
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		assert.ErrorIs(t, v.ValidateFromPathContext(ctx, goodPkg), context.Canceled)
+		assert.ErrorIs(t, v.ValidateFromZipContext(ctx, zipPath), context.Canceled)
+		assert.ErrorIs(t, v.ValidateFromFSContext(ctx, goodPkg, os.DirFS(goodPkg)), context.Canceled)
+	})
+
+	t.Run("deadline_exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), 0)
+		defer cancel()
+
+		assert.ErrorIs(t, v.ValidateFromPathContext(ctx, goodPkg), context.DeadlineExceeded)
+	})
+}
+
+func TestWithConcurrency_option(t *testing.T) {
+	packagesPath := filepath.Join("..", "..", "..", "..", "test", "packages")
+	sequential, err := New(LegacyMode)
+	require.NoError(t, err)
+	parallel, err := New(LegacyMode, WithConcurrency(4))
+	require.NoError(t, err)
+
+	for _, pkgName := range []string{"good_v3", "bad_pipeline_tags", "bad_duplicated_fields", "bad_kibana_ids"} {
+		t.Run(pkgName, func(t *testing.T) {
+			pkgPath := filepath.Join(packagesPath, pkgName)
+			expected := sequential.ValidateFromPath(pkgPath)
+			for range 3 {
+				assert.Equal(t, expected, parallel.ValidateFromPath(pkgPath))
+			}
+		})
+	}
+}
+
+func TestValidateConcurrently(t *testing.T) {
+	packagesPath := filepath.Join("..", "..", "..", "..", "test", "packages")
+	// These packages use formats that depend on the package being validated.
+	pkgNames := []string{"good_v3", "missing_image_files", "input_groups", "input_groups_bad_data_stream"}
+
+	messages := func(err error) []string {
+		var errs specerrors.ValidationErrors
+		if !errors.As(err, &errs) {
+			return nil
+		}
+		var result []string
+		for _, vErr := range errs {
+			result = append(result, vErr.Error())
+		}
+		return result
+	}
+
+	expected := make(map[string][]string)
+	for _, pkgName := range pkgNames {
+		expected[pkgName] = messages(ValidateFromPath(filepath.Join(packagesPath, pkgName)))
+	}
+	require.NotEmpty(t, expected["missing_image_files"])
+	require.NotEmpty(t, expected["input_groups_bad_data_stream"])
+
+	for i := range 4 * len(pkgNames) {
+		pkgName := pkgNames[i%len(pkgNames)]
+		t.Run(fmt.Sprintf("%s_%d", pkgName, i), func(t *testing.T) {
+			t.Parallel()
+			err := ValidateFromPath(filepath.Join(packagesPath, pkgName))
+			assert.ElementsMatch(t, expected[pkgName], messages(err))
+		})
+	}
+}
+
+func TestWithRuleTimeout_option(t *testing.T) {
+	pkgPath := filepath.Join("..", "..", "..", "..", "test", "packages", "good_v3")
+	slow := Rule{
+		Name: "slow",
+		Validate: func(ctx context.Context, pkg *packages.Package) specerrors.ValidationErrors {
+			<-ctx.Done()
+			return nil
+		},
+	}
+
+	v, err := New(LegacyMode, WithRules(slow), WithRuleTimeout(200*time.Millisecond))
+	require.NoError(t, err)
+	err = v.ValidateFromPath(pkgPath)
+	var errs specerrors.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	timeouts, _ := errs.Collect(func(err specerrors.ValidationError) bool {
+		return err.Code() == specerrors.CodeRuleTimeout
+	})
+	require.Len(t, timeouts, 1)
+	assert.Equal(t, "semantic rule slow reached the timeout of 200ms, its results can be incomplete (PSR00012)", timeouts[0].Error())
+	assert.Equal(t, specerrors.SeverityError, specerrors.SeverityOf(timeouts[0]))
+}
+
+func TestWithRules_option(t *testing.T) {
+	pkgPath := filepath.Join("..", "..", "..", "..", "test", "packages", "good_v3")
+
+	ownerTeam := Rule{
+		Validate: func(ctx context.Context, pkg *packages.Package) specerrors.ValidationErrors {
+			manifest, err := pkg.Manifest()
+			if err != nil {
+				return specerrors.ValidationErrors{specerrors.NewStructuredError(err, "")}
+			}
+			if !strings.HasPrefix(manifest.Owner.Github, "elastic/team-") {
+				return specerrors.ValidationErrors{
+					specerrors.NewStructuredErrorf("file \"%s\" is invalid: owner %q is not a team", pkg.Path("manifest.yml"), manifest.Owner.Github).WithFile("manifest.yml"),
+				}
+			}
+			return nil
+		},
+	}
+	sampleEvents := Rule{
+		Validate: func(ctx context.Context, pkg *packages.Package) specerrors.ValidationErrors {
+			dataStreams, err := pkg.DataStreams()
+			if err != nil {
+				return specerrors.ValidationErrors{specerrors.NewStructuredError(err, "")}
+			}
+			var errs specerrors.ValidationErrors
+			for _, dataStream := range dataStreams {
+				_, err := fs.Stat(pkg, path.Join(dataStream.Path, "sample_event.json"))
+				if errors.Is(err, fs.ErrNotExist) {
+					errs = append(errs, specerrors.NewStructuredErrorf("data stream %q has no sample event", dataStream.Name))
+				}
+			}
+			return errs
+		},
+	}
+
+	cases := []struct {
+		title    string
+		rules    []Rule
+		expected []string
+	}{
+		{
+			title: "no rules",
+		},
+		{
+			title:    "rule failing",
+			rules:    []Rule{ownerTeam},
+			expected: []string{`file "` + pkgPath + `/manifest.yml" is invalid: owner "elastic/foobar" is not a team`},
+		},
+		{
+			title: "rule for other package types",
+			rules: []Rule{{Validate: ownerTeam.Validate, Types: []string{"input", "content"}}},
+		},
+		{
+			title: "rule for newer versions",
+			rules: []Rule{{Validate: ownerTeam.Validate, Since: semver.MustParse("99.0.0")}},
+		},
+		{
+			title: "rule for older versions",
+			rules: []Rule{{Validate: ownerTeam.Validate, Until: semver.MustParse("3.0.0")}},
+		},
+		{
+			title: "rule for other modes",
+			rules: []Rule{{Validate: ownerTeam.Validate, Modes: []Mode{BuildMode}}},
+		},
+		{
+			title: "multiple rules",
+			rules: []Rule{ownerTeam, sampleEvents},
+			expected: []string{
+				`file "` + pkgPath + `/manifest.yml" is invalid: owner "elastic/foobar" is not a team`,
+				`data stream "agent_settings" has no sample event`,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			v, err := New(LegacyMode, WithRules(c.rules...))
+			require.NoError(t, err)
+
+			err = v.ValidateFromPath(pkgPath)
+			if len(c.expected) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var errs specerrors.ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			var messages []string
+			for _, e := range errs {
+				messages = append(messages, e.Error())
+			}
+			assert.Subset(t, messages, c.expected)
+		})
+	}
+}
+
+func TestWithUnusedExclusions_option(t *testing.T) {
+	pkgPath := filepath.Join(t.TempDir(), "good_scoped_exclusions")
+	err := cp.Copy(filepath.Join("..", "..", "..", "..", "test", "packages", "good_scoped_exclusions"), pkgPath)
+	require.NoError(t, err)
+
+	f, err := os.OpenFile(filepath.Join(pkgPath, "validation.yml"), os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString("    - code: SVR00002\n      reason: Not used.\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	v, err := New(LegacyMode)
+	require.NoError(t, err)
+	err = v.ValidateFromPath(pkgPath)
+	var errs specerrors.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	for _, e := range errs {
+		assert.NotEqual(t, specerrors.CodeUnusedExclusion, e.Code())
+	}
+
+	v, err = New(LegacyMode, WithUnusedExclusions(true))
+	require.NoError(t, err)
+	err = v.ValidateFromPath(pkgPath)
+	require.ErrorAs(t, err, &errs)
+	var unused specerrors.ValidationErrors
+	for _, e := range errs {
+		if e.Code() == specerrors.CodeUnusedExclusion {
+			unused = append(unused, e)
+		}
+	}
+	require.Len(t, unused, 1)
+	assert.Equal(t, `file "validation.yml" is invalid: exclusion of SVR00002 is not used, no validation error was excluded (PSR00004)`, unused[0].Error())
+	assert.Equal(t, 8, unused[0].(specerrors.ValidationPositionError).Line())
+}
+
+func TestValidationConfigVersion(t *testing.T) {
+	pkgPath := filepath.Join(t.TempDir(), "good_scoped_exclusions")
+	err := cp.Copy(filepath.Join("..", "..", "..", "..", "test", "packages", "good_scoped_exclusions"), pkgPath)
+	require.NoError(t, err)
+	manifestPath := filepath.Join(pkgPath, "manifest.yml")
+	manifest, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	manifest = bytes.Replace(manifest, []byte("format_version: 3.7.0"), []byte("format_version: 3.6.0"), 1)
+	require.NoError(t, os.WriteFile(manifestPath, manifest, 0o644))
+
+	// Exclusions and severities in validation.yml are supported since spec 3.7.0.
+	err = ValidateFromPath(pkgPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `validation.yml" is invalid: field errors: Additional property exclusions is not allowed (JSE00003)`)
+
+	err = os.WriteFile(filepath.Join(pkgPath, "validation.yml"), []byte("errors:\n  severity:\n    SVR00006: info\n"), 0o644)
+	require.NoError(t, err)
+	err = ValidateFromPath(pkgPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `validation.yml" is invalid: field errors: Additional property severity is not allowed (JSE00003)`)
+}
+
 func TestBuildModeValidation(t *testing.T) {
 	basePath := filepath.Join("..", "..", "..", "..", "test", "built_packages")
 	tests := map[string]struct {
@@ -1598,7 +2008,7 @@ func TestBuildModeValidation(t *testing.T) {
 		},
 		"bad_built_missing_input": {
 			// Caught by schema oneOf (input|package), not the semantic layer.
-			expectedErrContains: []string{"streams.0: input is required"},
+			expectedErrContains: []string{"streams.0: input is required (JSE00002)"},
 		},
 		"bad_built_stream_package": {
 			expectedErrContains: []string{"stream[0] has 'package:' which is source-only"},
@@ -1655,3 +2065,231 @@ func TestLegacyPreservation_FromPath(t *testing.T) {
 	err = v.ValidateFromPath(pkgPath)
 	require.NoError(t, err)
 }
+
+func TestValidateErrorsLocation(t *testing.T) {
+	testPackagesPath := path.Join("..", "..", "..", "..", "test", "packages")
+	entries, err := os.ReadDir(testPackagesPath)
+	require.NoError(t, err)
+
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), "bad_") {
+			continue
+		}
+		t.Run(entry.Name(), func(t *testing.T) {
+			t.Parallel()
+			errs := ValidateFromPath(path.Join(testPackagesPath, entry.Name()))
+			var vErrs specerrors.ValidationErrors
+			if !errors.As(errs, &vErrs) {
+				return
+			}
+			for _, vErr := range vErrs {
+				located, ok := vErr.(interface{ File() string })
+				require.True(t, ok, "error without location: %s", vErr)
+				assert.NotEmpty(t, located.File(), "error without file: %s", vErr)
+			}
+		})
+	}
+}
+
+func TestValidateErrorsCode(t *testing.T) {
+	testPackagesPath := path.Join("..", "..", "..", "..", "test", "packages")
+	entries, err := os.ReadDir(testPackagesPath)
+	require.NoError(t, err)
+
+	validator, err := New(LegacyMode, WithWarningsAsErrors(true))
+	require.NoError(t, err)
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		t.Run(entry.Name(), func(t *testing.T) {
+			t.Parallel()
+			errs := validator.ValidateFromPath(path.Join(testPackagesPath, entry.Name()))
+			var vErrs specerrors.ValidationErrors
+			if !errors.As(errs, &vErrs) {
+				return
+			}
+			for _, vErr := range vErrs {
+				require.NotEmpty(t, vErr.Code(), "error without code: %s", vErr)
+				_, found := specerrors.LookupCode(vErr.Code())
+				assert.True(t, found, "error with unknown code: %s", vErr)
+			}
+		})
+	}
+}
+
+func TestValidateSchemaErrors(t *testing.T) {
+	pkgPath := path.Join("..", "..", "..", "..", "test", "packages", "bad_ingest_pipeline")
+	errs := ValidateFromPath(pkgPath)
+	var vErrs specerrors.ValidationErrors
+	require.ErrorAs(t, errs, &vErrs)
+
+	type schemaFinding struct {
+		code       string
+		keyword    string
+		field      string
+		schemaPath string
+		details    map[string]any
+	}
+	var findings []schemaFinding
+	for _, vErr := range vErrs {
+		var schemaErr *specerrors.SchemaError
+		if !errors.As(vErr, &schemaErr) {
+			continue
+		}
+		finding := schemaFinding{
+			code:       vErr.Code(),
+			keyword:    schemaErr.Keyword,
+			field:      schemaErr.Field,
+			schemaPath: strings.TrimPrefix(schemaErr.SchemaPath, "integration/elasticsearch/pipeline.spec.yml#"),
+		}
+		for _, name := range []string{"property", "allowed"} {
+			if value, found := schemaErr.Details[name]; found {
+				finding.details = map[string]any{name: value}
+			}
+		}
+		findings = append(findings, finding)
+	}
+
+	assert.ElementsMatch(t, []schemaFinding{
+		{code: "JSE00001", keyword: "required", field: "processors.1.rename", schemaPath: "/definitions/processors/then/allOf/0/contains/properties/rename/required", details: map[string]any{"property": "if"}},
+		{code: "JSE00001", keyword: "required", field: "processors.0", schemaPath: "/definitions/processors/then/allOf/1/contains/required", details: map[string]any{"property": "remove"}},
+		{code: "JSE00001", keyword: "const", field: "processors.2.remove.field", schemaPath: "/definitions/processors/then/allOf/1/contains/properties/remove/properties/field/oneOf/0/const", details: map[string]any{"allowed": `"message"`}},
+		{code: "JSE00001", keyword: "const", field: "processors.2.remove.if", schemaPath: "/definitions/processors/then/allOf/1/contains/properties/remove/properties/if/const", details: map[string]any{"allowed": `"ctx.event?.original != null"`}},
+		{code: "JSE00003", keyword: "additional_property_not_allowed", field: "processors.1", schemaPath: "/definitions/processor/additionalProperties", details: map[string]any{"property": "reroute"}},
+		{code: "JSE00003", keyword: "additional_property_not_allowed", field: "processors.2.foreach.processor", schemaPath: "/definitions/processor/additionalProperties", details: map[string]any{"property": "paint"}},
+	}, findings)
+}
+
+func TestValidateApplyFixes(t *testing.T) {
+	tests := []string{
+		"bad_pipeline_tags",
+		"bad_pipeline_on_failure",
+		"missing_pipeline_dashes",
+	}
+
+	validator, err := New(LegacyMode)
+	require.NoError(t, err)
+
+	validationErrors := func(t *testing.T, pkgPath string) specerrors.ValidationErrors {
+		var errs specerrors.ValidationErrors
+		if err := validator.ValidateFromPath(pkgPath); err != nil {
+			require.ErrorAs(t, err, &errs)
+		}
+		return errs
+	}
+
+	for _, pkgName := range tests {
+		t.Run(pkgName, func(t *testing.T) {
+			pkgPath := t.TempDir()
+			err := os.CopyFS(pkgPath, os.DirFS(path.Join("..", "..", "..", "..", "test", "packages", pkgName)))
+			require.NoError(t, err)
+
+			errs := validationErrors(t, pkgPath)
+			initialErrors := len(errs)
+
+			// Overlapping fixes are applied in later passes.
+			for len(errs) > 0 {
+				result, err := specerrors.ApplyFixes(pkgPath, errs)
+				require.NoError(t, err)
+				if len(result.Applied) == 0 {
+					break
+				}
+				errs = validationErrors(t, pkgPath)
+			}
+
+			assert.Less(t, len(errs), initialErrors)
+			for _, err := range errs {
+				assert.Nil(t, err.(specerrors.ValidationFixError).Fix(), "unapplied fix: %s", err)
+			}
+		})
+	}
+}
+
+func TestValidateChangelogEntryFix(t *testing.T) {
+	pkgPath := t.TempDir()
+	err := os.CopyFS(pkgPath, os.DirFS(path.Join("..", "..", "..", "..", "test", "packages", "good_v3")))
+	require.NoError(t, err)
+	manifestPath := filepath.Join(pkgPath, "manifest.yml")
+	manifest, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	manifest = regexp.MustCompile(`(?m)^version: .*$`).ReplaceAll(manifest, []byte("version: 99.0.0"))
+	require.NoError(t, os.WriteFile(manifestPath, manifest, 0644))
+
+	validator, err := New(LegacyMode, WithMinimumSeverity(specerrors.SeverityError))
+	require.NoError(t, err)
+	var errs specerrors.ValidationErrors
+	require.ErrorAs(t, validator.ValidateFromPath(pkgPath), &errs)
+	require.Len(t, errs, 1)
+	assert.Equal(t, specerrors.CodeVersionIntegrity, errs[0].Code())
+
+	result, err := specerrors.ApplyFixes(pkgPath, errs)
+	require.NoError(t, err)
+	require.Len(t, result.Applied, 1)
+
+	// The added entry needs to be completed, so the package is still invalid.
+	errs = nil
+	require.ErrorAs(t, validator.ValidateFromPath(pkgPath), &errs)
+	require.NotEmpty(t, errs)
+	for _, err := range errs {
+		assert.NotEqual(t, specerrors.CodeVersionIntegrity, err.Code())
+	}
+	assert.Contains(t, errs.Error(), "description is required")
+	assert.Contains(t, errs.Error(), "link is required")
+}
+
+func TestValidateErrorsPosition(t *testing.T) {
+	type location struct {
+		file   string
+		line   int
+		column int
+	}
+	tests := map[string]map[string]location{
+		"bad_ingest_pipeline": {
+			"field processors.1: Additional property reroute is not allowed (JSE00003)": {
+				file:   "data_stream/test/elasticsearch/ingest_pipeline/default.yml",
+				line:   7,
+				column: 3,
+			},
+			"field processors.2.foreach.processor: Additional property paint is not allowed (JSE00003)": {
+				file:   "data_stream/test/elasticsearch/ingest_pipeline/default.yml",
+				line:   14,
+				column: 7,
+			},
+		},
+		"bad_pipeline_tags": {
+			"set processor at line 4 missing required tag (SVR00006)": {
+				file:   "data_stream/example/elasticsearch/ingest_pipeline/default.yml",
+				line:   4,
+				column: 5,
+			},
+		},
+	}
+
+	for pkgName, expected := range tests {
+		t.Run(pkgName, func(t *testing.T) {
+			t.Parallel()
+			errs := ValidateFromPath(path.Join("..", "..", "..", "..", "test", "packages", pkgName))
+			var vErrs specerrors.ValidationErrors
+			require.ErrorAs(t, errs, &vErrs)
+
+			found := make(map[string]location)
+			for _, vErr := range vErrs {
+				for suffix := range expected {
+					if !strings.HasSuffix(vErr.Error(), suffix) {
+						continue
+					}
+					structuredErr, ok := vErr.(*specerrors.StructuredError)
+					require.True(t, ok)
+					found[suffix] = location{
+						file:   structuredErr.File(),
+						line:   structuredErr.Line(),
+						column: structuredErr.Column(),
+					}
+				}
+			}
+			assert.Equal(t, expected, found)
+		})
+	}
+}